@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	quoteUseCase "erp-api/internal/usecase/quote"
+)
+
+// startQuoteExpiration expira periodicamente os orçamentos pendentes com validade vencida.
+// O intervalo vem de QUOTE_EXPIRATION_INTERVAL (padrão 1h).
+func startQuoteExpiration(ctx context.Context, quotes quoteUseCase.UseCaseInterface) {
+	interval, err := time.ParseDuration(os.Getenv("QUOTE_EXPIRATION_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+
+	run := func() {
+		expired, err := quotes.ExpireOverdue(ctx, time.Now())
+		if err != nil {
+			log.Printf("Quote expiration failed: %v", err)
+			return
+		}
+		if expired > 0 {
+			log.Printf("Quote expiration: %d quotes expired", expired)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -10,11 +10,19 @@ import (
 	"time"
 
 	"erp-api/infrastructure/ioc"
+	"erp-api/internal/delivery/http/appointment"
 	"erp-api/internal/delivery/http/client"
+	"erp-api/internal/delivery/http/deliveryzone"
+	"erp-api/internal/delivery/http/order"
 	"erp-api/internal/delivery/http/product"
+	"erp-api/internal/delivery/http/production"
 	"erp-api/internal/delivery/http/quote"
+	"erp-api/internal/delivery/http/quotetemplate"
 	"erp-api/internal/delivery/http/reports"
+	"erp-api/internal/delivery/http/service"
 	settingsHandler "erp-api/internal/delivery/http/settings"
+	"erp-api/internal/delivery/http/slab"
+	"erp-api/internal/delivery/http/stock"
 	"erp-api/internal/delivery/http/tenant"
 	"erp-api/internal/delivery/http/user"
 	"erp-api/internal/infra/container"
@@ -38,6 +46,10 @@ func main() {
 
 	router := setupRouter(appContainer)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	startQuoteExpiration(jobsCtx, appContainer.GetQuoteUseCase())
+
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
 		port = "8080"
@@ -60,6 +72,7 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -135,6 +148,39 @@ func setupRouter(container *container.Container) *gin.Engine {
 			products.DELETE("/:id", authMiddleware.Authenticate(), product.NewHandler(container.GetProductUseCase()).Delete)
 			products.GET("", authMiddleware.Authenticate(), product.NewHandler(container.GetProductUseCase()).List)
 			products.GET("/count", authMiddleware.Authenticate(), product.NewHandler(container.GetProductUseCase()).Count)
+			products.GET("/:id/stock-movements", authMiddleware.Authenticate(), stock.NewHandler(container.GetStockUseCase()).List)
+			products.POST("/:id/stock-movements", authMiddleware.Authenticate(), stock.NewHandler(container.GetStockUseCase()).Create)
+			products.POST("/:id/stock/reconcile", authMiddleware.Authenticate(), stock.NewHandler(container.GetStockUseCase()).Reconcile)
+		}
+
+		slabs := api.Group("/slabs")
+		{
+			slabs.POST("", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).Create)
+			slabs.GET("/:id", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).GetByID)
+			slabs.PUT("/:id", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).Update)
+			slabs.DELETE("/:id", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).Delete)
+			slabs.GET("", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).List)
+			slabs.POST("/:id/photos", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).AddPhoto)
+			slabs.DELETE("/:id/photos/:photoId", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).DeletePhoto)
+			slabs.POST("/:id/remnants", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).RegisterRemnants)
+		}
+
+		services := api.Group("/services")
+		{
+			services.POST("", authMiddleware.Authenticate(), service.NewHandler(container.GetServiceUseCase()).Create)
+			services.GET("/:id", authMiddleware.Authenticate(), service.NewHandler(container.GetServiceUseCase()).GetByID)
+			services.PUT("/:id", authMiddleware.Authenticate(), service.NewHandler(container.GetServiceUseCase()).Update)
+			services.DELETE("/:id", authMiddleware.Authenticate(), service.NewHandler(container.GetServiceUseCase()).Delete)
+			services.GET("", authMiddleware.Authenticate(), service.NewHandler(container.GetServiceUseCase()).List)
+		}
+
+		deliveryZones := api.Group("/delivery-zones")
+		{
+			deliveryZones.POST("", authMiddleware.Authenticate(), deliveryzone.NewHandler(container.GetDeliveryZoneUseCase()).Create)
+			deliveryZones.GET("/:id", authMiddleware.Authenticate(), deliveryzone.NewHandler(container.GetDeliveryZoneUseCase()).GetByID)
+			deliveryZones.PUT("/:id", authMiddleware.Authenticate(), deliveryzone.NewHandler(container.GetDeliveryZoneUseCase()).Update)
+			deliveryZones.DELETE("/:id", authMiddleware.Authenticate(), deliveryzone.NewHandler(container.GetDeliveryZoneUseCase()).Delete)
+			deliveryZones.GET("", authMiddleware.Authenticate(), deliveryzone.NewHandler(container.GetDeliveryZoneUseCase()).List)
 		}
 
 		quotes := api.Group("/quotes")
@@ -145,7 +191,88 @@ func setupRouter(container *container.Container) *gin.Engine {
 			quotes.DELETE("/:id", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).Delete)
 			quotes.GET("", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).List)
 			quotes.GET("/count", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).Count)
+			quotes.GET("/analytics/conversion", authMiddleware.Authenticate(), quote.NewAnalyticsHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetUserUseCase()).Conversion)
+			quotes.GET("/analytics/profitability", authMiddleware.Authenticate(), quote.NewAnalyticsHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetUserUseCase()).Profitability)
 			quotes.PUT("/:id/status", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).UpdateStatus)
+			quotes.POST("/:id/discount-approval", authMiddleware.RequireAnyRole("admin", "manager"), quote.NewHandler(container.GetQuoteUseCase()).ApproveDiscount)
+			quotes.POST("/:id/renew", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).Renew)
+			quotes.POST("/:id/duplicate", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).Duplicate)
+			quotes.POST("/:id/freight", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).CalculateFreight)
+			quotes.POST("/:id/cutting-plan", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).CuttingPlan)
+			quotes.GET("/:id/history", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).History)
+			quotes.POST("/:id/items", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).AddItem)
+			quotes.PUT("/:id/items/:itemId", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).UpdateItem)
+			quotes.DELETE("/:id/items/:itemId", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).DeleteItem)
+			quotes.POST("/:id/options", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).AddOption)
+			quotes.PUT("/:id/options/:optionId", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).UpdateOption)
+			quotes.DELETE("/:id/options/:optionId", authMiddleware.Authenticate(), quote.NewHandler(container.GetQuoteUseCase()).DeleteOption)
+			quotes.POST("/:id/share", authMiddleware.Authenticate(), quote.NewPublicHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetProductUseCase(), container.GetSettingsUseCase(), container.GetShareTokenManager(), container.GetShareBaseURL()).CreateShareLink)
+			quotes.GET("/:id/pdf", authMiddleware.Authenticate(), reports.NewProposalHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetProductUseCase(), container.GetSettingsUseCase()).QuotePDF)
+			quotes.GET("/:id/drawings", authMiddleware.Authenticate(), reports.NewDrawingHandler(container.GetQuoteUseCase(), container.GetProductUseCase()).QuoteDrawings)
+			quotes.GET("/:id/items/:itemId/remnants", authMiddleware.Authenticate(), slab.NewHandler(container.GetSlabUseCase()).SearchRemnants)
+		}
+
+		quoteTemplates := api.Group("/quote-templates")
+		{
+			quoteTemplates.POST("", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).Create)
+			quoteTemplates.GET("/:id", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).GetByID)
+			quoteTemplates.PUT("/:id", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).Update)
+			quoteTemplates.DELETE("/:id", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).Delete)
+			quoteTemplates.GET("", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).List)
+			quoteTemplates.POST("/:id/quotes", authMiddleware.Authenticate(), quotetemplate.NewHandler(container.GetQuoteTemplateUseCase()).Instantiate)
+		}
+
+		orders := api.Group("/orders")
+		{
+			orders.POST("", authMiddleware.Authenticate(), order.NewHandler(container.GetOrderUseCase()).Create)
+			orders.GET("/:id", authMiddleware.Authenticate(), order.NewHandler(container.GetOrderUseCase()).GetByID)
+			orders.GET("", authMiddleware.Authenticate(), order.NewHandler(container.GetOrderUseCase()).List)
+			orders.PUT("/:id/status", authMiddleware.Authenticate(), order.NewHandler(container.GetOrderUseCase()).UpdateStatus)
+		}
+
+		productionRoutes := api.Group("/production")
+		{
+			productionRoutes.POST("/work-orders", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).Create)
+			productionRoutes.GET("/work-orders/:id", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).GetByID)
+			productionRoutes.GET("/work-orders", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).List)
+			productionRoutes.POST("/work-orders/:id/start", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).Start)
+			productionRoutes.POST("/work-orders/:id/complete", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).Complete)
+			productionRoutes.PUT("/work-orders/:id/assignee", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).Assign)
+			productionRoutes.GET("/board", authMiddleware.Authenticate(), production.NewHandler(container.GetProductionUseCase()).Board)
+		}
+
+		teams := api.Group("/teams")
+		{
+			teams.POST("", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).Create)
+			teams.GET("/:id", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).GetByID)
+			teams.PUT("/:id", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).Update)
+			teams.DELETE("/:id", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).Delete)
+			teams.GET("", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).List)
+			teams.GET("/:id/feed", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).Feed)
+			teams.POST("/:id/feed", authMiddleware.Authenticate(), appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).RotateFeed)
+		}
+
+		appointments := api.Group("/appointments")
+		{
+			appointments.POST("", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).Create)
+			appointments.GET("/:id", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).GetByID)
+			appointments.PUT("/:id", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).Update)
+			appointments.DELETE("/:id", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).Delete)
+			appointments.GET("", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).List)
+			appointments.PUT("/:id/status", authMiddleware.Authenticate(), appointment.NewHandler(container.GetAppointmentUseCase()).UpdateStatus)
+		}
+
+		// Link público do orçamento: o token assinado substitui a autenticação
+		publicQuotes := api.Group("/public/quotes")
+		{
+			publicQuotes.GET("/:token", quote.NewPublicHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetProductUseCase(), container.GetSettingsUseCase(), container.GetShareTokenManager(), container.GetShareBaseURL()).Get)
+			publicQuotes.POST("/:token/respond", quote.NewPublicHandler(container.GetQuoteUseCase(), container.GetClientUseCase(), container.GetProductUseCase(), container.GetSettingsUseCase(), container.GetShareTokenManager(), container.GetShareBaseURL()).Respond)
+		}
+
+		// Calendário .ics da equipe: o token da URL substitui a autenticação
+		publicCalendars := api.Group("/public/calendars")
+		{
+			publicCalendars.GET("/:token", appointment.NewTeamHandler(container.GetAppointmentUseCase(), container.GetCalendarBaseURL()).Calendar)
 		}
 
 		settings := api.Group("/settings")
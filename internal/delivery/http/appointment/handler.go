@@ -0,0 +1,292 @@
+package appointment
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+	clientDomain "erp-api/internal/domain/client"
+	orderDomain "erp-api/internal/domain/order"
+	quoteDomain "erp-api/internal/domain/quote"
+	appointmentUseCase "erp-api/internal/usecase/appointment"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	appointmentUseCase appointmentUseCase.UseCaseInterface
+}
+
+func NewHandler(appointmentUseCase appointmentUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		appointmentUseCase: appointmentUseCase,
+	}
+}
+
+// Create agenda uma medição ou instalação para uma equipe
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req appointmentDomain.CreateAppointmentDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	appointment, err := h.appointmentUseCase.Create(c.Request.Context(), tenantID, userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, appointment)
+}
+
+// GetByID busca uma visita
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	appointment, err := h.appointmentUseCase.GetByID(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+// Update remarca ou corrige uma visita agendada
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req appointmentDomain.UpdateAppointmentDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	appointment, err := h.appointmentUseCase.Update(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+// UpdateStatus conclui ou cancela uma visita
+func (h *Handler) UpdateStatus(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req appointmentDomain.UpdateAppointmentStatusDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	appointment, err := h.appointmentUseCase.UpdateStatus(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+// Delete remove uma visita
+func (h *Handler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.appointmentUseCase.Delete(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista as visitas, com filtros por equipe, cliente, documento e período
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	filter := appointmentDomain.ListFilter{
+		TeamID:   c.Query("team_id"),
+		ClientID: c.Query("client_id"),
+		QuoteID:  c.Query("quote_id"),
+		OrderID:  c.Query("order_id"),
+		Type:     appointmentDomain.AppointmentType(c.Query("type")),
+		Status:   appointmentDomain.AppointmentStatus(c.Query("status")),
+	}
+
+	if filter.From, err = parseDateParam(c, "from", false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if filter.To, err = parseDateParam(c, "to", true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	appointments, err := h.appointmentUseCase.List(c.Request.Context(), tenantID, filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.appointmentUseCase.Count(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointmentDomain.AppointmentListDTO{
+		Appointments: appointments,
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+	})
+}
+
+// parseDateParam aceita RFC3339 ou apenas a data; endOfDay estende a data até o fim do dia
+func parseDateParam(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	value := strings.TrimSpace(c.Query(name))
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD)", name)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case appointmentDomain.ErrAppointmentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Appointment not found",
+		})
+	case appointmentDomain.ErrTeamNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Team not found",
+		})
+	case clientDomain.ErrClientNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Client not found",
+		})
+	case quoteDomain.ErrQuoteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote not found",
+		})
+	case orderDomain.ErrOrderNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Order not found",
+		})
+	case appointmentDomain.ErrTeamAlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A team with this name already exists",
+		})
+	case appointmentDomain.ErrTeamInUse:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Team has appointments, deactivate it instead",
+		})
+	case appointmentDomain.ErrScheduleConflict:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Team already has an appointment in this time window",
+		})
+	case appointmentDomain.ErrAppointmentClosed:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Appointment is already done or cancelled",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package appointment
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+	appointmentUseCase "erp-api/internal/usecase/appointment"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TeamHandler cuida das equipes de campo e do calendário .ics de cada uma
+type TeamHandler struct {
+	appointmentUseCase appointmentUseCase.UseCaseInterface
+	baseURL            string
+}
+
+// NewTeamHandler cria o handler de equipes; baseURL é o endereço público dos
+// calendários (o token da equipe é concatenado ao final).
+func NewTeamHandler(appointmentUseCase appointmentUseCase.UseCaseInterface, baseURL string) *TeamHandler {
+	return &TeamHandler{
+		appointmentUseCase: appointmentUseCase,
+		baseURL:            strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Create cadastra uma equipe
+func (h *TeamHandler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req appointmentDomain.CreateTeamDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	team, err := h.appointmentUseCase.CreateTeam(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// GetByID busca uma equipe
+func (h *TeamHandler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	team, err := h.appointmentUseCase.GetTeam(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// Update altera nome, descrição ou situação da equipe
+func (h *TeamHandler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req appointmentDomain.UpdateTeamDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	team, err := h.appointmentUseCase.UpdateTeam(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// Delete remove uma equipe sem visitas
+func (h *TeamHandler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.appointmentUseCase.DeleteTeam(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista as equipes do tenant
+func (h *TeamHandler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	teams, err := h.appointmentUseCase.ListTeams(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.appointmentUseCase.CountTeams(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, appointmentDomain.TeamListDTO{
+		Teams:  teams,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// Feed devolve o endereço do calendário da equipe para assinar no celular
+func (h *TeamHandler) Feed(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	team, err := h.appointmentUseCase.GetTeam(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.feed(team))
+}
+
+// RotateFeed gera um novo endereço de calendário, invalidando o anterior
+func (h *TeamHandler) RotateFeed(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	team, err := h.appointmentUseCase.RotateFeedToken(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.feed(team))
+}
+
+// Calendar serve o .ics público da equipe; o token na URL é a única credencial
+func (h *TeamHandler) Calendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.appointmentUseCase.Calendar(c.Request.Context(), token)
+	if err != nil {
+		if err == appointmentDomain.ErrTeamNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Calendar not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+func (h *TeamHandler) feed(team *appointmentDomain.Team) appointmentDomain.TeamFeedDTO {
+	return appointmentDomain.TeamFeedDTO{
+		TeamID: team.ID.String(),
+		URL:    h.baseURL + "/" + team.FeedToken + ".ics",
+	}
+}
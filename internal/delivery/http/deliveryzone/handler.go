@@ -0,0 +1,184 @@
+package deliveryzone
+
+import (
+	"net/http"
+	"strconv"
+
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	zoneUseCase "erp-api/internal/usecase/deliveryzone"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	zoneUseCase zoneUseCase.UseCaseInterface
+}
+
+func NewHandler(zoneUseCase zoneUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		zoneUseCase: zoneUseCase,
+	}
+}
+
+// Create cadastra uma zona de entrega
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req zoneDomain.CreateZoneDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.zoneUseCase.Create(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+// GetByID busca uma zona de entrega
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	zone, err := h.zoneUseCase.GetByID(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+// Update altera uma zona; fretes já calculados não mudam
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req zoneDomain.UpdateZoneDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	zone, err := h.zoneUseCase.Update(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+// Delete remove uma zona de entrega
+func (h *Handler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.zoneUseCase.Delete(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista as zonas de entrega do tenant
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	zones, err := h.zoneUseCase.List(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.zoneUseCase.Count(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, zoneDomain.ZoneListDTO{
+		Zones:  zones,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case zoneDomain.ErrZoneNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Delivery zone not found",
+		})
+	case zoneDomain.ErrZoneAlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A delivery zone with this name already exists",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package order
+
+import (
+	"net/http"
+	"strconv"
+
+	orderDomain "erp-api/internal/domain/order"
+	quoteDomain "erp-api/internal/domain/quote"
+	stockDomain "erp-api/internal/domain/stock"
+	orderUseCase "erp-api/internal/usecase/order"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	orderUseCase orderUseCase.UseCaseInterface
+}
+
+func NewHandler(orderUseCase orderUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		orderUseCase: orderUseCase,
+	}
+}
+
+// Create gera um pedido a partir de um orçamento aprovado
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req orderDomain.CreateOrderDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	order, err := h.orderUseCase.CreateFromQuote(c.Request.Context(), tenantID, userID, &req)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case orderDomain.ErrQuoteNotApproved:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Only approved quotes can be converted into orders",
+			})
+		case orderDomain.ErrOrderAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "An order already exists for this quote",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetByID busca um pedido com seus itens
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	order, err := h.orderUseCase.GetDetail(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		switch err {
+		case orderDomain.ErrOrderNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Order not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// List lista pedidos, com filtro opcional por status e cliente
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	filter := orderDomain.ListFilter{
+		Status:   orderDomain.OrderStatus(c.Query("status")),
+		ClientID: c.Query("client_id"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status parameter",
+		})
+		return
+	}
+
+	orders, err := h.orderUseCase.List(c.Request.Context(), tenantID, filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.orderUseCase.Count(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderDomain.OrderListDTO{
+		Orders: orders,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// UpdateStatus avança o pedido para a próxima etapa
+func (h *Handler) UpdateStatus(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req orderDomain.UpdateOrderStatusDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	order, err := h.orderUseCase.UpdateStatus(c.Request.Context(), tenantID, c.Param("id"), userID, &req)
+	if err != nil {
+		switch err {
+		case orderDomain.ErrOrderNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Order not found",
+			})
+		case orderDomain.ErrInvalidOrderStatus:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid order status",
+			})
+		case orderDomain.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Invalid order status transition",
+			})
+		case stockDomain.ErrInsufficientStock:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Insufficient stock to start production",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
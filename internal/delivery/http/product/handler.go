@@ -51,7 +51,9 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUseCase.Create(c.Request.Context(), &req)
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	product, err := h.productUseCase.Create(c.Request.Context(), userID, &req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -91,8 +93,16 @@ func (h *Handler) GetByID(c *gin.Context) {
 		return
 	}
 
+	reserved, err := h.productUseCase.Reserved(c.Request.Context(), tenantID, []string{product.ID.String()})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	log.Info().Msg("Get product by ID ended")
-	c.JSON(http.StatusOK, product)
+	c.JSON(http.StatusOK, toDTO(product, reserved[product.ID.String()]))
 }
 
 func (h *Handler) Update(c *gin.Context) {
@@ -117,13 +127,19 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUseCase.Update(c.Request.Context(), tenantID, id, &req)
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	product, err := h.productUseCase.Update(c.Request.Context(), tenantID, userID, id, &req)
 	if err != nil {
 		switch err {
 		case productDomain.ErrProductNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Product not found",
 			})
+		case productDomain.ErrPriceTypeInUse:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Price type cannot change on a product with stock movements or reservations",
+			})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -214,6 +230,19 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
+	productIDs := make([]string, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID.String()
+	}
+
+	reserved, err := h.productUseCase.Reserved(c.Request.Context(), tenantID, productIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	response := productDomain.ProductListDTO{
 		Products: make([]*productDomain.ProductDTO, len(products)),
 		Total:    total,
@@ -222,20 +251,7 @@ func (h *Handler) List(c *gin.Context) {
 	}
 
 	for i, product := range products {
-		response.Products[i] = &productDomain.ProductDTO{
-			ID:          product.ID.String(),
-			TenantID:    product.TenantID.String(),
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Stock:       product.Stock,
-			SKU:         product.SKU,
-			Category:    product.Category,
-			ImageURL:    product.ImageURL,
-			IsActive:    product.IsActive,
-			CreatedAt:   product.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt:   product.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		}
+		response.Products[i] = toDTO(product, reserved[product.ID.String()])
 	}
 
 	log.Info().Msg("List products ended")
@@ -267,3 +283,25 @@ func (h *Handler) Count(c *gin.Context) {
 		"count": count,
 	})
 }
+
+// toDTO monta a resposta do produto separando o estoque reservado do disponível.
+func toDTO(product *productDomain.Product, reserved int) *productDomain.ProductDTO {
+	return &productDomain.ProductDTO{
+		ID:             product.ID.String(),
+		TenantID:       product.TenantID.String(),
+		Name:           product.Name,
+		Description:    product.Description,
+		Price:          product.Price,
+		CostPrice:      product.CostPrice,
+		PriceType:      product.PriceType,
+		Stock:          product.Stock,
+		ReservedStock:  reserved,
+		AvailableStock: product.Stock - reserved,
+		SKU:            product.SKU,
+		Category:       product.Category,
+		ImageURL:       product.ImageURL,
+		IsActive:       product.IsActive,
+		CreatedAt:      product.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      product.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
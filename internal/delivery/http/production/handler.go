@@ -0,0 +1,283 @@
+package production
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	productionDomain "erp-api/internal/domain/production"
+	quoteDomain "erp-api/internal/domain/quote"
+	productionUseCase "erp-api/internal/usecase/production"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	productionUseCase productionUseCase.UseCaseInterface
+}
+
+func NewHandler(productionUseCase productionUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		productionUseCase: productionUseCase,
+	}
+}
+
+// Create abre as ordens de produção das peças de um orçamento aprovado
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req productionDomain.CreateWorkOrdersDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	workOrders, err := h.productionUseCase.CreateFromQuote(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"work_orders": workOrders,
+	})
+}
+
+// GetByID busca uma ordem de produção com todas as etapas
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	workOrder, err := h.productionUseCase.GetDetail(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workOrder)
+}
+
+// List lista ordens de produção, com filtro opcional por orçamento, etapa e status
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	filter := productionDomain.ListFilter{
+		QuoteID: c.Query("quote_id"),
+		Stage:   productionDomain.NormalizeStage(c.Query("stage")),
+		Status:  productionDomain.WorkOrderStatus(c.Query("status")),
+		Open:    c.Query("open") == "true",
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid status parameter",
+		})
+		return
+	}
+
+	workOrders, err := h.productionUseCase.List(c.Request.Context(), tenantID, filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.productionUseCase.Count(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, productionDomain.WorkOrderListDTO{
+		WorkOrders: workOrders,
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// Start inicia a etapa atual da ordem; o corpo é opcional
+func (h *Handler) Start(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req productionDomain.StageActionDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	workOrder, err := h.productionUseCase.StartStage(c.Request.Context(), tenantID, c.Param("id"), userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workOrder)
+}
+
+// Complete conclui a etapa atual e passa a ordem para a próxima; o corpo é opcional
+func (h *Handler) Complete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req productionDomain.StageActionDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	workOrder, err := h.productionUseCase.CompleteStage(c.Request.Context(), tenantID, c.Param("id"), userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workOrder)
+}
+
+// Assign troca o responsável pela etapa atual
+func (h *Handler) Assign(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req productionDomain.AssignStageDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	workOrder, err := h.productionUseCase.AssignStage(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workOrder)
+}
+
+// Board lista as ordens em produção agrupadas pela etapa atual, na ordem do roteiro
+func (h *Handler) Board(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	board, err := h.productionUseCase.Board(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case productionDomain.ErrWorkOrderNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Work order not found",
+		})
+	case quoteDomain.ErrQuoteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote not found",
+		})
+	case productionDomain.ErrQuoteNotApproved:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Only approved quotes can go into production",
+		})
+	case productionDomain.ErrWorkOrdersAlreadyExist:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Work orders already exist for this quote",
+		})
+	case productionDomain.ErrWorkOrderCompleted:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Work order is already completed",
+		})
+	case productionDomain.ErrStageAlreadyStarted:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Current stage has already started",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package quote
+
+import (
+	"context"
+	"net/http"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	clientUseCase "erp-api/internal/usecase/client"
+	quoteUseCase "erp-api/internal/usecase/quote"
+	userUseCase "erp-api/internal/usecase/user"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler expõe os indicadores de conversão e rentabilidade dos orçamentos
+type AnalyticsHandler struct {
+	quoteUseCase  quoteUseCase.UseCaseInterface
+	clientUseCase clientUseCase.UseCaseInterface
+	userUseCase   userUseCase.UseCaseInterface
+}
+
+func NewAnalyticsHandler(
+	quoteUseCase quoteUseCase.UseCaseInterface,
+	clientUseCase clientUseCase.UseCaseInterface,
+	userUseCase userUseCase.UseCaseInterface,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		quoteUseCase:  quoteUseCase,
+		clientUseCase: clientUseCase,
+		userUseCase:   userUseCase,
+	}
+}
+
+// Conversion retorna quantidade, taxa de aprovação, ticket médio e tempo até a
+// aprovação, no geral e por vendedor, mês e cliente. Aceita os mesmos filtros
+// da listagem (created_from, created_to, user_id, client_id...).
+func (h *AnalyticsHandler) Conversion(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	filter, ok := listFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.quoteUseCase.ConversionReport(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	h.labelGroups(c.Request.Context(), tenantID, report)
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Profitability retorna receita, custo e margem bruta dos orçamentos aprovados,
+// no geral e por vendedor, mês de aprovação e cliente, com a contagem dos que
+// ficaram abaixo da margem mínima do tenant. Aceita os mesmos filtros da listagem.
+func (h *AnalyticsHandler) Profitability(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	filter, ok := listFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.quoteUseCase.ProfitabilityReport(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	for _, group := range report.ByUser {
+		group.Label = h.userLabel(ctx, tenantID, group.Key)
+	}
+	for _, group := range report.ByClient {
+		group.Label = h.clientLabel(ctx, tenantID, group.Key)
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// labelGroups preenche o nome do vendedor e do cliente de cada grupo.
+// Falhas na busca deixam o grupo só com o ID.
+func (h *AnalyticsHandler) labelGroups(ctx context.Context, tenantID string, report *quoteDomain.ConversionReport) {
+	for _, group := range report.ByUser {
+		group.Label = h.userLabel(ctx, tenantID, group.Key)
+	}
+
+	for _, group := range report.ByClient {
+		group.Label = h.clientLabel(ctx, tenantID, group.Key)
+	}
+}
+
+func (h *AnalyticsHandler) userLabel(ctx context.Context, tenantID, id string) string {
+	user, err := h.userUseCase.GetByID(ctx, id)
+	if err != nil || user.TenantID.String() != tenantID {
+		return ""
+	}
+	return user.DisplayName
+}
+
+func (h *AnalyticsHandler) clientLabel(ctx context.Context, tenantID, id string) string {
+	client, err := h.clientUseCase.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return ""
+	}
+	return client.Name
+}
@@ -0,0 +1,58 @@
+package quote
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApproveDiscount aprova o desconto de um orçamento aguardando aprovação (somente gerentes)
+func (h *Handler) ApproveDiscount(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req quoteDomain.ApproveDiscountDTO
+
+	// Corpo opcional: o motivo é registrado no histórico
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	quote, err := h.quoteUseCase.ApproveDiscount(c.Request.Context(), tenantID, id, userID, &req)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case quoteDomain.ErrDiscountApprovalNotPending, quoteDomain.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote is not awaiting discount approval",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
@@ -0,0 +1,106 @@
+package quote
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	quoteDomain "erp-api/internal/domain/quote"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listFilterFromQuery lê os filtros e a ordenação da listagem; responde 400 para valores inválidos.
+//
+// Datas aceitam YYYY-MM-DD ou RFC 3339; em created_to/approved_to uma data
+// sem horário inclui o dia inteiro.
+func listFilterFromQuery(c *gin.Context) (quoteDomain.ListFilter, bool) {
+	filter, err := parseListFilter(c)
+	if err == nil {
+		err = filter.Validate()
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid filter parameters",
+			"details": err.Error(),
+		})
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func parseListFilter(c *gin.Context) (quoteDomain.ListFilter, error) {
+	filter := quoteDomain.ListFilter{
+		Status:   quoteDomain.QuoteStatus(c.Query("status")),
+		ClientID: c.Query("client_id"),
+		UserID:   c.Query("user_id"),
+		Number:   c.Query("number"),
+		SortBy:   quoteDomain.SortField(c.Query("sort_by")),
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDateParam(c, "created_from", false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseDateParam(c, "created_to", true); err != nil {
+		return filter, err
+	}
+	if filter.ApprovedFrom, err = parseDateParam(c, "approved_from", false); err != nil {
+		return filter, err
+	}
+	if filter.ApprovedTo, err = parseDateParam(c, "approved_to", true); err != nil {
+		return filter, err
+	}
+	if filter.MinTotal, err = parseFloatParam(c, "min_total"); err != nil {
+		return filter, err
+	}
+	if filter.MaxTotal, err = parseFloatParam(c, "max_total"); err != nil {
+		return filter, err
+	}
+
+	switch strings.ToLower(c.DefaultQuery("sort_order", "desc")) {
+	case "asc":
+		filter.SortAsc = true
+	case "desc":
+	default:
+		return filter, fmt.Errorf("sort_order must be asc or desc")
+	}
+
+	return filter, nil
+}
+
+func parseDateParam(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	value := strings.TrimSpace(c.Query(name))
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD)", name)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+func parseFloatParam(c *gin.Context, name string) (*float64, error) {
+	value := strings.TrimSpace(c.Query(name))
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+	return &f, nil
+}
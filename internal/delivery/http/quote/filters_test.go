@@ -0,0 +1,75 @@
+package quote
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	quoteDomain "erp-api/internal/domain/quote"
+
+	"github.com/gin-gonic/gin"
+)
+
+func contextWithQuery(query string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/quotes?"+query, nil)
+	return c
+}
+
+func TestParseListFilter(t *testing.T) {
+	c := contextWithQuery("status=approved&client_id=c1&user_id=u1&created_from=2026-01-01&created_to=2026-01-31" +
+		"&min_total=100.5&max_total=2000&sort_by=client_name&sort_order=asc")
+
+	filter, err := parseListFilter(c)
+	if err != nil {
+		t.Fatalf("parseListFilter() error = %v", err)
+	}
+
+	if filter.Status != quoteDomain.QuoteStatusApproved || filter.ClientID != "c1" || filter.UserID != "u1" {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+	if filter.CreatedFrom == nil || !filter.CreatedFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedFrom = %v", filter.CreatedFrom)
+	}
+	// created_to sem horário inclui o dia inteiro
+	if filter.CreatedTo == nil || !filter.CreatedTo.After(time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("CreatedTo = %v", filter.CreatedTo)
+	}
+	if filter.MinTotal == nil || *filter.MinTotal != 100.5 || filter.MaxTotal == nil || *filter.MaxTotal != 2000 {
+		t.Errorf("MinTotal/MaxTotal = %v/%v", filter.MinTotal, filter.MaxTotal)
+	}
+	if filter.SortBy != quoteDomain.SortByClientName || !filter.SortAsc {
+		t.Errorf("SortBy/SortAsc = %v/%v", filter.SortBy, filter.SortAsc)
+	}
+}
+
+func TestParseListFilter_Defaults(t *testing.T) {
+	filter, err := parseListFilter(contextWithQuery(""))
+	if err != nil {
+		t.Fatalf("parseListFilter() error = %v", err)
+	}
+	if filter.SortAsc || filter.SortBy != "" || filter.CreatedFrom != nil || filter.MinTotal != nil {
+		t.Errorf("expected empty filter sorted descending, got %+v", filter)
+	}
+}
+
+func TestListFilterFromQuery_Invalid(t *testing.T) {
+	tests := []string{
+		"status=unknown",
+		"created_from=31/01/2026",
+		"min_total=abc",
+		"min_total=500&max_total=100",
+		"created_from=2026-02-01&created_to=2026-01-01",
+		"sort_by=notes",
+		"sort_order=sideways",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			if _, ok := listFilterFromQuery(contextWithQuery(query)); ok {
+				t.Errorf("expected %q to be rejected", query)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package quote
+
+import (
+	"net/http"
+
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalculateFreight calcula o frete pela zona de entrega do cliente e o grava como
+// linha de frete do orçamento
+func (h *Handler) CalculateFreight(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.CalculateFreight(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		if err == zoneDomain.ErrNoZoneForAddress {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "No delivery zone covers the client's address",
+			})
+			return
+		}
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
@@ -1,10 +1,16 @@
 package quote
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
+	clientDomain "erp-api/internal/domain/client"
+	"erp-api/internal/domain/cutting"
 	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	quoteUseCase "erp-api/internal/usecase/quote"
 	"erp-api/pkg/middleware"
 
@@ -64,6 +70,14 @@ func (h *Handler) Create(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Invalid date",
 			})
+		case stockDomain.ErrInsufficientStock:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Not enough available stock to reserve the quote material",
+			})
+		case slabDomain.ErrSlabUnavailable:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Slab is already reserved or no longer in stock",
+			})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -87,7 +101,7 @@ func (h *Handler) GetByID(c *gin.Context) {
 
 	id := c.Param("id")
 
-	quote, err := h.quoteUseCase.GetByID(c.Request.Context(), tenantID, id)
+	quote, err := h.quoteUseCase.GetDetail(c.Request.Context(), tenantID, id)
 	if err != nil {
 		switch err {
 		case quoteDomain.ErrQuoteNotFound:
@@ -133,6 +147,18 @@ func (h *Handler) Update(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Quote not found",
 			})
+		case clientDomain.ErrClientNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Client not found",
+			})
+		case quoteDomain.ErrStatusChangeNotAllowed:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote status must be changed through the status endpoint",
+			})
+		case quoteDomain.ErrQuoteNotEditable:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote can only be edited while pending or awaiting approval",
+			})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -163,6 +189,14 @@ func (h *Handler) Delete(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": "Quote not found",
 			})
+		case quoteDomain.ErrQuoteHasOrder:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote already has a sales order",
+			})
+		case quoteDomain.ErrQuoteInProduction:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote already has work orders in production",
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": err.Error(),
@@ -203,7 +237,12 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	quotes, err := h.quoteUseCase.List(c.Request.Context(), tenantID, limit, offset)
+	filter, ok := listFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	quotes, err := h.quoteUseCase.List(c.Request.Context(), tenantID, filter, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -211,7 +250,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	total, err := h.quoteUseCase.Count(c.Request.Context(), tenantID)
+	total, err := h.quoteUseCase.Count(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -229,15 +268,22 @@ func (h *Handler) List(c *gin.Context) {
 	for i, quote := range quotes {
 		response.Quotes[i] = &quoteDomain.QuoteDTO{
 			ID:         quote.ID.String(),
+			Number:     quote.Number,
 			TenantID:   quote.TenantID.String(),
 			ClientID:   quote.ClientID.String(),
 			UserID:     quote.UserID.String(),
+			Subtotal:   quote.Subtotal,
 			TotalValue: quote.TotalValue,
 			Discount:   quote.Discount,
 			Status:     quote.Status,
 			Notes:      quote.Notes,
+			ValidUntil: quote.ValidUntil,
 			CreatedAt:  quote.CreatedAt.Format("2006-01-02T15:04:05Z"),
 			UpdatedAt:  quote.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+
+			DiscountType:    quote.DiscountType,
+			DiscountAmount:  quote.DiscountAmount,
+			DiscountPercent: quote.DiscountPercent,
 		}
 	}
 
@@ -254,7 +300,12 @@ func (h *Handler) Count(c *gin.Context) {
 		return
 	}
 
-	count, err := h.quoteUseCase.Count(c.Request.Context(), tenantID)
+	filter, ok := listFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.quoteUseCase.Count(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -288,7 +339,9 @@ func (h *Handler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	err := h.quoteUseCase.UpdateStatus(c.Request.Context(), tenantID, id, &req)
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	err := h.quoteUseCase.UpdateStatus(c.Request.Context(), tenantID, id, userID, &req)
 	if err != nil {
 		switch err {
 		case quoteDomain.ErrQuoteNotFound:
@@ -299,6 +352,42 @@ func (h *Handler) UpdateStatus(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Invalid quote status",
 			})
+		case quoteDomain.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Invalid quote status transition",
+			})
+		case quoteDomain.ErrQuoteExpired:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote has expired and must be renewed",
+			})
+		case quoteDomain.ErrMarginBelowMinimum:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote margin is below the minimum required for approval",
+			})
+		case stockDomain.ErrInsufficientStock:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Not enough available stock to reserve the quote material",
+			})
+		case quoteDomain.ErrQuoteHasOrder:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote already has a sales order and cannot be cancelled",
+			})
+		case quoteDomain.ErrQuoteInProduction:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote already has work orders in production and cannot be cancelled",
+			})
+		case slabDomain.ErrSlabUnavailable:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Slab is already reserved or no longer in stock",
+			})
+		case quoteDomain.ErrQuoteOptionNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote option not found",
+			})
+		case quoteDomain.ErrOptionRequired:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Choose which option is approved (option_id)",
+			})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -311,3 +400,305 @@ func (h *Handler) UpdateStatus(c *gin.Context) {
 		"message": "Quote status updated successfully",
 	})
 }
+
+// History lista o histórico de mudanças de status de um orçamento
+func (h *Handler) History(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+
+	history, err := h.quoteUseCase.GetHistory(c.Request.Context(), tenantID, id)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"history": history,
+	})
+}
+
+// Renew renova a validade do orçamento e atualiza os preços com o cadastro atual
+func (h *Handler) Renew(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req quoteDomain.RenewQuoteDTO
+
+	// Corpo opcional: sem valid_until usa a validade padrão do tenant
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	quote, err := h.quoteUseCase.Renew(c.Request.Context(), tenantID, id, userID, &req)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case quoteDomain.ErrQuoteNotRenewable, quoteDomain.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Only pending, awaiting approval or expired quotes can be renewed",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// Duplicate copia o orçamento, com itens e observações, para outro cliente
+func (h *Handler) Duplicate(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req quoteDomain.DuplicateQuoteDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	quote, err := h.quoteUseCase.Duplicate(c.Request.Context(), tenantID, id, userID, &req)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case quoteDomain.ErrInvalidItems:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Quote must have at least one item",
+			})
+		case quoteDomain.ErrInvalidDate:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid date",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, quote)
+}
+
+// CuttingPlan calcula o aproveitamento de chapas das peças de um orçamento aprovado
+func (h *Handler) CuttingPlan(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req cutting.PlanRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	plan, err := h.quoteUseCase.CuttingPlan(c.Request.Context(), tenantID, id, &req)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case quoteDomain.ErrQuoteNotApproved:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Cutting plans are only available for approved quotes",
+			})
+		case cutting.ErrTooManyPieces:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": "Quote has too many pieces for a cutting plan",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// AddItem adiciona um item ao orçamento e recalcula os totais
+func (h *Handler) AddItem(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req quoteDomain.QuoteItemDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.AddItem(c.Request.Context(), tenantID, id, &req)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quote)
+}
+
+// UpdateItem atualiza um item do orçamento e recalcula os totais
+func (h *Handler) UpdateItem(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	itemID := c.Param("itemId")
+	var req quoteDomain.UpdateQuoteItemDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.UpdateItem(c.Request.Context(), tenantID, id, itemID, &req)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// DeleteItem remove um item do orçamento e recalcula os totais
+func (h *Handler) DeleteItem(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	itemID := c.Param("itemId")
+
+	quote, err := h.quoteUseCase.DeleteItem(c.Request.Context(), tenantID, id, itemID)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func respondItemError(c *gin.Context, err error) {
+	switch err {
+	case quoteDomain.ErrQuoteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote not found",
+		})
+	case quoteDomain.ErrQuoteItemNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote item not found",
+		})
+	case quoteDomain.ErrQuoteNotEditable:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Quote can only be edited while pending or awaiting approval",
+		})
+	case quoteDomain.ErrInvalidItems:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Quote must have at least one item",
+		})
+	case quoteDomain.ErrQuoteOptionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote option not found",
+		})
+	case quoteDomain.ErrDuplicateOptionName:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Option names must be unique within the quote",
+		})
+	case quoteDomain.ErrLastOption:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "The last option of a quote cannot be removed",
+		})
+	case quoteDomain.ErrFreightLine:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Freight lines are calculated from the delivery zone and cannot be edited",
+		})
+	case slabDomain.ErrSlabUnavailable:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Slab is already reserved or no longer in stock",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
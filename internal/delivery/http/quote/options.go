@@ -0,0 +1,93 @@
+package quote
+
+import (
+	"net/http"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddOption adiciona uma alternativa ao orçamento (ex.: o mesmo serviço em outra pedra)
+func (h *Handler) AddOption(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	var req quoteDomain.CreateOptionDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.AddOption(c.Request.Context(), tenantID, id, &req)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quote)
+}
+
+// UpdateOption renomeia uma opção do orçamento
+func (h *Handler) UpdateOption(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	optionID := c.Param("optionId")
+	var req quoteDomain.UpdateOptionDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.UpdateOption(c.Request.Context(), tenantID, id, optionID, &req)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// DeleteOption remove uma opção e seus itens
+func (h *Handler) DeleteOption(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	id := c.Param("id")
+	optionID := c.Param("optionId")
+
+	quote, err := h.quoteUseCase.DeleteOption(c.Request.Context(), tenantID, id, optionID)
+	if err != nil {
+		respondItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
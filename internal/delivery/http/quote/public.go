@@ -0,0 +1,300 @@
+package quote
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	clientDomain "erp-api/internal/domain/client"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	clientUseCase "erp-api/internal/usecase/client"
+	productUseCase "erp-api/internal/usecase/product"
+	quoteUseCase "erp-api/internal/usecase/quote"
+	settingsUseCase "erp-api/internal/usecase/settings"
+	"erp-api/pkg/auth"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// PublicHandler atende o link público do orçamento, acessado pelo cliente sem login
+type PublicHandler struct {
+	quoteUseCase    quoteUseCase.UseCaseInterface
+	clientUseCase   clientUseCase.UseCaseInterface
+	productUseCase  productUseCase.UseCaseInterface
+	settingsUseCase settingsUseCase.UseCaseInterface
+	shareTokens     *auth.ShareTokenManager
+	baseURL         string
+}
+
+// NewPublicHandler cria o handler do link público; baseURL é o endereço
+// onde o front-end exibe o orçamento (o token é concatenado ao final).
+func NewPublicHandler(
+	quoteUseCase quoteUseCase.UseCaseInterface,
+	clientUseCase clientUseCase.UseCaseInterface,
+	productUseCase productUseCase.UseCaseInterface,
+	settingsUseCase settingsUseCase.UseCaseInterface,
+	shareTokens *auth.ShareTokenManager,
+	baseURL string,
+) *PublicHandler {
+	return &PublicHandler{
+		quoteUseCase:    quoteUseCase,
+		clientUseCase:   clientUseCase,
+		productUseCase:  productUseCase,
+		settingsUseCase: settingsUseCase,
+		shareTokens:     shareTokens,
+		baseURL:         strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// CreateShareLink gera o link assinado e com validade para enviar ao cliente
+func (h *PublicHandler) CreateShareLink(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	quote, err := h.quoteUseCase.GetByID(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	token, expiresAt, err := h.shareTokens.GenerateQuoteToken(tenantID, quote.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to generate share link",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, quoteDomain.ShareLinkDTO{
+		Token:     token,
+		URL:       h.baseURL + "/" + token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// Get exibe o orçamento em modo somente leitura
+func (h *PublicHandler) Get(c *gin.Context) {
+	claims, ok := h.validateToken(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.loadPublicQuote(c.Request.Context(), claims.TenantID, claims.QuoteID)
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound, clientDomain.ErrClientNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to load quote",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// Respond registra a aprovação ou recusa do cliente, com comentário opcional
+func (h *PublicHandler) Respond(c *gin.Context) {
+	claims, ok := h.validateToken(c)
+	if !ok {
+		return
+	}
+
+	var req quoteDomain.ClientDecisionDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	err := h.quoteUseCase.RespondAsClient(c.Request.Context(), claims.TenantID, claims.QuoteID, &req, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote not found",
+			})
+		case quoteDomain.ErrInvalidDecision:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		case quoteDomain.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote can no longer be answered",
+			})
+		case quoteDomain.ErrQuoteExpired:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote has expired",
+			})
+		case quoteDomain.ErrMarginBelowMinimum, stockDomain.ErrInsufficientStock,
+			slabDomain.ErrSlabUnavailable, slabDomain.ErrSlabProductMismatch:
+			// Não revela custo, margem nem estoque ao cliente
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Quote cannot be approved online, please contact the seller",
+			})
+		case quoteDomain.ErrQuoteOptionNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Quote option not found",
+			})
+		case quoteDomain.ErrOptionRequired:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Choose which option is approved (option_id)",
+			})
+		default:
+			// O cliente não está logado: o erro interno fica só no log
+			log.Error().Err(err).Str("quote_id", claims.QuoteID).Msg("Public quote response failed")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to record quote response",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quote response recorded successfully",
+	})
+}
+
+// validateToken responde 404 para tokens inválidos ou expirados, sem revelar o motivo
+func (h *PublicHandler) validateToken(c *gin.Context) (*auth.QuoteShareClaims, bool) {
+	claims, err := h.shareTokens.ValidateQuoteToken(c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Link not found or expired",
+		})
+		return nil, false
+	}
+	return claims, true
+}
+
+func (h *PublicHandler) loadPublicQuote(ctx context.Context, tenantID, quoteID string) (*quoteDomain.PublicQuoteDTO, error) {
+	detail, err := h.quoteUseCase.GetDetail(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := h.clientUseCase.GetByID(ctx, tenantID, detail.ClientID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := h.settingsUseCase.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &quoteDomain.PublicQuoteDTO{
+		ID:         detail.ID.String(),
+		Number:     detail.Number,
+		Status:     detail.Status,
+		Subtotal:   detail.Subtotal,
+		Discount:   detail.DiscountAmount,
+		TotalValue: detail.TotalValue,
+		Notes:      detail.Notes,
+		CreatedAt:  detail.CreatedAt,
+		ApprovedAt: detail.ApprovedAt,
+		ValidUntil: detail.ValidUntil,
+		Company: quoteDomain.PublicCompanyDTO{
+			Name:         settings.Settings["company_name"],
+			LogoURL:      settings.Settings["logo_url"],
+			PrimaryColor: settings.Settings["primary_color"],
+			Phone:        settings.Settings["company_phone"],
+			Email:        settings.Settings["company_email"],
+		},
+		ClientName: client.Name,
+		Items:      make([]quoteDomain.PublicQuoteItemDTO, 0, len(detail.Items)),
+		CanRespond: detail.Status == quoteDomain.QuoteStatusPending && !detail.IsOverdue(time.Now()),
+	}
+
+	if detail.SelectedOptionID != nil {
+		view.SelectedOptionID = detail.SelectedOptionID.String()
+	}
+	// Com várias opções em aberto o cronograma depende da opção escolhida
+	if len(detail.Options) <= 1 || detail.SelectedOptionID != nil {
+		view.PaymentPlan = detail.PaymentPlan
+	}
+
+	// Com opções, cada item é exibido dentro da sua alternativa
+	optionIndex := make(map[string]int, len(detail.Options))
+	for i, option := range detail.Options {
+		optionIndex[option.ID.String()] = i
+		view.Options = append(view.Options, quoteDomain.PublicQuoteOptionDTO{
+			ID:         option.ID.String(),
+			Name:       option.Name,
+			Subtotal:   option.Subtotal,
+			Discount:   option.Discount,
+			TotalValue: option.TotalValue,
+			Items:      []quoteDomain.PublicQuoteItemDTO{},
+		})
+	}
+
+	names := make(map[string]string)
+	for _, item := range detail.Items {
+		productID := item.ProductIDString()
+		if _, ok := names[productID]; !ok && !item.IsService() {
+			product, err := h.productUseCase.GetByID(ctx, tenantID, productID)
+			if err != nil && err != productDomain.ErrProductNotFound {
+				return nil, err
+			}
+			if product != nil {
+				names[productID] = product.Name
+			}
+		}
+
+		publicItem := quoteDomain.PublicQuoteItemDTO{
+			ProductName: names[productID],
+			WidthCM:     item.WidthCM,
+			HeightCM:    item.HeightCM,
+			Thickness:   item.Thickness,
+			AreaM2:      item.AreaM2,
+			EdgeType:    item.EdgeType,
+			EdgeSides:   item.EdgeSides,
+			HasCutout:   item.HasCutout,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.DiscountAmount,
+			Total:       item.Total,
+			Kind:        item.Kind,
+		}
+		if item.IsService() {
+			publicItem.ProductName = item.Label()
+		}
+
+		if item.OptionID != nil {
+			if i, ok := optionIndex[item.OptionID.String()]; ok {
+				view.Options[i].Items = append(view.Options[i].Items, publicItem)
+				continue
+			}
+		}
+		view.Items = append(view.Items, publicItem)
+	}
+
+	return view, nil
+}
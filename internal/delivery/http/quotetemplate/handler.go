@@ -0,0 +1,225 @@
+package quotetemplate
+
+import (
+	"net/http"
+	"strconv"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	templateUseCase "erp-api/internal/usecase/quotetemplate"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	templateUseCase templateUseCase.UseCaseInterface
+}
+
+func NewHandler(templateUseCase templateUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		templateUseCase: templateUseCase,
+	}
+}
+
+// Create cadastra um modelo de orçamento
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req templateDomain.CreateTemplateDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateUseCase.Create(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetByID busca um modelo com seus itens
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	template, err := h.templateUseCase.GetDetail(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Update altera um modelo; itens informados substituem os atuais
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req templateDomain.UpdateTemplateDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateUseCase.Update(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete remove um modelo; orçamentos já criados a partir dele não mudam
+func (h *Handler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.templateUseCase.Delete(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista os modelos do tenant
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	templates, err := h.templateUseCase.List(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.templateUseCase.Count(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, templateDomain.TemplateListDTO{
+		Templates: templates,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	})
+}
+
+// Instantiate cria um orçamento a partir do modelo com os preços atuais dos produtos
+func (h *Handler) Instantiate(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req templateDomain.InstantiateTemplateDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	quote, err := h.templateUseCase.Instantiate(c.Request.Context(), tenantID, c.Param("id"), userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quote)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case templateDomain.ErrTemplateNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote template not found",
+		})
+	case templateDomain.ErrTemplateAlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A quote template with this name already exists",
+		})
+	case productDomain.ErrProductNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Product not found",
+		})
+	case quoteDomain.ErrInvalidDate:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	productUseCase "erp-api/internal/usecase/product"
+	quoteUseCase "erp-api/internal/usecase/quote"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Dimensões (em mm) da área de cada desenho; a mesma geometria é usada no SVG e no PDF
+const (
+	drawingWidth  = 190.0
+	drawingHeight = 125.0
+)
+
+// DrawingHandler gera os desenhos técnicos das peças de um orçamento
+type DrawingHandler struct {
+	quoteUseCase   quoteUseCase.UseCaseInterface
+	productUseCase productUseCase.UseCaseInterface
+}
+
+// NewDrawingHandler cria um novo handler de desenhos técnicos
+func NewDrawingHandler(
+	quoteUseCase quoteUseCase.UseCaseInterface,
+	productUseCase productUseCase.UseCaseInterface,
+) *DrawingHandler {
+	return &DrawingHandler{
+		quoteUseCase:   quoteUseCase,
+		productUseCase: productUseCase,
+	}
+}
+
+// ItemDrawing é o desenho SVG de um item do orçamento
+type ItemDrawing struct {
+	ItemID      string `json:"item_id"`
+	ProductName string `json:"product_name"`
+	SVG         string `json:"svg"`
+}
+
+// QuoteDrawings gera o desenho cotado de cada peça do orçamento
+// @Summary Desenhos técnicos das peças
+// @Tags quotes
+// @Produce application/pdf
+// @Produce json
+// @Produce image/svg+xml
+// @Param id path string true "Quote ID"
+// @Param format query string false "pdf (padrão) ou svg"
+// @Param item_id query string false "Retorna somente o SVG do item (format=svg)"
+// @Router /api/v1/quotes/{id}/drawings [get]
+func (h *DrawingHandler) QuoteDrawings(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", "pdf")
+	if format != "pdf" && format != "svg" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be pdf or svg"})
+		return
+	}
+
+	quote, items, products, err := h.loadDrawingData(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Quote not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load quote"})
+		}
+		return
+	}
+
+	if format == "pdf" {
+		pdf := buildDrawingsPDF(quote, items, products)
+
+		c.Header("Content-Type", "application/pdf")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=desenhos-%s.pdf", quoteNumber(quote)))
+		if err := pdf.Output(c.Writer); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate PDF"})
+		}
+		return
+	}
+
+	// Um item específico é devolvido como imagem SVG pura
+	if itemID := c.Query("item_id"); itemID != "" {
+		for i, item := range items {
+			if item.ID.String() == itemID && hasDrawing(item) {
+				svg := renderPieceSVG(layoutPiece(i+1, item, productName(products, item)))
+				c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(svg))
+				return
+			}
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Quote item not found or has no drawing"})
+		return
+	}
+
+	drawings := make([]ItemDrawing, 0, len(items))
+	for i, item := range items {
+		if !hasDrawing(item) {
+			continue
+		}
+		name := productName(products, item)
+		drawings = append(drawings, ItemDrawing{
+			ItemID:      item.ID.String(),
+			ProductName: name,
+			SVG:         renderPieceSVG(layoutPiece(i+1, item, name)),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"quote_id": quote.ID,
+		"drawings": drawings,
+	})
+}
+
+func (h *DrawingHandler) loadDrawingData(ctx context.Context, tenantID, quoteID string) (*quoteDomain.Quote, []*quoteDomain.QuoteItem, map[string]*productDomain.Product, error) {
+	quote, err := h.quoteUseCase.GetByID(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	items, err := h.quoteUseCase.GetItems(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Aprovado com opções: só as peças da opção escolhida vão para a produção
+	items = quoteDomain.ActiveItems(quote, items)
+
+	products := make(map[string]*productDomain.Product)
+	for _, item := range items {
+		if item.IsService() {
+			continue
+		}
+		productID := item.ProductIDString()
+		if _, ok := products[productID]; ok {
+			continue
+		}
+		product, err := h.productUseCase.GetByID(ctx, tenantID, productID)
+		if err != nil && err != productDomain.ErrProductNotFound {
+			return nil, nil, nil, err
+		}
+		products[productID] = product
+	}
+
+	return quote, items, products, nil
+}
+
+// hasDrawing indica se o item é uma peça com medidas para ser desenhada;
+// linhas de serviço e de frete nunca têm desenho
+func hasDrawing(item *quoteDomain.QuoteItem) bool {
+	return !item.IsService() && item.WidthCM > 0 && item.HeightCM > 0
+}
+
+// Estilos dos elementos do desenho
+const (
+	stylePiece     = "piece"
+	styleEdge      = "edge"
+	styleDimension = "dimension"
+	styleCutout    = "cutout"
+	styleTitle     = "title"
+	styleLabel     = "label"
+)
+
+// shape é um elemento do desenho em mm, independente do formato de saída
+type shape struct {
+	Kind           string // rect, line ou text
+	X1, Y1, X2, Y2 float64
+	Text           string
+	Anchor         string // start, middle ou end (textos)
+	Style          string
+}
+
+// layoutPiece monta o desenho cotado de uma peça: contorno em escala, cotas de
+// largura e altura, bordas acabadas e marcação de recorte.
+func layoutPiece(index int, item *quoteDomain.QuoteItem, name string) []shape {
+	title := fmt.Sprintf("#%d %s - %d peça(s)", index, name, item.Quantity)
+	if item.Thickness > 0 {
+		title += fmt.Sprintf(" - esp. %s cm", formatFloat(item.Thickness))
+	}
+	shapes := []shape{{Kind: "text", X1: 0, Y1: 6, Text: title, Anchor: "start", Style: styleTitle}}
+
+	if !hasDrawing(item) {
+		return append(shapes, shape{Kind: "text", X1: 0, Y1: 16, Text: "Item sem medidas", Anchor: "start", Style: styleLabel})
+	}
+
+	// Área útil do desenho, reservando espaço para as cotas e a legenda
+	areaX, areaY := 30.0, 16.0
+	areaW, areaH := drawingWidth-areaX-10, drawingHeight-areaY-30
+	scale := math.Min(areaW/item.WidthCM, areaH/item.HeightCM)
+	w, h := item.WidthCM*scale, item.HeightCM*scale
+	x, y := areaX+(areaW-w)/2, areaY+(areaH-h)/2
+
+	shapes = append(shapes, shape{Kind: "rect", X1: x, Y1: y, X2: x + w, Y2: y + h, Style: stylePiece})
+
+	// Destaca os lados com borda acabada; a frente fica embaixo, junto da cota de largura
+	for _, side := range quoteDomain.FinishedEdges(item) {
+		switch side {
+		case quoteDomain.EdgeSideFront:
+			shapes = append(shapes, shape{Kind: "line", X1: x, Y1: y + h, X2: x + w, Y2: y + h, Style: styleEdge})
+		case quoteDomain.EdgeSideBack:
+			shapes = append(shapes, shape{Kind: "line", X1: x, Y1: y, X2: x + w, Y2: y, Style: styleEdge})
+		case quoteDomain.EdgeSideLeft:
+			shapes = append(shapes, shape{Kind: "line", X1: x, Y1: y, X2: x, Y2: y + h, Style: styleEdge})
+		case quoteDomain.EdgeSideRight:
+			shapes = append(shapes, shape{Kind: "line", X1: x + w, Y1: y, X2: x + w, Y2: y + h, Style: styleEdge})
+		}
+	}
+
+	// Marcação de recorte (cuba, cooktop): posição indicativa, centralizada na peça
+	if item.HasCutout {
+		cw, ch := w*0.4, h*0.4
+		cx, cy := x+(w-cw)/2, y+(h-ch)/2
+		shapes = append(shapes,
+			shape{Kind: "rect", X1: cx, Y1: cy, X2: cx + cw, Y2: cy + ch, Style: styleCutout},
+			shape{Kind: "line", X1: cx, Y1: cy, X2: cx + cw, Y2: cy + ch, Style: styleCutout},
+			shape{Kind: "line", X1: cx + cw, Y1: cy, X2: cx, Y2: cy + ch, Style: styleCutout},
+			shape{Kind: "text", X1: x + w/2, Y1: cy - 2, Text: "RECORTE", Anchor: "middle", Style: styleLabel},
+		)
+	}
+
+	// Cota de largura abaixo da peça
+	dimY := y + h + 7
+	shapes = append(shapes,
+		shape{Kind: "line", X1: x, Y1: dimY, X2: x + w, Y2: dimY, Style: styleDimension},
+		shape{Kind: "line", X1: x, Y1: dimY - 2, X2: x, Y2: dimY + 2, Style: styleDimension},
+		shape{Kind: "line", X1: x + w, Y1: dimY - 2, X2: x + w, Y2: dimY + 2, Style: styleDimension},
+		shape{Kind: "text", X1: x + w/2, Y1: dimY + 5, Text: formatFloat(item.WidthCM) + " cm", Anchor: "middle", Style: styleLabel},
+	)
+
+	// Cota de altura à esquerda da peça
+	dimX := x - 7
+	shapes = append(shapes,
+		shape{Kind: "line", X1: dimX, Y1: y, X2: dimX, Y2: y + h, Style: styleDimension},
+		shape{Kind: "line", X1: dimX - 2, Y1: y, X2: dimX + 2, Y2: y, Style: styleDimension},
+		shape{Kind: "line", X1: dimX - 2, Y1: y + h, X2: dimX + 2, Y2: y + h, Style: styleDimension},
+		shape{Kind: "text", X1: dimX - 2, Y1: y + h/2 + 1, Text: formatFloat(item.HeightCM) + " cm", Anchor: "end", Style: styleLabel},
+	)
+
+	// Legenda
+	legend := []string{}
+	if item.EdgeType != "" {
+		legend = append(legend, "Borda: "+item.EdgeType+" (lados destacados)")
+	}
+	if item.HasCutout {
+		legend = append(legend, "Com recorte")
+	}
+	if item.Notes != "" {
+		legend = append(legend, "Obs.: "+truncate(item.Notes, 90))
+	}
+	for i, line := range legend {
+		shapes = append(shapes, shape{Kind: "text", X1: 0, Y1: drawingHeight - 14 + float64(i)*5, Text: line, Anchor: "start", Style: styleLabel})
+	}
+
+	return shapes
+}
+
+// renderPieceSVG converte o desenho em um documento SVG com unidades em mm
+func renderPieceSVG(shapes []shape) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s" font-family="Arial, sans-serif">`,
+		svgNumber(drawingWidth), svgNumber(drawingHeight), svgNumber(drawingWidth), svgNumber(drawingHeight))
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+
+	for _, s := range shapes {
+		switch s.Kind {
+		case "rect":
+			fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" %s/>`,
+				svgNumber(s.X1), svgNumber(s.Y1), svgNumber(s.X2-s.X1), svgNumber(s.Y2-s.Y1), svgStyle(s.Style))
+		case "line":
+			fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" %s/>`,
+				svgNumber(s.X1), svgNumber(s.Y1), svgNumber(s.X2), svgNumber(s.Y2), svgStyle(s.Style))
+		case "text":
+			fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="%s" %s>%s</text>`,
+				svgNumber(s.X1), svgNumber(s.Y1), s.Anchor, svgStyle(s.Style), html.EscapeString(s.Text))
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+func svgStyle(style string) string {
+	switch style {
+	case stylePiece:
+		return `fill="#f2f2f2" stroke="#222222" stroke-width="0.4"`
+	case styleEdge:
+		return `stroke="#1565c0" stroke-width="1.4"`
+	case styleDimension:
+		return `stroke="#555555" stroke-width="0.25"`
+	case styleCutout:
+		return `fill="none" stroke="#c62828" stroke-width="0.35" stroke-dasharray="1.5,1"`
+	case styleTitle:
+		return `font-size="4.5" font-weight="bold" fill="#000000"`
+	default:
+		return `font-size="3.5" fill="#333333"`
+	}
+}
+
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// buildDrawingsPDF reúne os desenhos do orçamento em um PDF, dois por página
+func buildDrawingsPDF(quote *quoteDomain.Quote, items []*quoteDomain.QuoteItem, products map[string]*productDomain.Product) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf("Orçamento %s - Página %d/{nb}", quoteNumber(quote), pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+
+	drawn := 0
+	for i, item := range items {
+		if !hasDrawing(item) {
+			continue
+		}
+
+		if drawn%2 == 0 {
+			pdf.AddPage()
+			pdf.SetFont("Arial", "B", 13)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.CellFormat(0, 8, tr(fmt.Sprintf("DESENHOS TÉCNICOS - Nº %s", quoteNumber(quote))), "", 1, "L", false, 0, "")
+			pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+		}
+
+		originY := 22 + float64(drawn%2)*(drawingHeight+8)
+		drawPiecePDF(pdf, tr, layoutPiece(i+1, item, productName(products, item)), 10, originY)
+		drawn++
+	}
+
+	if drawn == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 8, tr("Nenhum item com medidas para desenhar."), "", 1, "L", false, 0, "")
+	}
+
+	return pdf
+}
+
+// drawPiecePDF desenha os elementos do layout a partir da origem (ox, oy) da página
+func drawPiecePDF(pdf *gofpdf.Fpdf, tr func(string) string, shapes []shape, ox, oy float64) {
+	for _, s := range shapes {
+		pdfStyle(pdf, s.Style)
+
+		switch s.Kind {
+		case "rect":
+			if s.Style == styleCutout {
+				pdf.SetDashPattern([]float64{1.5, 1}, 0)
+			}
+			fill := "D"
+			if s.Style == stylePiece {
+				fill = "FD"
+			}
+			pdf.Rect(ox+s.X1, oy+s.Y1, s.X2-s.X1, s.Y2-s.Y1, fill)
+			pdf.SetDashPattern([]float64{}, 0)
+		case "line":
+			if s.Style == styleCutout {
+				pdf.SetDashPattern([]float64{1.5, 1}, 0)
+			}
+			pdf.Line(ox+s.X1, oy+s.Y1, ox+s.X2, oy+s.Y2)
+			pdf.SetDashPattern([]float64{}, 0)
+		case "text":
+			text := tr(s.Text)
+			x := ox + s.X1
+			switch s.Anchor {
+			case "middle":
+				x -= pdf.GetStringWidth(text) / 2
+			case "end":
+				x -= pdf.GetStringWidth(text)
+			}
+			pdf.Text(x, oy+s.Y1, text)
+		}
+	}
+}
+
+func pdfStyle(pdf *gofpdf.Fpdf, style string) {
+	switch style {
+	case stylePiece:
+		pdf.SetDrawColor(34, 34, 34)
+		pdf.SetFillColor(242, 242, 242)
+		pdf.SetLineWidth(0.4)
+	case styleEdge:
+		pdf.SetDrawColor(21, 101, 192)
+		pdf.SetLineWidth(1.4)
+	case styleDimension:
+		pdf.SetDrawColor(85, 85, 85)
+		pdf.SetLineWidth(0.25)
+	case styleCutout:
+		pdf.SetDrawColor(198, 40, 40)
+		pdf.SetLineWidth(0.35)
+	case styleTitle:
+		pdf.SetFont("Arial", "B", 11)
+		pdf.SetTextColor(0, 0, 0)
+	default:
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(51, 51, 51)
+	}
+}
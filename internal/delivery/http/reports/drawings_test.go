@@ -0,0 +1,92 @@
+package reports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+func TestRenderPieceSVG(t *testing.T) {
+	item := &quoteDomain.QuoteItem{
+		WidthCM: 200, HeightCM: 62.5, Thickness: 2, Quantity: 1,
+		EdgeType: "reto", EdgeSides: "front,left,right", HasCutout: true,
+	}
+
+	svg := renderPieceSVG(layoutPiece(1, item, "Granito <Preto>"))
+
+	for _, want := range []string{
+		"<svg ",
+		">200 cm</text>",
+		">62,5 cm</text>",
+		">RECORTE</text>",
+		"Borda: reto",
+		"Granito &lt;Preto&gt;",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+	if got := strings.Count(svg, `stroke="#1565c0"`); got != 3 {
+		t.Errorf("finished edges = %d, want 3", got)
+	}
+}
+
+func TestRenderPieceSVG_PlainPiece(t *testing.T) {
+	item := &quoteDomain.QuoteItem{WidthCM: 80, HeightCM: 40, Quantity: 2}
+
+	svg := renderPieceSVG(layoutPiece(1, item, "Mármore"))
+
+	if strings.Contains(svg, "RECORTE") {
+		t.Error("piece without cutout should not have a cutout marker")
+	}
+	if strings.Contains(svg, `stroke="#1565c0"`) {
+		t.Error("piece without edge finish should not have finished sides")
+	}
+}
+
+func TestBuildDrawingsPDF(t *testing.T) {
+	quote := &quoteDomain.Quote{ID: "0b9d2c3e-1111-2222-3333-444455556666", Number: "ORC-2026-000001"}
+	items := []*quoteDomain.QuoteItem{
+		{ID: "i1", ProductID: productRef("p1"), WidthCM: 200, HeightCM: 60, Quantity: 1, EdgeType: "reto", HasCutout: true},
+		{ID: "i2", ProductID: productRef("p1"), WidthCM: 60, HeightCM: 60, Quantity: 2},
+		{ID: "i3", ProductID: productRef("p1"), Quantity: 1},
+		{ID: "i4", ProductID: productRef("p1"), WidthCM: 45, HeightCM: 120, Quantity: 1, EdgeType: "boleado"},
+	}
+	products := map[string]*productDomain.Product{"p1": {Name: "Granito São Gabriel"}}
+
+	pdf := buildDrawingsPDF(quote, items, products)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Fatalf("expected a PDF document")
+	}
+	if got := pdf.PageCount(); got != 2 {
+		t.Errorf("pages = %d, want 2", got)
+	}
+}
+
+func TestHasDrawing(t *testing.T) {
+	tests := []struct {
+		name string
+		item *quoteDomain.QuoteItem
+		want bool
+	}{
+		{"measured piece", &quoteDomain.QuoteItem{WidthCM: 80, HeightCM: 40}, true},
+		{"piece without measures", &quoteDomain.QuoteItem{WidthCM: 80}, false},
+		{"service line", &quoteDomain.QuoteItem{Kind: quoteDomain.ItemKindService, WidthCM: 80, HeightCM: 40}, false},
+		{"freight line", &quoteDomain.QuoteItem{Kind: quoteDomain.ItemKindFreight}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasDrawing(tt.item); got != tt.want {
+				t.Errorf("hasDrawing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
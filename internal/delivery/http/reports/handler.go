@@ -87,6 +87,7 @@ func (h *Handler) buildProductListDTO(c *gin.Context, limit, offset int) (*produ
 			Name:        p.Name,
 			Description: p.Description,
 			Price:       p.Price,
+			PriceType:   p.PriceType,
 			Stock:       p.Stock,
 			SKU:         p.SKU,
 			Category:    p.Category,
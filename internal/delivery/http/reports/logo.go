@@ -0,0 +1,135 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	maxLogoSize      = 2 << 20 // 2 MB
+	maxLogoRedirects = 3
+)
+
+var (
+	errLogoURL      = errors.New("logo url must be an https url")
+	errLogoAddress  = errors.New("logo host resolves to an internal address")
+	errLogoTooLarge = errors.New("logo exceeds the maximum size")
+)
+
+// sharedAddressSpace é a faixa 100.64.0.0/10 (CGNAT), que não entra em IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// newLogoClient cria o cliente que baixa o logo do tenant. A URL vem das settings,
+// que qualquer usuário do tenant pode alterar, então só https é aceito e a conexão
+// é recusada para endereços internos. O IP é conferido no momento da conexão, já
+// resolvido, o que também cobre redirecionamentos e DNS que muda de endereço.
+func newLogoClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: rejectInternalAddress,
+	}
+
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxLogoRedirects {
+				return http.ErrUseLastResponse
+			}
+			return checkLogoURL(req.URL)
+		},
+	}
+}
+
+// checkLogoURL aceita apenas URLs https com host.
+func checkLogoURL(u *url.URL) error {
+	if u.Scheme != "https" || u.Hostname() == "" {
+		return errLogoURL
+	}
+	return nil
+}
+
+// rejectInternalAddress recusa a conexão com loopback, redes privadas, link-local
+// (onde ficam os metadados de nuvem) e demais endereços que não são da internet.
+func rejectInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return errLogoAddress
+	}
+	return nil
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// downloadLogo baixa o logo e retorna a imagem com o tipo aceito pelo gofpdf.
+// Imagens acima de maxLogoSize são recusadas em vez de truncadas.
+func downloadLogo(ctx context.Context, client *http.Client, rawURL string) ([]byte, string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, "", errLogoURL
+	}
+	if err := checkLogoURL(u); err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errors.New("logo download failed: " + resp.Status)
+	}
+	if resp.ContentLength > maxLogoSize {
+		return nil, "", errLogoTooLarge
+	}
+
+	var imageType string
+	switch contentType := resp.Header.Get("Content-Type"); {
+	case strings.Contains(contentType, "png"):
+		imageType = "PNG"
+	case strings.Contains(contentType, "jpeg"), strings.Contains(contentType, "jpg"):
+		imageType = "JPG"
+	default:
+		return nil, "", errors.New("logo must be a png or jpeg image")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLogoSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > maxLogoSize {
+		return nil, "", errLogoTooLarge
+	}
+
+	return body, imageType, nil
+}
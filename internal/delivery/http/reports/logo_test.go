@@ -0,0 +1,81 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsInternalIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.0.0.5", true},
+		{"172.16.3.4", true},
+		{"192.168.1.10", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2606:4700:4700::1111", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := isInternalIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("isInternalIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadLogo_RejectsUnsafeURLs(t *testing.T) {
+	client := newLogoClient()
+
+	for _, rawURL := range []string{"http://example.com/logo.png", "file:///etc/passwd", "https://", "gopher://x"} {
+		if _, _, err := downloadLogo(context.Background(), client, rawURL); !errors.Is(err, errLogoURL) {
+			t.Errorf("downloadLogo(%q) error = %v, want %v", rawURL, err, errLogoURL)
+		}
+	}
+}
+
+func TestDownloadLogo_RejectsInternalAddress(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not reach an internal address")
+	}))
+	defer server.Close()
+
+	_, _, err := downloadLogo(context.Background(), newLogoClient(), server.URL+"/logo.png")
+	if !errors.Is(err, errLogoAddress) {
+		t.Fatalf("error = %v, want %v", err, errLogoAddress)
+	}
+}
+
+func TestDownloadLogo_Size(t *testing.T) {
+	size := maxLogoSize
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(bytes.Repeat([]byte{0}, size))
+	}))
+	defer server.Close()
+
+	logo, imageType, err := downloadLogo(context.Background(), server.Client(), server.URL)
+	if err != nil || imageType != "PNG" || len(logo) != maxLogoSize {
+		t.Fatalf("downloadLogo() = %d bytes, %q, %v", len(logo), imageType, err)
+	}
+
+	size = maxLogoSize + 1
+	if _, _, err := downloadLogo(context.Background(), server.Client(), server.URL); !errors.Is(err, errLogoTooLarge) {
+		t.Fatalf("error = %v, want %v", err, errLogoTooLarge)
+	}
+}
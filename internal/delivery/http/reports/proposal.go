@@ -0,0 +1,584 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	clientDomain "erp-api/internal/domain/client"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	settingsDomain "erp-api/internal/domain/settings"
+	clientUseCase "erp-api/internal/usecase/client"
+	productUseCase "erp-api/internal/usecase/product"
+	quoteUseCase "erp-api/internal/usecase/quote"
+	settingsUseCase "erp-api/internal/usecase/settings"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ProposalHandler gera a proposta comercial (PDF) de um orçamento
+type ProposalHandler struct {
+	quoteUseCase    quoteUseCase.UseCaseInterface
+	clientUseCase   clientUseCase.UseCaseInterface
+	productUseCase  productUseCase.UseCaseInterface
+	settingsUseCase settingsUseCase.UseCaseInterface
+	httpClient      *http.Client
+}
+
+// NewProposalHandler cria um novo handler de propostas
+func NewProposalHandler(
+	quoteUseCase quoteUseCase.UseCaseInterface,
+	clientUseCase clientUseCase.UseCaseInterface,
+	productUseCase productUseCase.UseCaseInterface,
+	settingsUseCase settingsUseCase.UseCaseInterface,
+) *ProposalHandler {
+	return &ProposalHandler{
+		quoteUseCase:    quoteUseCase,
+		clientUseCase:   clientUseCase,
+		productUseCase:  productUseCase,
+		settingsUseCase: settingsUseCase,
+		httpClient:      newLogoClient(),
+	}
+}
+
+// proposalData reúne tudo o que é impresso na proposta
+type proposalData struct {
+	Quote    *quoteDomain.Quote
+	Items    []*quoteDomain.QuoteItem
+	Options  []*quoteDomain.QuoteOption
+	Payment  *quoteDomain.PaymentPlan
+	Products map[string]*productDomain.Product
+	Client   *clientDomain.Client
+	Settings map[string]string
+	Logo     []byte
+	LogoType string
+}
+
+// QuotePDF gera a proposta comercial do orçamento com a identidade visual do tenant
+// @Summary Proposta comercial em PDF
+// @Tags quotes
+// @Produce application/pdf
+// @Param id path string true "Quote ID"
+// @Router /api/v1/quotes/{id}/pdf [get]
+func (h *ProposalHandler) QuotePDF(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	data, err := h.loadProposalData(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		switch err {
+		case quoteDomain.ErrQuoteNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Quote not found"})
+		case clientDomain.ErrClientNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Client not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load quote"})
+		}
+		return
+	}
+
+	pdf := buildProposalPDF(data)
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=proposta-%s.pdf", quoteNumber(data.Quote)))
+	if err := pdf.Output(c.Writer); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate PDF"})
+	}
+}
+
+func (h *ProposalHandler) loadProposalData(ctx context.Context, tenantID, quoteID string) (*proposalData, error) {
+	detail, err := h.quoteUseCase.GetDetail(ctx, tenantID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	quote, items := detail.Quote, detail.Items
+
+	client, err := h.clientUseCase.GetByID(ctx, tenantID, quote.ClientID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := h.settingsUseCase.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[string]*productDomain.Product)
+	for _, item := range items {
+		if item.IsService() {
+			continue
+		}
+		productID := item.ProductIDString()
+		if _, ok := products[productID]; ok {
+			continue
+		}
+		product, err := h.productUseCase.GetByID(ctx, tenantID, productID)
+		if err != nil && err != productDomain.ErrProductNotFound {
+			return nil, err
+		}
+		products[productID] = product
+	}
+
+	data := &proposalData{
+		Quote:    quote,
+		Items:    items,
+		Options:  detail.Options,
+		Payment:  detail.PaymentPlan,
+		Products: products,
+		Client:   client,
+		Settings: settings.Settings,
+	}
+
+	// O logo é opcional: se não puder ser baixado, a proposta sai sem ele
+	data.Logo, data.LogoType = h.fetchLogo(ctx, settings.Settings[settingsDomain.SettingLogoURL])
+
+	return data, nil
+}
+
+func (h *ProposalHandler) fetchLogo(ctx context.Context, logoURL string) ([]byte, string) {
+	if logoURL == "" {
+		return nil, ""
+	}
+
+	logo, imageType, err := downloadLogo(ctx, h.httpClient, logoURL)
+	if err != nil {
+		return nil, ""
+	}
+	return logo, imageType
+}
+
+func buildProposalPDF(data *proposalData) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	r, g, b := parseHexColor(data.Settings["primary_color"])
+
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf("%s - Página %d/{nb}", data.Settings["company_name"], pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Cabeçalho com logo e dados da empresa
+	pdf.SetFillColor(r, g, b)
+	pdf.Rect(0, 0, 210, 4, "F")
+
+	textX := 10.0
+	if len(data.Logo) > 0 {
+		options := gofpdf.ImageOptions{ImageType: data.LogoType}
+		pdf.RegisterImageOptionsReader("logo", options, bytes.NewReader(data.Logo))
+		if pdf.Ok() {
+			pdf.ImageOptions("logo", 10, 10, 0, 20, false, options, 0, "")
+			textX = 60
+		} else {
+			pdf.ClearError()
+		}
+	}
+
+	pdf.SetXY(textX, 10)
+	pdf.SetTextColor(r, g, b)
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 8, tr(data.Settings["company_name"]), "", 1, "L", false, 0, "")
+
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range companyLines(data.Settings) {
+		pdf.SetX(textX)
+		pdf.CellFormat(0, 4.5, tr(line), "", 1, "L", false, 0, "")
+	}
+
+	pdf.SetY(36)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "B", 13)
+	pdf.CellFormat(120, 8, tr("PROPOSTA COMERCIAL"), "", 0, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(0, 8, tr(fmt.Sprintf("Nº %s  -  %s", quoteNumber(data.Quote), data.Quote.CreatedAt.Format("02/01/2006"))), "", 1, "R", false, 0, "")
+	pdf.SetDrawColor(r, g, b)
+	pdf.Line(10, pdf.GetY(), 200, pdf.GetY())
+	pdf.Ln(3)
+
+	// Cliente
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 6, tr("Cliente"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	for _, line := range clientLines(data.Client) {
+		pdf.CellFormat(0, 4.5, tr(line), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Itens
+	headers := []string{"#", "Produto", "Medidas (cm)", "Área m²", "Borda", "Recorte", "Qtd", "Preço unit.", "Total"}
+	widths := []float64{7, 45, 28, 15, 20, 14, 9, 26, 26}
+	aligns := []string{"C", "L", "C", "R", "L", "C", "C", "R", "R"}
+
+	pdf.SetFillColor(r, g, b)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 8)
+	for i, hText := range headers {
+		pdf.CellFormat(widths[i], 7, tr(hText), "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetTextColor(0, 0, 0)
+	groups := proposalGroups(data)
+	i := 0
+	for _, group := range groups {
+		if group.Option != nil {
+			pdf.SetFont("Arial", "B", 8)
+			pdf.SetFillColor(235, 235, 235)
+			pdf.CellFormat(sum(widths), 6, tr(group.Option.Name), "1", 1, "L", true, 0, "")
+		}
+
+		pdf.SetFont("Arial", "", 8)
+		for _, item := range group.Items {
+			i++
+			row := []string{
+				strconv.Itoa(i),
+				truncate(productName(data.Products, item), 30),
+				formatDimensions(item),
+				formatOptionalFloat(item.AreaM2, 3),
+				truncate(item.EdgeType, 14),
+				boolToStr(item.HasCutout),
+				strconv.Itoa(item.Quantity),
+				formatMoney(item.UnitPrice) + priceTypeSuffix(item.PriceType),
+				formatMoney(item.Total),
+			}
+			for j, v := range row {
+				pdf.CellFormat(widths[j], 6, tr(v), "1", 0, aligns[j], false, 0, "")
+			}
+			pdf.Ln(-1)
+
+			if item.Notes != "" {
+				pdf.SetFont("Arial", "I", 7)
+				pdf.CellFormat(widths[0], 5, "", "LB", 0, "L", false, 0, "")
+				pdf.CellFormat(sum(widths[1:]), 5, tr(truncate(item.Notes, 120)), "RB", 1, "L", false, 0, "")
+				pdf.SetFont("Arial", "", 8)
+			}
+		}
+
+		if group.Option != nil && len(groups) > 1 {
+			pdf.SetFont("Arial", "B", 8)
+			pdf.CellFormat(sum(widths[:len(widths)-1]), 6, tr("Total da opção"), "1", 0, "R", false, 0, "")
+			pdf.CellFormat(widths[len(widths)-1], 6, tr(formatMoney(group.Option.TotalValue)), "1", 1, "R", false, 0, "")
+		}
+	}
+	pdf.Ln(3)
+
+	// Totais: com várias opções em aberto, o cliente compara o total de cada uma acima
+	labelWidth, valueWidth := 40.0, 30.0
+	if len(groups) > 1 {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.CellFormat(0, 6, tr("Escolha uma das opções acima para aprovação."), "", 1, "R", false, 0, "")
+		return finishProposal(pdf, tr, data)
+	}
+
+	totalsX := 200 - labelWidth - valueWidth
+	totals := [][2]string{
+		{"Subtotal", formatMoney(data.Quote.Subtotal)},
+		{discountLabel(data.Quote), "- " + formatMoney(data.Quote.DiscountAmount)},
+	}
+	pdf.SetFont("Arial", "", 9)
+	for _, t := range totals {
+		pdf.SetX(totalsX)
+		pdf.CellFormat(labelWidth, 6, tr(t[0]), "", 0, "R", false, 0, "")
+		pdf.CellFormat(valueWidth, 6, tr(t[1]), "", 1, "R", false, 0, "")
+	}
+	pdf.SetX(totalsX)
+	pdf.SetFont("Arial", "B", 11)
+	pdf.SetFillColor(r, g, b)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.CellFormat(labelWidth, 8, tr("Total"), "", 0, "R", true, 0, "")
+	pdf.CellFormat(valueWidth, 8, tr(formatMoney(data.Quote.TotalValue)), "", 1, "R", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	return finishProposal(pdf, tr, data)
+}
+
+// finishProposal imprime as observações do orçamento
+func finishProposal(pdf *gofpdf.Fpdf, tr func(string) string, data *proposalData) *gofpdf.Fpdf {
+	// Com várias opções em aberto os valores dependem da escolha; só as condições são impressas
+	if lines := paymentLines(data.Quote.PaymentTerms, data.Payment, len(proposalGroups(data)) == 1); len(lines) > 0 {
+		pdf.Ln(6)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(0, 6, tr("Condições de pagamento"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		for _, line := range lines {
+			pdf.CellFormat(0, 5, tr(line), "", 1, "L", false, 0, "")
+		}
+	}
+
+	if data.Quote.Notes != "" {
+		pdf.Ln(6)
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(0, 6, tr("Observações"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 9)
+		pdf.MultiCell(0, 5, tr(data.Quote.Notes), "", "L", false)
+	}
+
+	return pdf
+}
+
+// proposalGroup é um bloco de itens na proposta; Option é nil em orçamentos sem opções
+type proposalGroup struct {
+	Option *quoteDomain.QuoteOption
+	Items  []*quoteDomain.QuoteItem
+}
+
+// proposalGroups separa os itens por opção. Depois da aprovação só a opção
+// escolhida é impressa.
+func proposalGroups(data *proposalData) []proposalGroup {
+	if len(data.Options) == 0 {
+		return []proposalGroup{{Items: data.Items}}
+	}
+
+	var groups []proposalGroup
+	for _, option := range data.Options {
+		if selected := data.Quote.SelectedOptionID; selected != nil && *selected != option.ID {
+			continue
+		}
+		groups = append(groups, proposalGroup{Option: option, Items: quoteDomain.ItemsOf(option, data.Items)})
+	}
+	return groups
+}
+
+func companyLines(settings map[string]string) []string {
+	var lines []string
+
+	if address := settings["company_address"]; address != "" {
+		lines = append(lines, address)
+	}
+
+	cityState := joinNonEmpty(" - ", settings["company_city"], settings["company_state"])
+	if location := joinNonEmpty("  CEP ", cityState, settings["company_zip"]); location != "" {
+		lines = append(lines, location)
+	}
+
+	if contact := joinNonEmpty("  |  ", settings["company_phone"], settings["company_email"]); contact != "" {
+		lines = append(lines, contact)
+	}
+
+	return lines
+}
+
+func clientLines(client *clientDomain.Client) []string {
+	lines := []string{client.Name}
+
+	if client.Document != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", client.DocumentType, client.Document))
+	}
+	if contact := joinNonEmpty("  |  ", client.Phone, client.Email); contact != "" {
+		lines = append(lines, contact)
+	}
+
+	cityState := joinNonEmpty(" - ", client.City, client.State)
+	if address := joinNonEmpty(", ", client.Address, cityState); address != "" {
+		if client.ZipCode != "" {
+			address += "  CEP " + client.ZipCode
+		}
+		lines = append(lines, address)
+	}
+
+	return lines
+}
+
+func productName(products map[string]*productDomain.Product, item *quoteDomain.QuoteItem) string {
+	if item.IsService() {
+		return item.Label()
+	}
+	if product := products[item.ProductIDString()]; product != nil {
+		return product.Name
+	}
+	return "-"
+}
+
+func formatDimensions(item *quoteDomain.QuoteItem) string {
+	if item.WidthCM == 0 && item.HeightCM == 0 {
+		return "-"
+	}
+	dimensions := fmt.Sprintf("%s x %s", formatFloat(item.WidthCM), formatFloat(item.HeightCM))
+	if item.Thickness > 0 {
+		dimensions += " x " + formatFloat(item.Thickness)
+	}
+	return dimensions
+}
+
+func priceTypeSuffix(priceType productDomain.PriceType) string {
+	switch priceType {
+	case productDomain.PriceTypeSquareMeter:
+		return "/m²"
+	case productDomain.PriceTypeLinearMeter:
+		return "/m"
+	default:
+		return ""
+	}
+}
+
+func formatMoney(v float64) string {
+	// Formato brasileiro: R$ 1.234,56
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, decPart := s[:len(s)-3], s[len(s)-2:]
+	var grouped strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	result := "R$ " + grouped.String() + "," + decPart
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+func formatFloat(v float64) string {
+	return strings.Replace(strconv.FormatFloat(v, 'f', -1, 64), ".", ",", 1)
+}
+
+func formatOptionalFloat(v float64, decimals int) string {
+	if v == 0 {
+		return "-"
+	}
+	return strings.Replace(strconv.FormatFloat(v, 'f', decimals, 64), ".", ",", 1)
+}
+
+// parseHexColor converte "#RRGGBB" em RGB; cores inválidas usam um cinza escuro.
+func parseHexColor(hex string) (int, int, int) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) != 6 {
+		return 51, 51, 51
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 51, 51, 51
+	}
+	return int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// quoteNumber usa o número sequencial; orçamentos antigos, sem número, caem no ID curto
+func quoteNumber(quote *quoteDomain.Quote) string {
+	if quote.Number != "" {
+		return quote.Number
+	}
+	return shortID(quote.ID.String())
+}
+
+func shortID(id string) string {
+	if len(id) <= 8 {
+		return strings.ToUpper(id)
+	}
+	return strings.ToUpper(id[:8])
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// discountLabel indica o percentual quando o desconto do orçamento é percentual
+func discountLabel(quote *quoteDomain.Quote) string {
+	if quote.DiscountType == quoteDomain.DiscountTypePercent {
+		return "Desconto (" + formatFloat(quote.Discount) + "%)"
+	}
+	return "Desconto"
+}
+
+var paymentMethodLabels = map[quoteDomain.PaymentMethod]string{
+	quoteDomain.PaymentMethodPix:    "PIX",
+	quoteDomain.PaymentMethodBoleto: "Boleto",
+	quoteDomain.PaymentMethodCard:   "Cartão",
+}
+
+// paymentLines descreve as condições de pagamento; com withValues inclui os valores do cronograma
+func paymentLines(terms quoteDomain.PaymentTerms, plan *quoteDomain.PaymentPlan, withValues bool) []string {
+	var lines []string
+
+	if len(terms.Methods) > 0 {
+		labels := make([]string, 0, len(terms.Methods))
+		for _, method := range terms.Methods {
+			labels = append(labels, paymentMethodLabels[method])
+		}
+		lines = append(lines, "Formas de pagamento: "+strings.Join(labels, ", "))
+	}
+
+	withValues = withValues && plan != nil && len(plan.Schedule) > 0
+
+	if terms.Installments == 0 {
+		line := "À vista"
+		if withValues {
+			line += ": " + formatMoney(plan.TotalValue)
+		}
+		if terms.CashDiscountPercent > 0 {
+			line += " (desconto de " + formatFloat(terms.CashDiscountPercent) + "%)"
+		}
+		return append(lines, line)
+	}
+
+	var parts []string
+	if terms.DownPaymentPercent > 0 {
+		entry := "Entrada de " + formatFloat(terms.DownPaymentPercent) + "%"
+		if withValues {
+			entry = "Entrada de " + formatMoney(plan.DownPayment)
+		}
+		parts = append(parts, entry)
+	}
+
+	installments := fmt.Sprintf("%dx", terms.Installments)
+	if withValues {
+		// Sem juros a última parcela pode diferir nos centavos
+		schedule := plan.Schedule[len(plan.Schedule)-terms.Installments:]
+		first, last := schedule[0].Amount, schedule[len(schedule)-1].Amount
+		installments = fmt.Sprintf("%dx de %s", terms.Installments, formatMoney(first))
+		if last != first {
+			installments = fmt.Sprintf("%dx de %s + 1x de %s", terms.Installments-1, formatMoney(first), formatMoney(last))
+		}
+	}
+	interval := terms.IntervalDays
+	if interval <= 0 {
+		interval = quoteDomain.DefaultIntervalDays
+	}
+	installments += fmt.Sprintf(" a cada %d dias", interval)
+	if terms.InterestRate > 0 {
+		installments += " (juros de " + formatFloat(terms.InterestRate) + "% a.m.)"
+	} else {
+		installments += " sem juros"
+	}
+	parts = append(parts, installments)
+	lines = append(lines, strings.Join(parts, " + "))
+
+	if withValues && plan.Interest > 0 {
+		lines = append(lines, "Total a prazo: "+formatMoney(plan.TotalValue))
+	}
+	return lines
+}
@@ -0,0 +1,142 @@
+package reports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	clientDomain "erp-api/internal/domain/client"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
+)
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[float64]string{
+		0:          "R$ 0,00",
+		12.5:       "R$ 12,50",
+		1234.56:    "R$ 1.234,56",
+		1234567.89: "R$ 1.234.567,89",
+		-980:       "-R$ 980,00",
+	}
+
+	for value, want := range tests {
+		if got := formatMoney(value); got != want {
+			t.Errorf("formatMoney(%v) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	r, g, b := parseHexColor("#2196F3")
+	if r != 0x21 || g != 0x96 || b != 0xF3 {
+		t.Errorf("parseHexColor() = %d,%d,%d", r, g, b)
+	}
+
+	r, g, b = parseHexColor("azul")
+	if r != 51 || g != 51 || b != 51 {
+		t.Errorf("expected fallback color, got %d,%d,%d", r, g, b)
+	}
+}
+
+func TestBuildProposalPDF(t *testing.T) {
+	data := &proposalData{
+		Quote: &quoteDomain.Quote{
+			ID:             "0b9d2c3e-1111-2222-3333-444455556666",
+			Subtotal:       1910,
+			Discount:       60,
+			DiscountAmount: 60,
+			TotalValue:     1850,
+			Notes:          "Prazo de entrega: 15 dias úteis.",
+			CreatedAt:      time.Now(),
+		},
+		Items: []*quoteDomain.QuoteItem{
+			{
+				ProductID: productRef("p1"), WidthCM: 200, HeightCM: 60, Thickness: 2, AreaM2: 1.2,
+				PriceType: productDomain.PriceTypeSquareMeter, UnitPrice: 500, Quantity: 2,
+				EdgeType: "reto", HasCutout: true, Total: 1660, Notes: "Bancada da cozinha",
+			},
+			{Kind: quoteDomain.ItemKindService, Description: "Instalação", PriceType: productDomain.PriceTypeUnit, UnitPrice: 250, Quantity: 1, Total: 250},
+		},
+		Products: map[string]*productDomain.Product{"p1": {Name: "Granito São Gabriel"}},
+		Client:   &clientDomain.Client{Name: "João da Silva", DocumentType: "CPF", Document: "12345678909", City: "Curitiba", State: "PR"},
+		Settings: map[string]string{"company_name": "Marmoraria Exemplo", "primary_color": "#2196F3"},
+	}
+
+	var buf bytes.Buffer
+	if err := buildProposalPDF(data).Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Fatalf("expected a PDF document")
+	}
+}
+
+func TestProposalGroups(t *testing.T) {
+	opt1, opt2 := dbtypes.UUID("opt-1"), dbtypes.UUID("opt-2")
+	data := &proposalData{
+		Quote:   &quoteDomain.Quote{},
+		Options: []*quoteDomain.QuoteOption{{ID: opt1, Name: "Granito"}, {ID: opt2, Name: "Quartzo"}},
+		Items: []*quoteDomain.QuoteItem{
+			{OptionID: &opt1, ProductID: productRef("p1"), Quantity: 1, Total: 900},
+			{OptionID: &opt2, ProductID: productRef("p2"), Quantity: 1, Total: 1400},
+		},
+		Products: map[string]*productDomain.Product{"p1": {Name: "Granito"}, "p2": {Name: "Quartzo"}},
+		Client:   &clientDomain.Client{Name: "Maria Souza"},
+		Settings: map[string]string{},
+	}
+
+	if groups := proposalGroups(data); len(groups) != 2 || len(groups[0].Items) != 1 {
+		t.Fatalf("expected one group per option, got %+v", groups)
+	}
+
+	var buf bytes.Buffer
+	if err := buildProposalPDF(data).Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	// Depois da aprovação só a opção escolhida aparece na proposta
+	data.Quote.SelectedOptionID = &opt2
+	groups := proposalGroups(data)
+	if len(groups) != 1 || groups[0].Option.Name != "Quartzo" {
+		t.Errorf("expected only the selected option, got %+v", groups)
+	}
+}
+
+func TestPaymentLines(t *testing.T) {
+	terms := quoteDomain.PaymentTerms{
+		Methods:            quoteDomain.PaymentMethods{quoteDomain.PaymentMethodPix, quoteDomain.PaymentMethodCard},
+		DownPaymentPercent: 30,
+		Installments:       3,
+	}
+	plan := quoteDomain.BuildPaymentPlan(terms, 1000, nil)
+
+	got := paymentLines(terms, plan, true)
+	want := []string{
+		"Formas de pagamento: PIX, Cartão",
+		"Entrada de R$ 300,00 + 2x de R$ 233,33 + 1x de R$ 233,34 a cada 30 dias sem juros",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("paymentLines() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Sem valores (várias opções em aberto) só as condições são descritas
+	if got := paymentLines(terms, plan, false); got[1] != "Entrada de 30% + 3x a cada 30 dias sem juros" {
+		t.Errorf("paymentLines() without values = %q", got[1])
+	}
+
+	cash := quoteDomain.PaymentTerms{CashDiscountPercent: 5}
+	if got := paymentLines(cash, quoteDomain.BuildPaymentPlan(cash, 1000, nil), true); len(got) != 1 || got[0] != "À vista: R$ 950,00 (desconto de 5%)" {
+		t.Errorf("paymentLines() cash = %q", got)
+	}
+}
+
+func productRef(id string) *dbtypes.UUID {
+	ref := dbtypes.UUID(id)
+	return &ref
+}
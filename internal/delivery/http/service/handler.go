@@ -0,0 +1,192 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	serviceDomain "erp-api/internal/domain/service"
+	serviceUseCase "erp-api/internal/usecase/service"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	serviceUseCase serviceUseCase.UseCaseInterface
+}
+
+func NewHandler(serviceUseCase serviceUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		serviceUseCase: serviceUseCase,
+	}
+}
+
+// Create cadastra um serviço no catálogo do tenant
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req serviceDomain.CreateServiceDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	service, err := h.serviceUseCase.Create(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, service)
+}
+
+// GetByID busca um serviço do catálogo
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	service, err := h.serviceUseCase.GetByID(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, service)
+}
+
+// Update altera um serviço; orçamentos existentes não mudam
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req serviceDomain.UpdateServiceDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	service, err := h.serviceUseCase.Update(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, service)
+}
+
+// Delete remove um serviço do catálogo
+func (h *Handler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.serviceUseCase.Delete(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista os serviços do tenant; active=true traz só os ativos
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	activeOnly, err := strconv.ParseBool(c.DefaultQuery("active", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid active parameter",
+		})
+		return
+	}
+
+	services, err := h.serviceUseCase.List(c.Request.Context(), tenantID, activeOnly, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.serviceUseCase.Count(c.Request.Context(), tenantID, activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, serviceDomain.ServiceListDTO{
+		Services: services,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case serviceDomain.ErrServiceNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Service not found",
+		})
+	case serviceDomain.ErrServiceAlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A service with this name already exists",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
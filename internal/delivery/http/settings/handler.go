@@ -72,6 +72,10 @@ func (h *Handler) Update(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Invalid email",
 			})
+		case settingsDomain.ErrInvalidLogoURL:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Logo URL must use https",
+			})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
@@ -0,0 +1,325 @@
+package slab
+
+import (
+	"net/http"
+	"strconv"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	slabUseCase "erp-api/internal/usecase/slab"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	slabUseCase slabUseCase.UseCaseInterface
+}
+
+func NewHandler(slabUseCase slabUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		slabUseCase: slabUseCase,
+	}
+}
+
+// Create cadastra uma chapa no estoque
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req slabDomain.CreateSlabDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	slab, err := h.slabUseCase.Create(c.Request.Context(), tenantID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, slab)
+}
+
+// GetByID busca uma chapa com as fotos
+func (h *Handler) GetByID(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	slab, err := h.slabUseCase.GetByID(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, slab)
+}
+
+// Update altera lote, localização, espessura, observações ou situação da chapa
+func (h *Handler) Update(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req slabDomain.UpdateSlabDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	slab, err := h.slabUseCase.Update(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, slab)
+}
+
+// Delete remove uma chapa cadastrada por engano
+func (h *Handler) Delete(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.slabUseCase.Delete(c.Request.Context(), tenantID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List lista as chapas, com filtros por produto, lote, situação e só retalhos
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	filter := slabDomain.ListFilter{
+		ProductID:    c.Query("product_id"),
+		Lot:          c.Query("lot"),
+		Status:       slabDomain.Status(c.Query("status")),
+		RemnantsOnly: c.Query("remnants") == "true",
+	}
+
+	slabs, err := h.slabUseCase.List(c.Request.Context(), tenantID, filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	total, err := h.slabUseCase.Count(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, slabDomain.SlabListDTO{
+		Slabs:  slabs,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// AddPhoto anexa uma foto à chapa
+func (h *Handler) AddPhoto(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req slabDomain.AddPhotoDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	photo, err := h.slabUseCase.AddPhoto(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, photo)
+}
+
+// DeletePhoto remove uma foto da chapa
+func (h *Handler) DeletePhoto(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.slabUseCase.DeletePhoto(c.Request.Context(), tenantID, c.Param("id"), c.Param("photoId")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterRemnants cadastra as sobras do corte como novas chapas
+func (h *Handler) RegisterRemnants(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req slabDomain.RegisterRemnantsDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	remnants, err := h.slabUseCase.RegisterRemnants(c.Request.Context(), tenantID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"remnants": remnants,
+	})
+}
+
+// SearchRemnants procura retalhos que comportam a peça de um item do orçamento.
+// Por padrão a peça pode ser girada; margin_cm reserva folga para o corte.
+func (h *Handler) SearchRemnants(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	allowRotation := c.DefaultQuery("allow_rotation", "true") != "false"
+
+	marginCM, err := strconv.ParseFloat(c.DefaultQuery("margin_cm", "0"), 64)
+	if err != nil || marginCM < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid margin_cm parameter",
+		})
+		return
+	}
+
+	result, err := h.slabUseCase.SearchRemnants(c.Request.Context(), tenantID, c.Param("id"), c.Param("itemId"), allowRotation, marginCM)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case slabDomain.ErrSlabNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slab not found",
+		})
+	case slabDomain.ErrPhotoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Slab photo not found",
+		})
+	case productDomain.ErrProductNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Product not found",
+		})
+	case quoteDomain.ErrQuoteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote not found",
+		})
+	case quoteDomain.ErrQuoteItemNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Quote item not found",
+		})
+	case slabDomain.ErrInvalidStatusChange:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Used and discarded slabs cannot change status",
+		})
+	case slabDomain.ErrReservedByQuote:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Reserved slabs are managed by their quote",
+		})
+	case slabDomain.ErrSlabDiscarded:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Discarded slabs cannot have remnants",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package stock
+
+import (
+	"net/http"
+	"strconv"
+
+	productDomain "erp-api/internal/domain/product"
+	stockDomain "erp-api/internal/domain/stock"
+	stockUseCase "erp-api/internal/usecase/stock"
+	"erp-api/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	stockUseCase stockUseCase.UseCaseInterface
+}
+
+func NewHandler(stockUseCase stockUseCase.UseCaseInterface) *Handler {
+	return &Handler{
+		stockUseCase: stockUseCase,
+	}
+}
+
+// Create lança uma entrada, saída, ajuste ou reserva no estoque do produto
+func (h *Handler) Create(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	var req stockDomain.CreateMovementDTO
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	movement, err := h.stockUseCase.Record(c.Request.Context(), tenantID, userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, movement)
+}
+
+// List mostra o histórico de movimentos do produto, do mais recente ao mais antigo
+func (h *Handler) List(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid limit parameter",
+		})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid offset parameter",
+		})
+		return
+	}
+
+	productID := c.Param("id")
+	filter := stockDomain.ListFilter{
+		Type: stockDomain.MovementType(c.Query("type")),
+	}
+
+	movements, err := h.stockUseCase.History(c.Request.Context(), tenantID, productID, filter, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	total, err := h.stockUseCase.CountHistory(c.Request.Context(), tenantID, productID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stockDomain.MovementListDTO{
+		Movements: movements,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	})
+}
+
+// Reconcile confere o saldo do produto com o livro de estoque e corrige divergências
+func (h *Handler) Reconcile(c *gin.Context) {
+	tenantID, exists := middleware.GetTenantIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.stockUseCase.Reconcile(c.Request.Context(), tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch err {
+	case productDomain.ErrProductNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Product not found",
+		})
+	case stockDomain.ErrInsufficientStock:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Insufficient stock for this movement",
+		})
+	case stockDomain.ErrInvalidReservation:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Cannot release more than is reserved",
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package appointment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTeamNotFound        = errors.New("team not found")
+	ErrTeamAlreadyExists   = errors.New("a team with this name already exists")
+	ErrTeamInactive        = errors.New("team is inactive")
+	ErrTeamInUse           = errors.New("team has appointments, deactivate it instead")
+	ErrInvalidTeamName     = errors.New("team name is required")
+	ErrAppointmentNotFound = errors.New("appointment not found")
+	ErrInvalidType         = errors.New("appointment type must be measurement or installation")
+	ErrInvalidStatus       = errors.New("invalid appointment status")
+	ErrInvalidTimeWindow   = errors.New("ends_at must be after starts_at and at most 24 hours later")
+	ErrScheduleConflict    = errors.New("team already has an appointment in this time window")
+	ErrAppointmentClosed   = errors.New("appointment is already done or cancelled")
+	ErrClientMismatch      = errors.New("quote or order belongs to another client")
+	ErrInvalidStatusChange = errors.New("invalid appointment status transition")
+)
+
+// MaxDuration limita a janela de uma visita; janelas maiores costumam ser erro de digitação.
+const MaxDuration = 24 * time.Hour
+
+func (t AppointmentType) IsValid() bool {
+	return t == TypeMeasurement || t == TypeInstallation
+}
+
+func (s AppointmentStatus) IsValid() bool {
+	return s == StatusScheduled || s == StatusDone || s == StatusCancelled
+}
+
+// Label é o nome da visita para a agenda.
+func (t AppointmentType) Label() string {
+	if t == TypeInstallation {
+		return "Instalação"
+	}
+	return "Medição"
+}
+
+func (req *CreateTeamDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidTeamName
+	}
+	return nil
+}
+
+func (req *UpdateTeamDTO) Validate() error {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return ErrInvalidTeamName
+	}
+	return nil
+}
+
+func (req *CreateAppointmentDTO) Validate() error {
+	if !req.Type.IsValid() {
+		return ErrInvalidType
+	}
+	return ValidateWindow(req.StartsAt, req.EndsAt)
+}
+
+func (req *UpdateAppointmentDTO) Validate() error {
+	if req.Type != nil && !req.Type.IsValid() {
+		return ErrInvalidType
+	}
+	if req.TeamID != nil && strings.TrimSpace(*req.TeamID) == "" {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+func (req *UpdateAppointmentStatusDTO) Validate() error {
+	if !req.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// ValidateWindow confere que a janela termina depois de começar e não passa de MaxDuration.
+func ValidateWindow(startsAt, endsAt time.Time) error {
+	if startsAt.IsZero() || !endsAt.After(startsAt) || endsAt.Sub(startsAt) > MaxDuration {
+		return ErrInvalidTimeWindow
+	}
+	return nil
+}
+
+// IsOpen indica se a visita ainda pode ser remarcada.
+func (a *Appointment) IsOpen() bool {
+	return a.Status == StatusScheduled
+}
+
+// ChangeStatus conclui ou cancela uma visita agendada.
+func (a *Appointment) ChangeStatus(next AppointmentStatus) error {
+	if !a.IsOpen() {
+		return ErrAppointmentClosed
+	}
+	if next == StatusScheduled {
+		return ErrInvalidStatusChange
+	}
+	a.Status = next
+	return nil
+}
+
+// NewFeedToken gera um token aleatório para o calendário público da equipe.
+func NewFeedToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
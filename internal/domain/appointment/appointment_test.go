@@ -0,0 +1,103 @@
+package appointment
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateWindow(t *testing.T) {
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		end     time.Time
+		wantErr bool
+	}{
+		{"valid", start, start.Add(2 * time.Hour), false},
+		{"full day", start, start.Add(MaxDuration), false},
+		{"missing start", time.Time{}, start, true},
+		{"ends before start", start, start.Add(-time.Hour), true},
+		{"empty window", start, start, true},
+		{"too long", start, start.Add(MaxDuration + time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWindow(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	a := &Appointment{Status: StatusScheduled}
+	if err := a.ChangeStatus(StatusScheduled); err != ErrInvalidStatusChange {
+		t.Fatalf("ChangeStatus(scheduled) error = %v, want %v", err, ErrInvalidStatusChange)
+	}
+	if err := a.ChangeStatus(StatusDone); err != nil {
+		t.Fatalf("ChangeStatus(done) error = %v", err)
+	}
+	if a.Status != StatusDone {
+		t.Fatalf("status = %s, want %s", a.Status, StatusDone)
+	}
+	if err := a.ChangeStatus(StatusCancelled); err != ErrAppointmentClosed {
+		t.Fatalf("ChangeStatus() on done appointment error = %v, want %v", err, ErrAppointmentClosed)
+	}
+}
+
+func TestBuildCalendar(t *testing.T) {
+	team := &Team{ID: "team1", Name: "Equipe Sul"}
+	appointments := []*Appointment{
+		{
+			ID:       "a1",
+			ClientID: "c1",
+			Type:     TypeInstallation,
+			StartsAt: time.Date(2024, 3, 10, 9, 0, 0, 0, time.FixedZone("BRT", -3*3600)),
+			EndsAt:   time.Date(2024, 3, 10, 12, 0, 0, 0, time.FixedZone("BRT", -3*3600)),
+			Address:  "Rua das Flores, 10; fundos",
+			City:     "Curitiba",
+			State:    "PR",
+			Notes:    "Levar silicone\nConfirmar acesso ao prédio",
+		},
+	}
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ics := string(BuildCalendar(team, appointments, map[string]string{"c1": "Maria"}, now))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Equipe Sul\r\n",
+		"UID:a1@erp-api\r\n",
+		"DTSTART:20240310T120000Z\r\n",
+		"DTEND:20240310T150000Z\r\n",
+		"SUMMARY:Instalação - Maria\r\n",
+		"LOCATION:Rua das Flores\\, 10\\; fundos\\, Curitiba/PR\r\n",
+		"DESCRIPTION:Levar silicone\\nConfirmar acesso ao prédio\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar missing %q\n%s", want, ics)
+		}
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("ção ", 40)
+	folded := foldLine(line)
+
+	for i, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("line %d has %d octets", i, len(part))
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line %d must start with a space", i)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != line {
+		t.Errorf("unfolded line = %q, want %q", got, line)
+	}
+}
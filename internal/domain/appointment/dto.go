@@ -0,0 +1,78 @@
+package appointment
+
+import "time"
+
+type CreateTeamDTO struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+type UpdateTeamDTO struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+type TeamListDTO struct {
+	Teams  []*Team `json:"teams"`
+	Total  int     `json:"total"`
+	Limit  int     `json:"limit"`
+	Offset int     `json:"offset"`
+}
+
+// TeamFeedDTO é o endereço do calendário da equipe para assinatura no celular
+type TeamFeedDTO struct {
+	TeamID string `json:"team_id"`
+	URL    string `json:"url"`
+}
+
+type CreateAppointmentDTO struct {
+	TeamID   string          `json:"team_id" binding:"required"`
+	ClientID string          `json:"client_id" binding:"required"`
+	QuoteID  string          `json:"quote_id,omitempty"`
+	OrderID  string          `json:"order_id,omitempty"`
+	Type     AppointmentType `json:"type" binding:"required"`
+	StartsAt time.Time       `json:"starts_at" binding:"required"`
+	EndsAt   time.Time       `json:"ends_at" binding:"required"`
+	Address  string          `json:"address,omitempty"`
+	City     string          `json:"city,omitempty"`
+	State    string          `json:"state,omitempty"`
+	ZipCode  string          `json:"zip_code,omitempty"`
+	Notes    string          `json:"notes,omitempty"`
+}
+
+// UpdateAppointmentDTO remarca ou corrige a visita; campos nulos não mudam
+type UpdateAppointmentDTO struct {
+	TeamID   *string          `json:"team_id,omitempty"`
+	Type     *AppointmentType `json:"type,omitempty"`
+	StartsAt *time.Time       `json:"starts_at,omitempty"`
+	EndsAt   *time.Time       `json:"ends_at,omitempty"`
+	Address  *string          `json:"address,omitempty"`
+	City     *string          `json:"city,omitempty"`
+	State    *string          `json:"state,omitempty"`
+	ZipCode  *string          `json:"zip_code,omitempty"`
+	Notes    *string          `json:"notes,omitempty"`
+}
+
+type UpdateAppointmentStatusDTO struct {
+	Status AppointmentStatus `json:"status" binding:"required"`
+}
+
+// ListFilter restringe a listagem; From/To trazem as visitas que se sobrepõem ao período
+type ListFilter struct {
+	TeamID   string
+	ClientID string
+	QuoteID  string
+	OrderID  string
+	Type     AppointmentType
+	Status   AppointmentStatus
+	From     *time.Time
+	To       *time.Time
+}
+
+type AppointmentListDTO struct {
+	Appointments []*Appointment `json:"appointments"`
+	Total        int            `json:"total"`
+	Limit        int            `json:"limit"`
+	Offset       int            `json:"offset"`
+}
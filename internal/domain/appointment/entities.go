@@ -0,0 +1,80 @@
+package appointment
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+type AppointmentType string
+
+const (
+	TypeMeasurement  AppointmentType = "measurement"  // medição no local
+	TypeInstallation AppointmentType = "installation" // instalação das peças
+)
+
+type AppointmentStatus string
+
+const (
+	StatusScheduled AppointmentStatus = "scheduled"
+	StatusDone      AppointmentStatus = "done"
+	StatusCancelled AppointmentStatus = "cancelled"
+)
+
+// Team é uma equipe de medição ou instalação. FeedToken identifica o calendário
+// público (.ics) da equipe e pode ser trocado para revogar assinaturas antigas.
+type Team struct {
+	ID          dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID `json:"tenant_id" gorm:"not null;uniqueIndex:idx_teams_tenant_name"`
+	Name        string       `json:"name" gorm:"not null;size:120;uniqueIndex:idx_teams_tenant_name"`
+	Description string       `json:"description,omitempty"`
+	IsActive    bool         `json:"is_active" gorm:"default:true"`
+	FeedToken   string       `json:"-" gorm:"size:64;uniqueIndex"`
+	CreatedAt   time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (t *Team) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// Appointment é uma visita de medição ou instalação de uma equipe na janela
+// [StartsAt, EndsAt). Orçamento e pedido são opcionais.
+type Appointment struct {
+	ID       dbtypes.UUID      `json:"id" gorm:"primaryKey"`
+	TenantID dbtypes.UUID      `json:"tenant_id" gorm:"not null;index"`
+	TeamID   dbtypes.UUID      `json:"team_id" gorm:"not null;index:idx_appointments_team_window"`
+	ClientID dbtypes.UUID      `json:"client_id" gorm:"not null;index"`
+	QuoteID  *dbtypes.UUID     `json:"quote_id,omitempty" gorm:"index"`
+	OrderID  *dbtypes.UUID     `json:"order_id,omitempty" gorm:"index"`
+	Type     AppointmentType   `json:"type" gorm:"size:20;not null"`
+	Status   AppointmentStatus `json:"status" gorm:"size:20;not null;default:'scheduled'"`
+
+	// Janela de atendimento
+	StartsAt time.Time `json:"starts_at" gorm:"not null;index:idx_appointments_team_window"`
+	EndsAt   time.Time `json:"ends_at" gorm:"not null"`
+
+	// Endereço da visita; quando não informado, vem do cadastro do cliente
+	Address string `json:"address,omitempty"`
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty" gorm:"size:2"`
+	ZipCode string `json:"zip_code,omitempty" gorm:"size:9"`
+
+	Notes     string        `json:"notes,omitempty"`
+	CreatedBy *dbtypes.UUID `json:"created_by,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (a *Appointment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package appointment
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+// BuildCalendar monta o calendário iCalendar (RFC 5545) da equipe. clients
+// mapeia o ID do cliente para o nome exibido no título de cada visita.
+func BuildCalendar(team *Team, appointments []*Appointment, clients map[string]string, now time.Time) []byte {
+	var b strings.Builder
+	line := func(value string) {
+		b.WriteString(foldLine(value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//erp-api//Agenda de equipes//PT")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeText(team.Name))
+	// Sugere aos aplicativos de agenda atualizar a assinatura a cada hora
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	line("X-PUBLISHED-TTL:PT1H")
+
+	for _, a := range appointments {
+		summary := a.Type.Label()
+		if name := clients[a.ClientID.String()]; name != "" {
+			summary += " - " + name
+		}
+		stamp := a.UpdatedAt
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s@erp-api", a.ID))
+		line("DTSTAMP:" + stamp.UTC().Format(icalTimeFormat))
+		line("DTSTART:" + a.StartsAt.UTC().Format(icalTimeFormat))
+		line("DTEND:" + a.EndsAt.UTC().Format(icalTimeFormat))
+		line("SUMMARY:" + escapeText(summary))
+		if location := a.Location(); location != "" {
+			line("LOCATION:" + escapeText(location))
+		}
+		if a.Notes != "" {
+			line("DESCRIPTION:" + escapeText(a.Notes))
+		}
+		line("STATUS:CONFIRMED")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// Location junta endereço, cidade/UF e CEP em uma linha.
+func (a *Appointment) Location() string {
+	var parts []string
+	if a.Address != "" {
+		parts = append(parts, a.Address)
+	}
+	switch {
+	case a.City != "" && a.State != "":
+		parts = append(parts, a.City+"/"+a.State)
+	case a.City != "":
+		parts = append(parts, a.City)
+	case a.State != "":
+		parts = append(parts, a.State)
+	}
+	if a.ZipCode != "" {
+		parts = append(parts, a.ZipCode)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeText escapa os caracteres especiais de valores TEXT.
+func escapeText(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, ";", "\\;")
+	value = strings.ReplaceAll(value, ",", "\\,")
+	value = strings.ReplaceAll(value, "\r\n", "\\n")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	return value
+}
+
+// foldLine quebra linhas acima de 75 octetos, sem partir caracteres UTF-8;
+// as continuações começam com um espaço.
+func foldLine(value string) string {
+	const limit = 75
+	if len(value) <= limit {
+		return value
+	}
+
+	var b strings.Builder
+	size := 0
+	for _, r := range value {
+		n := len(string(r))
+		if size+n > limit {
+			b.WriteString("\r\n ")
+			size = 1
+		}
+		b.WriteRune(r)
+		size += n
+	}
+	return b.String()
+}
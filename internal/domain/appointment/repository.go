@@ -0,0 +1,36 @@
+package appointment
+
+import (
+	"context"
+	"time"
+)
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *Team) error
+	GetByID(ctx context.Context, tenantID, id string) (*Team, error)
+	// GetForUpdate lê a equipe travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Team, error)
+	GetByName(ctx context.Context, tenantID, name string) (*Team, error)
+	GetByFeedToken(ctx context.Context, token string) (*Team, error)
+	Update(ctx context.Context, team *Team) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*Team, error)
+	Count(ctx context.Context, tenantID string) (int, error)
+}
+
+type Repository interface {
+	Create(ctx context.Context, appointment *Appointment) error
+	GetByID(ctx context.Context, tenantID, id string) (*Appointment, error)
+	// GetForUpdate lê a visita travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Appointment, error)
+	Update(ctx context.Context, appointment *Appointment) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, filter ListFilter, limit, offset int) ([]*Appointment, error)
+	Count(ctx context.Context, tenantID string, filter ListFilter) (int, error)
+	// ListOverlapping retorna as visitas não canceladas da equipe que se
+	// sobrepõem à janela, exceto excludeID. Visitas encostadas (uma termina
+	// quando a outra começa) não se sobrepõem
+	ListOverlapping(ctx context.Context, tenantID, teamID string, startsAt, endsAt time.Time, excludeID string) ([]*Appointment, error)
+	// ListForFeed retorna as visitas não canceladas da equipe que terminam depois de since
+	ListForFeed(ctx context.Context, tenantID, teamID string, since time.Time) ([]*Appointment, error)
+}
@@ -0,0 +1,218 @@
+package cutting
+
+import (
+	"math"
+	"sort"
+)
+
+// Piece é uma peça retangular a ser cortada, em centímetros.
+type Piece struct {
+	ItemID   string  `json:"item_id"`
+	Copy     int     `json:"copy"` // 1..Quantity do item
+	WidthCM  float64 `json:"width_cm"`
+	HeightCM float64 `json:"height_cm"`
+}
+
+// PlacedPiece é uma peça posicionada na chapa. X e Y são medidos a partir do
+// canto superior esquerdo; WidthCM e HeightCM já consideram a rotação.
+type PlacedPiece struct {
+	Piece
+	X       float64 `json:"x_cm"`
+	Y       float64 `json:"y_cm"`
+	Rotated bool    `json:"rotated"`
+}
+
+// Sheet é uma chapa usada no plano de corte.
+type Sheet struct {
+	Index        int            `json:"index"`
+	Pieces       []*PlacedPiece `json:"pieces"`
+	UsedAreaM2   float64        `json:"used_area_m2"`
+	WastePercent float64        `json:"waste_percent"`
+
+	free []rect
+}
+
+// PackOptions controla o empacotamento.
+type PackOptions struct {
+	AllowRotation bool
+	// KerfCM é a espessura do disco de corte, descontada entre peças vizinhas
+	KerfCM float64
+}
+
+// PackResult é o resultado do empacotamento de peças de um mesmo material.
+type PackResult struct {
+	Sheets []*Sheet `json:"sheets"`
+	// Unplaced são as peças que não cabem na chapa nem rotacionadas
+	Unplaced []Piece `json:"unplaced,omitempty"`
+}
+
+type rect struct {
+	x, y, w, h float64
+}
+
+func (r rect) contains(o rect) bool {
+	return o.x >= r.x && o.y >= r.y && o.x+o.w <= r.x+r.w && o.y+o.h <= r.y+r.h
+}
+
+func (r rect) intersects(o rect) bool {
+	return o.x < r.x+r.w && o.x+o.w > r.x && o.y < r.y+r.h && o.y+o.h > r.y
+}
+
+// Pack distribui as peças no menor número de chapas width×height que conseguir,
+// usando a heurística MaxRects (best short side fit) com as peças ordenadas da
+// maior para a menor.
+//
+// A serra é tratada somando o kerf à largura e à altura de cada peça e da
+// chapa: assim sobra um kerf entre peças vizinhas, mas não na borda da chapa.
+func Pack(pieces []Piece, width, height float64, opts PackOptions) *PackResult {
+	kerf := math.Max(opts.KerfCM, 0)
+	binW, binH := width+kerf, height+kerf
+
+	sorted := make([]Piece, len(pieces))
+	copy(sorted, pieces)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if sa, sb := math.Max(a.WidthCM, a.HeightCM), math.Max(b.WidthCM, b.HeightCM); sa != sb {
+			return sa > sb
+		}
+		return a.WidthCM*a.HeightCM > b.WidthCM*b.HeightCM
+	})
+
+	result := &PackResult{Sheets: []*Sheet{}}
+
+	for _, piece := range sorted {
+		w, h := piece.WidthCM+kerf, piece.HeightCM+kerf
+
+		var bestSheet *Sheet
+		var best placement
+		for _, sheet := range result.Sheets {
+			if p, ok := findPlacement(sheet.free, w, h, opts.AllowRotation); ok && (bestSheet == nil || p.better(best)) {
+				bestSheet, best = sheet, p
+			}
+		}
+
+		if bestSheet == nil {
+			sheet := &Sheet{Index: len(result.Sheets) + 1, free: []rect{{0, 0, binW, binH}}}
+			p, ok := findPlacement(sheet.free, w, h, opts.AllowRotation)
+			if !ok {
+				result.Unplaced = append(result.Unplaced, piece)
+				continue
+			}
+			result.Sheets = append(result.Sheets, sheet)
+			bestSheet, best = sheet, p
+		}
+
+		placed := &PlacedPiece{Piece: piece, X: best.x, Y: best.y, Rotated: best.rotated}
+		if best.rotated {
+			placed.WidthCM, placed.HeightCM = piece.HeightCM, piece.WidthCM
+		}
+		bestSheet.Pieces = append(bestSheet.Pieces, placed)
+		bestSheet.free = splitFree(bestSheet.free, rect{best.x, best.y, best.w, best.h})
+	}
+
+	sheetArea := width * height / 10000
+	for _, sheet := range result.Sheets {
+		used := 0.0
+		for _, p := range sheet.Pieces {
+			used += p.WidthCM * p.HeightCM / 10000
+		}
+		sheet.UsedAreaM2 = round(used, 4)
+		sheet.WastePercent = wastePercent(used, sheetArea)
+	}
+
+	return result
+}
+
+type placement struct {
+	x, y, w, h        float64
+	rotated           bool
+	shortFit, longFit float64
+}
+
+func (p placement) better(o placement) bool {
+	if p.shortFit != o.shortFit {
+		return p.shortFit < o.shortFit
+	}
+	return p.longFit < o.longFit
+}
+
+// findPlacement escolhe o espaço livre em que a peça deixa a menor sobra no lado mais curto.
+func findPlacement(free []rect, w, h float64, allowRotation bool) (placement, bool) {
+	var best placement
+	found := false
+
+	try := func(r rect, pw, ph float64, rotated bool) {
+		if pw > r.w || ph > r.h {
+			return
+		}
+		leftW, leftH := r.w-pw, r.h-ph
+		p := placement{
+			x: r.x, y: r.y, w: pw, h: ph, rotated: rotated,
+			shortFit: math.Min(leftW, leftH),
+			longFit:  math.Max(leftW, leftH),
+		}
+		if !found || p.better(best) {
+			best, found = p, true
+		}
+	}
+
+	for _, r := range free {
+		try(r, w, h, false)
+		if allowRotation && w != h {
+			try(r, h, w, true)
+		}
+	}
+
+	return best, found
+}
+
+// splitFree recorta dos espaços livres a área ocupada e descarta os espaços
+// contidos em outros.
+func splitFree(free []rect, used rect) []rect {
+	var next []rect
+	for _, r := range free {
+		if !r.intersects(used) {
+			next = append(next, r)
+			continue
+		}
+		if used.x > r.x {
+			next = append(next, rect{r.x, r.y, used.x - r.x, r.h})
+		}
+		if used.x+used.w < r.x+r.w {
+			next = append(next, rect{used.x + used.w, r.y, r.x + r.w - used.x - used.w, r.h})
+		}
+		if used.y > r.y {
+			next = append(next, rect{r.x, r.y, r.w, used.y - r.y})
+		}
+		if used.y+used.h < r.y+r.h {
+			next = append(next, rect{r.x, used.y + used.h, r.w, r.y + r.h - used.y - used.h})
+		}
+	}
+
+	pruned := make([]rect, 0, len(next))
+	for i, r := range next {
+		redundant := false
+		for j, o := range next {
+			if i != j && o.contains(r) && (r != o || j < i) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			pruned = append(pruned, r)
+		}
+	}
+	return pruned
+}
+
+func wastePercent(used, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return round((1-used/total)*100, 2)
+}
+
+func round(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
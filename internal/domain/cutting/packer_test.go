@@ -0,0 +1,100 @@
+package cutting
+
+import (
+	"testing"
+)
+
+func TestPack_FillsSlabExactly(t *testing.T) {
+	// Quatro peças de 150×90 preenchem exatamente uma chapa de 300×180
+	pieces := []Piece{
+		{ItemID: "a", Copy: 1, WidthCM: 150, HeightCM: 90},
+		{ItemID: "a", Copy: 2, WidthCM: 150, HeightCM: 90},
+		{ItemID: "a", Copy: 3, WidthCM: 150, HeightCM: 90},
+		{ItemID: "a", Copy: 4, WidthCM: 150, HeightCM: 90},
+	}
+
+	result := Pack(pieces, 300, 180, PackOptions{})
+
+	if len(result.Sheets) != 1 {
+		t.Fatalf("sheets = %d, want 1", len(result.Sheets))
+	}
+	if got := result.Sheets[0].WastePercent; got != 0 {
+		t.Errorf("WastePercent = %v, want 0", got)
+	}
+	assertNoOverlap(t, result, 300, 180, 0)
+}
+
+func TestPack_KerfForcesSecondSlab(t *testing.T) {
+	pieces := []Piece{
+		{ItemID: "a", Copy: 1, WidthCM: 150, HeightCM: 180},
+		{ItemID: "a", Copy: 2, WidthCM: 150, HeightCM: 180},
+	}
+
+	if got := len(Pack(pieces, 300, 180, PackOptions{}).Sheets); got != 1 {
+		t.Errorf("without kerf: sheets = %d, want 1", got)
+	}
+
+	result := Pack(pieces, 300, 180, PackOptions{KerfCM: 0.4})
+	if got := len(result.Sheets); got != 2 {
+		t.Errorf("with kerf: sheets = %d, want 2", got)
+	}
+	assertNoOverlap(t, result, 300, 180, 0.4)
+}
+
+func TestPack_Rotation(t *testing.T) {
+	// Peça em pé que só cabe deitada na chapa
+	pieces := []Piece{{ItemID: "a", Copy: 1, WidthCM: 60, HeightCM: 250}}
+
+	result := Pack(pieces, 300, 180, PackOptions{})
+	if len(result.Unplaced) != 1 || len(result.Sheets) != 0 {
+		t.Fatalf("without rotation the piece should not fit: %+v", result)
+	}
+
+	result = Pack(pieces, 300, 180, PackOptions{AllowRotation: true})
+	if len(result.Sheets) != 1 {
+		t.Fatalf("sheets = %d, want 1", len(result.Sheets))
+	}
+	placed := result.Sheets[0].Pieces[0]
+	if !placed.Rotated || placed.WidthCM != 250 || placed.HeightCM != 60 {
+		t.Errorf("expected rotated 250×60 piece, got %+v", placed)
+	}
+}
+
+func TestPack_ManyPieces(t *testing.T) {
+	var pieces []Piece
+	sizes := [][2]float64{{120, 60}, {80, 45}, {200, 62}, {55, 40}, {90, 90}, {150, 35}}
+	for i := 0; i < 20; i++ {
+		s := sizes[i%len(sizes)]
+		pieces = append(pieces, Piece{ItemID: "x", Copy: i + 1, WidthCM: s[0], HeightCM: s[1]})
+	}
+
+	result := Pack(pieces, 320, 190, PackOptions{AllowRotation: true, KerfCM: 0.5})
+
+	placed := 0
+	for _, sheet := range result.Sheets {
+		placed += len(sheet.Pieces)
+	}
+	if placed != len(pieces) || len(result.Unplaced) != 0 {
+		t.Fatalf("placed %d of %d pieces, unplaced %d", placed, len(pieces), len(result.Unplaced))
+	}
+	assertNoOverlap(t, result, 320, 190, 0.5)
+}
+
+func assertNoOverlap(t *testing.T, result *PackResult, width, height, kerf float64) {
+	t.Helper()
+
+	for _, sheet := range result.Sheets {
+		for i, a := range sheet.Pieces {
+			if a.X < 0 || a.Y < 0 || a.X+a.WidthCM > width+1e-9 || a.Y+a.HeightCM > height+1e-9 {
+				t.Errorf("sheet %d: piece %+v outside the slab", sheet.Index, a)
+			}
+			for _, b := range sheet.Pieces[i+1:] {
+				ra := rect{a.X, a.Y, a.WidthCM + kerf, a.HeightCM + kerf}
+				rb := rect{b.X, b.Y, b.WidthCM + kerf, b.HeightCM + kerf}
+				if ra.intersects(rb) {
+					t.Errorf("sheet %d: pieces %+v and %+v overlap", sheet.Index, a, b)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cutting
+
+import "errors"
+
+var (
+	ErrMissingSlabSize = errors.New("slab size is required for every product in the quote")
+	ErrInvalidSlabSize = errors.New("slab width and height must be greater than zero")
+	ErrInvalidKerf     = errors.New("kerf must not be negative")
+	ErrTooManyPieces   = errors.New("quote has too many pieces for a cutting plan")
+)
+
+// MaxPieces limita as peças de um plano de corte; o encaixe cresce bem mais
+// rápido que o número de peças.
+const MaxPieces = 1000
+
+// SlabSize é a dimensão da chapa usada para um produto (pedra).
+type SlabSize struct {
+	ProductID string  `json:"product_id" binding:"required"`
+	WidthCM   float64 `json:"width_cm" binding:"required"`
+	HeightCM  float64 `json:"height_cm" binding:"required"`
+}
+
+// PlanRequest são os parâmetros do plano de corte de um orçamento.
+type PlanRequest struct {
+	Slabs         []SlabSize `json:"slabs" binding:"required"`
+	AllowRotation bool       `json:"allow_rotation"`
+	// KerfMM é a espessura do disco de corte em milímetros
+	KerfMM float64 `json:"kerf_mm"`
+}
+
+func (req *PlanRequest) Validate() error {
+	if req.KerfMM < 0 {
+		return ErrInvalidKerf
+	}
+	for _, slab := range req.Slabs {
+		if slab.WidthCM <= 0 || slab.HeightCM <= 0 {
+			return ErrInvalidSlabSize
+		}
+	}
+	return nil
+}
+
+// SlabFor retorna a dimensão de chapa informada para o produto.
+func (req *PlanRequest) SlabFor(productID string) (SlabSize, bool) {
+	for _, slab := range req.Slabs {
+		if slab.ProductID == productID {
+			return slab, true
+		}
+	}
+	return SlabSize{}, false
+}
+
+// ProductPlan é o plano de corte das peças de um produto.
+type ProductPlan struct {
+	ProductID    string  `json:"product_id"`
+	ProductName  string  `json:"product_name,omitempty"`
+	SlabWidthCM  float64 `json:"slab_width_cm"`
+	SlabHeightCM float64 `json:"slab_height_cm"`
+	SlabCount    int     `json:"slab_count"`
+	PieceAreaM2  float64 `json:"piece_area_m2"`
+	SlabAreaM2   float64 `json:"slab_area_m2"`
+	WastePercent float64 `json:"waste_percent"`
+	*PackResult
+}
+
+// Plan é o plano de corte completo do orçamento.
+type Plan struct {
+	QuoteID      string         `json:"quote_id"`
+	SlabCount    int            `json:"slab_count"`
+	WastePercent float64        `json:"waste_percent"`
+	Products     []*ProductPlan `json:"products"`
+	// SkippedItems são itens sem medidas (ex.: peças vendidas por unidade)
+	SkippedItems []string `json:"skipped_items,omitempty"`
+}
+
+// NewProductPlan empacota as peças de um produto e calcula o aproveitamento.
+func NewProductPlan(productID string, slab SlabSize, pieces []Piece, opts PackOptions) *ProductPlan {
+	result := Pack(pieces, slab.WidthCM, slab.HeightCM, opts)
+
+	plan := &ProductPlan{
+		ProductID:    productID,
+		SlabWidthCM:  slab.WidthCM,
+		SlabHeightCM: slab.HeightCM,
+		SlabCount:    len(result.Sheets),
+		PackResult:   result,
+	}
+
+	used := 0.0
+	for _, sheet := range result.Sheets {
+		used += sheet.UsedAreaM2
+	}
+	plan.PieceAreaM2 = round(used, 4)
+	plan.SlabAreaM2 = round(float64(plan.SlabCount)*slab.WidthCM*slab.HeightCM/10000, 4)
+	plan.WastePercent = wastePercent(plan.PieceAreaM2, plan.SlabAreaM2)
+
+	return plan
+}
+
+// Summarize consolida o total de chapas e o desperdício geral do plano.
+func (p *Plan) Summarize() {
+	used, total := 0.0, 0.0
+	p.SlabCount = 0
+	for _, product := range p.Products {
+		p.SlabCount += product.SlabCount
+		used += product.PieceAreaM2
+		total += product.SlabAreaM2
+	}
+	p.WastePercent = wastePercent(used, total)
+}
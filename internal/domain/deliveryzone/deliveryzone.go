@@ -0,0 +1,83 @@
+package deliveryzone
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrZoneNotFound      = errors.New("delivery zone not found")
+	ErrZoneAlreadyExists = errors.New("a delivery zone with this name already exists")
+	ErrNoZoneForAddress  = errors.New("no delivery zone covers the client's address")
+	ErrInvalidName       = errors.New("zone name is required")
+	ErrInvalidCoverage   = errors.New("zone needs either a CEP range (8 digits, start <= end) or a city")
+	ErrInvalidState      = errors.New("state must be a two-letter code")
+	ErrInvalidRateBasis  = errors.New("rate basis must be m2 or kg")
+	ErrInvalidFee        = errors.New("fees and rates must not be negative")
+)
+
+// IsValid indica se a base da tarifa é suportada; vazio equivale a m².
+func (b RateBasis) IsValid() bool {
+	switch b {
+	case "", RateBasisArea, RateBasisWeight:
+		return true
+	}
+	return false
+}
+
+func (req *CreateZoneDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func (req *UpdateZoneDTO) Validate() error {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// Normalize deixa CEPs só com dígitos, cidade sem espaços extras e UF em maiúsculas.
+func (z *DeliveryZone) Normalize() {
+	z.Name = strings.TrimSpace(z.Name)
+	z.ZipStart = NormalizeZip(z.ZipStart)
+	z.ZipEnd = NormalizeZip(z.ZipEnd)
+	z.City = strings.TrimSpace(z.City)
+	z.State = strings.ToUpper(strings.TrimSpace(z.State))
+	if z.RateBasis == "" {
+		z.RateBasis = RateBasisArea
+	}
+}
+
+// Validate confere a zona já normalizada: abrangência por faixa de CEP ou por
+// cidade (não ambas) e valores não negativos.
+func (z *DeliveryZone) Validate() error {
+	if z.Name == "" {
+		return ErrInvalidName
+	}
+
+	byZip := z.ZipStart != "" || z.ZipEnd != ""
+	switch {
+	case byZip && z.City != "":
+		return ErrInvalidCoverage
+	case byZip:
+		if len(z.ZipStart) != 8 || len(z.ZipEnd) != 8 || z.ZipStart > z.ZipEnd {
+			return ErrInvalidCoverage
+		}
+	case z.City == "":
+		return ErrInvalidCoverage
+	}
+
+	if z.State != "" && len(z.State) != 2 {
+		return ErrInvalidState
+	}
+	if !z.RateBasis.IsValid() {
+		return ErrInvalidRateBasis
+	}
+	if z.BaseFee < 0 || z.Rate < 0 || z.MinimumFee < 0 {
+		return ErrInvalidFee
+	}
+	return nil
+}
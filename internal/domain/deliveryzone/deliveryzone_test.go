@@ -0,0 +1,104 @@
+package deliveryzone
+
+import (
+	"testing"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+func TestDeliveryZone_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		zone DeliveryZone
+		want error
+	}{
+		{"cep range", DeliveryZone{Name: "Centro", ZipStart: "80010-000", ZipEnd: "80060-999", Rate: 20}, nil},
+		{"city", DeliveryZone{Name: "São José", City: "São José dos Pinhais", State: "pr"}, nil},
+		{"blank name", DeliveryZone{Name: " ", City: "Curitiba"}, ErrInvalidName},
+		{"no coverage", DeliveryZone{Name: "Nada"}, ErrInvalidCoverage},
+		{"cep and city", DeliveryZone{Name: "Ambos", ZipStart: "80010000", ZipEnd: "80060999", City: "Curitiba"}, ErrInvalidCoverage},
+		{"inverted range", DeliveryZone{Name: "Inv", ZipStart: "80060999", ZipEnd: "80010000"}, ErrInvalidCoverage},
+		{"short cep", DeliveryZone{Name: "Curto", ZipStart: "8001", ZipEnd: "80060999"}, ErrInvalidCoverage},
+		{"bad state", DeliveryZone{Name: "UF", City: "Curitiba", State: "Paraná"}, ErrInvalidState},
+		{"bad basis", DeliveryZone{Name: "Base", City: "Curitiba", RateBasis: "km"}, ErrInvalidRateBasis},
+		{"negative fee", DeliveryZone{Name: "Neg", City: "Curitiba", BaseFee: -1}, ErrInvalidFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := tt.zone
+			zone.Normalize()
+			if got := zone.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	city := &DeliveryZone{Name: "Curitiba", City: "Curitiba", IsActive: true}
+	cityState := &DeliveryZone{Name: "Curitiba PR", City: "curitiba", State: "PR", IsActive: true}
+	wide := &DeliveryZone{Name: "Grande Curitiba", ZipStart: "80000000", ZipEnd: "83999999", IsActive: true}
+	narrow := &DeliveryZone{Name: "Centro", ZipStart: "80010000", ZipEnd: "80060999", IsActive: true}
+	inactive := &DeliveryZone{Name: "Centro antigo", ZipStart: "80010000", ZipEnd: "80010999", IsActive: false}
+	zones := []*DeliveryZone{city, cityState, wide, narrow, inactive}
+
+	tests := []struct {
+		name             string
+		zip, city, state string
+		want             *DeliveryZone
+	}{
+		{"narrowest cep range wins", "80010-100", "Curitiba", "PR", narrow},
+		{"wider range", "81200-000", "Curitiba", "PR", wide},
+		{"city with state", "", "Curitiba", "pr", cityState},
+		{"city without accents or case", "", " CURITIBA ", "SC", city},
+		{"no zone", "01001-000", "São Paulo", "SP", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(zones, tt.zip, tt.city, tt.state); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	accented := &DeliveryZone{Name: "SJP", City: "São José dos Pinhais", IsActive: true}
+	if got := Match([]*DeliveryZone{accented}, "", "Sao Jose dos  Pinhais", "PR"); got != accented {
+		t.Errorf("Match() should ignore accents and extra spaces, got %v", got)
+	}
+}
+
+func TestLoadOfAndFee(t *testing.T) {
+	items := []*quoteDomain.QuoteItem{
+		{AreaM2: 1.2, Thickness: 3, Quantity: 2},
+		{AreaM2: 0.5, Quantity: 1}, // sem espessura: usa a padrão de 2 cm
+		{Kind: quoteDomain.ItemKindService, Description: "Instalação", Quantity: 1},
+	}
+
+	load := LoadOf(items, FreightConfigFromSettings(map[string]string{}))
+	// 2,4 m² × 3 cm × 2,7 × 10 = 194,4 kg; 0,5 m² × 2 cm × 2,7 × 10 = 27 kg
+	if load.AreaM2 != 2.9 || load.WeightKG != 221.4 {
+		t.Fatalf("LoadOf() = %+v, want 2.9 m² and 221.4 kg", load)
+	}
+
+	byArea := &DeliveryZone{BaseFee: 50, RateBasis: RateBasisArea, Rate: 30}
+	if got := byArea.Fee(load); got != 137 {
+		t.Errorf("area fee = %v, want 137", got)
+	}
+
+	byWeight := &DeliveryZone{BaseFee: 40, RateBasis: RateBasisWeight, Rate: 0.5}
+	if got := byWeight.Fee(load); got != 150.7 {
+		t.Errorf("weight fee = %v, want 150.7", got)
+	}
+
+	minimum := &DeliveryZone{BaseFee: 20, Rate: 10, MinimumFee: 120}
+	if got := minimum.Fee(load); got != 120 {
+		t.Errorf("fee below minimum = %v, want 120", got)
+	}
+
+	cfg := FreightConfigFromSettings(map[string]string{SettingStoneDensity: "2.5", SettingDefaultThicknessCM: "x"})
+	if cfg.StoneDensity != 2.5 || cfg.DefaultThicknessCM != DefaultStoneThicknessCM {
+		t.Errorf("FreightConfigFromSettings() = %+v", cfg)
+	}
+}
@@ -0,0 +1,33 @@
+package deliveryzone
+
+type CreateZoneDTO struct {
+	Name       string    `json:"name" binding:"required"`
+	ZipStart   string    `json:"zip_start,omitempty"`
+	ZipEnd     string    `json:"zip_end,omitempty"`
+	City       string    `json:"city,omitempty"`
+	State      string    `json:"state,omitempty"`
+	BaseFee    float64   `json:"base_fee"`
+	RateBasis  RateBasis `json:"rate_basis,omitempty"`
+	Rate       float64   `json:"rate"`
+	MinimumFee float64   `json:"minimum_fee"`
+}
+
+type UpdateZoneDTO struct {
+	Name       *string    `json:"name,omitempty"`
+	ZipStart   *string    `json:"zip_start,omitempty"`
+	ZipEnd     *string    `json:"zip_end,omitempty"`
+	City       *string    `json:"city,omitempty"`
+	State      *string    `json:"state,omitempty"`
+	BaseFee    *float64   `json:"base_fee,omitempty"`
+	RateBasis  *RateBasis `json:"rate_basis,omitempty"`
+	Rate       *float64   `json:"rate,omitempty"`
+	MinimumFee *float64   `json:"minimum_fee,omitempty"`
+	IsActive   *bool      `json:"is_active,omitempty"`
+}
+
+type ZoneListDTO struct {
+	Zones  []*DeliveryZone `json:"zones"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
@@ -0,0 +1,48 @@
+package deliveryzone
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+// RateBasis indica sobre o que incide a tarifa variável da zona.
+type RateBasis string
+
+const (
+	RateBasisArea   RateBasis = "m2" // R$ por m² de peças
+	RateBasisWeight RateBasis = "kg" // R$ por kg estimado das peças
+)
+
+// DeliveryZone é uma região de entrega do tenant, definida por faixa de CEP ou
+// por cidade, com a tarifa usada no cálculo do frete.
+type DeliveryZone struct {
+	ID       dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null;uniqueIndex:idx_delivery_zones_tenant_name"`
+	Name     string       `json:"name" gorm:"not null;size:120;uniqueIndex:idx_delivery_zones_tenant_name"`
+
+	// Abrangência: faixa de CEP (8 dígitos) ou cidade/UF
+	ZipStart string `json:"zip_start,omitempty" gorm:"size:8"`
+	ZipEnd   string `json:"zip_end,omitempty" gorm:"size:8"`
+	City     string `json:"city,omitempty" gorm:"size:120"`
+	State    string `json:"state,omitempty" gorm:"size:2"`
+
+	// Tarifa
+	BaseFee    float64   `json:"base_fee"`                               // valor fixo por entrega
+	RateBasis  RateBasis `json:"rate_basis" gorm:"size:10;default:'m2'"` // m2 ou kg
+	Rate       float64   `json:"rate"`                                   // R$ por m² ou por kg
+	MinimumFee float64   `json:"minimum_fee"`                            // frete mínimo
+	IsActive   bool      `json:"is_active" gorm:"default:true"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (z *DeliveryZone) BeforeCreate(tx *gorm.DB) error {
+	if z.ID == "" {
+		z.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package deliveryzone
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+// Chaves em settings usadas na estimativa de peso das peças.
+const (
+	SettingStoneDensity       = "freight_stone_density"        // t/m³ (ou g/cm³)
+	SettingDefaultThicknessCM = "freight_default_thickness_cm" // para peças sem espessura
+)
+
+const (
+	DefaultStoneDensity     = 2.7 // densidade média de granitos e mármores
+	DefaultStoneThicknessCM = 2.0
+)
+
+// FreightConfig reúne os parâmetros do tenant para estimar o peso da carga.
+type FreightConfig struct {
+	StoneDensity       float64
+	DefaultThicknessCM float64
+}
+
+// FreightConfigFromSettings monta a configuração a partir das settings do tenant.
+// Valores ausentes ou inválidos usam os padrões.
+func FreightConfigFromSettings(settings map[string]string) FreightConfig {
+	cfg := FreightConfig{StoneDensity: DefaultStoneDensity, DefaultThicknessCM: DefaultStoneThicknessCM}
+	if v, err := strconv.ParseFloat(strings.TrimSpace(settings[SettingStoneDensity]), 64); err == nil && v > 0 {
+		cfg.StoneDensity = v
+	}
+	if v, err := strconv.ParseFloat(strings.TrimSpace(settings[SettingDefaultThicknessCM]), 64); err == nil && v > 0 {
+		cfg.DefaultThicknessCM = v
+	}
+	return cfg
+}
+
+// Load é a carga a entregar: área total e peso estimado das peças.
+type Load struct {
+	AreaM2   float64 `json:"area_m2"`
+	WeightKG float64 `json:"weight_kg"`
+}
+
+// LoadOf soma área e peso das peças (itens de produto) multiplicados pela quantidade.
+// O peso é área × espessura × densidade; 1 m² de 2 cm a 2,7 t/m³ pesa 54 kg.
+func LoadOf(items []*quoteDomain.QuoteItem, cfg FreightConfig) Load {
+	var load Load
+	for _, item := range quoteDomain.ProductItems(items) {
+		area := item.AreaM2 * float64(item.Quantity)
+		thickness := item.Thickness
+		if thickness <= 0 {
+			thickness = cfg.DefaultThicknessCM
+		}
+		load.AreaM2 += area
+		load.WeightKG += area * thickness * cfg.StoneDensity * 10
+	}
+	load.AreaM2 = roundTo(load.AreaM2, 4)
+	load.WeightKG = roundTo(load.WeightKG, 2)
+	return load
+}
+
+// Fee calcula o frete da zona para a carga: valor fixo mais a tarifa por m² ou
+// por kg, respeitando o frete mínimo.
+func (z *DeliveryZone) Fee(load Load) float64 {
+	quantity := load.AreaM2
+	if z.RateBasis == RateBasisWeight {
+		quantity = load.WeightKG
+	}
+	fee := z.BaseFee + z.Rate*quantity
+	return roundTo(math.Max(fee, z.MinimumFee), 2)
+}
+
+// Covers indica se a zona atende o endereço. Zonas por cidade conferem a UF
+// apenas quando ela foi cadastrada.
+func (z *DeliveryZone) Covers(zipCode, city, state string) bool {
+	if z.ZipStart != "" {
+		zip := NormalizeZip(zipCode)
+		return len(zip) == 8 && zip >= z.ZipStart && zip <= z.ZipEnd
+	}
+	if foldName(z.City) != foldName(city) {
+		return false
+	}
+	return z.State == "" || strings.EqualFold(z.State, strings.TrimSpace(state))
+}
+
+// Match escolhe a zona que atende o endereço. Faixas de CEP têm precedência
+// sobre cidades e, entre elas, vence a faixa mais estreita.
+func Match(zones []*DeliveryZone, zipCode, city, state string) *DeliveryZone {
+	var best *DeliveryZone
+	for _, zone := range zones {
+		if !zone.IsActive || !zone.Covers(zipCode, city, state) {
+			continue
+		}
+		if best == nil || moreSpecific(zone, best) {
+			best = zone
+		}
+	}
+	return best
+}
+
+func moreSpecific(a, b *DeliveryZone) bool {
+	if (a.ZipStart != "") != (b.ZipStart != "") {
+		return a.ZipStart != ""
+	}
+	if a.ZipStart == "" {
+		// Cidade com UF é mais específica que cidade sem UF
+		return a.State != "" && b.State == ""
+	}
+	return zipSpan(a) < zipSpan(b)
+}
+
+func zipSpan(z *DeliveryZone) int {
+	start, _ := strconv.Atoi(z.ZipStart)
+	end, _ := strconv.Atoi(z.ZipEnd)
+	return end - start
+}
+
+// NormalizeZip mantém apenas os dígitos do CEP ("80010-000" -> "80010000").
+func NormalizeZip(zip string) string {
+	var b strings.Builder
+	for _, r := range zip {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "ë", "e",
+	"í", "i", "î", "i", "ì", "i", "ï", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ò", "o", "ö", "o",
+	"ú", "u", "û", "u", "ù", "u", "ü", "u",
+	"ç", "c",
+)
+
+// foldName compara nomes de cidade sem diferenciar maiúsculas, acentos e espaços extras.
+func foldName(name string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.Join(strings.Fields(name), " ")))
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
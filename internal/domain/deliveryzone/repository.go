@@ -0,0 +1,14 @@
+package deliveryzone
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, zone *DeliveryZone) error
+	GetByID(ctx context.Context, tenantID, id string) (*DeliveryZone, error)
+	GetByName(ctx context.Context, tenantID, name string) (*DeliveryZone, error)
+	Update(ctx context.Context, zone *DeliveryZone) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*DeliveryZone, error)
+	Count(ctx context.Context, tenantID string) (int, error)
+	ListActive(ctx context.Context, tenantID string) ([]*DeliveryZone, error)
+}
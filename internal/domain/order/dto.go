@@ -0,0 +1,29 @@
+package order
+
+type CreateOrderDTO struct {
+	QuoteID string `json:"quote_id" binding:"required"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+type UpdateOrderStatusDTO struct {
+	Status OrderStatus `json:"status" binding:"required"`
+}
+
+// ListFilter restringe a listagem de pedidos; campos vazios não filtram
+type ListFilter struct {
+	Status   OrderStatus
+	ClientID string
+}
+
+// OrderDetailDTO é o pedido completo, com os itens
+type OrderDetailDTO struct {
+	*Order
+	Items []*OrderItem `json:"items"`
+}
+
+type OrderListDTO struct {
+	Orders []*Order `json:"orders"`
+	Total  int      `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+}
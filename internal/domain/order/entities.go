@@ -0,0 +1,105 @@
+package order
+
+import (
+	"time"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusConfirmed    OrderStatus = "confirmed"
+	OrderStatusInProduction OrderStatus = "in_production"
+	OrderStatusReady        OrderStatus = "ready"
+	OrderStatusInstalled    OrderStatus = "installed"
+	OrderStatusClosed       OrderStatus = "closed"
+)
+
+// Order é o pedido de venda gerado a partir de um orçamento aprovado.
+// Valores e itens são copiados do orçamento e não mudam mais.
+type Order struct {
+	ID       dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null;uniqueIndex:idx_orders_tenant_quote"`
+	QuoteID  dbtypes.UUID `json:"quote_id" gorm:"not null;uniqueIndex:idx_orders_tenant_quote"`
+	ClientID dbtypes.UUID `json:"client_id" gorm:"not null;index"`
+	UserID   dbtypes.UUID `json:"user_id" gorm:"not null;index"`
+
+	Subtotal   float64 `json:"subtotal"`
+	Discount   float64 `json:"discount"`
+	TotalValue float64 `json:"total_value"`
+
+	Status OrderStatus `json:"status" gorm:"not null;index"`
+	Notes  string      `json:"notes,omitempty"`
+
+	// Momento em que o pedido entrou em cada etapa
+	ConfirmedAt         time.Time  `json:"confirmed_at"`
+	ProductionStartedAt *time.Time `json:"production_started_at,omitempty"`
+	ReadyAt             *time.Time `json:"ready_at,omitempty"`
+	InstalledAt         *time.Time `json:"installed_at,omitempty"`
+	ClosedAt            *time.Time `json:"closed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (o *Order) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// OrderItem é a cópia congelada de um item do orçamento.
+type OrderItem struct {
+	ID          dbtypes.UUID  `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID  `json:"tenant_id" gorm:"not null"`
+	OrderID     dbtypes.UUID  `json:"order_id" gorm:"not null;index"`
+	QuoteItemID dbtypes.UUID  `json:"quote_item_id" gorm:"not null"`
+	ProductID   *dbtypes.UUID `json:"product_id,omitempty"` // vazio em linhas de serviço
+	ProductName string        `json:"product_name"`         // nome do produto (ou descrição do serviço) no momento do pedido
+	SlabID      *dbtypes.UUID `json:"slab_id,omitempty"`    // chapa reservada para a peça
+
+	Kind      quoteDomain.ItemKind `json:"kind" gorm:"size:10;default:'product'"`
+	ServiceID *dbtypes.UUID        `json:"service_id,omitempty"`
+
+	// Medidas
+	WidthCM   float64 `json:"width_cm,omitempty"`
+	HeightCM  float64 `json:"height_cm,omitempty"`
+	Thickness float64 `json:"thickness,omitempty"`
+	AreaM2    float64 `json:"area_m2,omitempty"`
+
+	// Preço
+	PriceType       productDomain.PriceType  `json:"price_type"`
+	UnitPrice       float64                  `json:"unit_price" gorm:"not null"`
+	Quantity        int                      `json:"quantity"`
+	EdgeSurcharge   float64                  `json:"edge_surcharge"`
+	CutoutSurcharge float64                  `json:"cutout_surcharge"`
+	DiscountType    quoteDomain.DiscountType `json:"discount_type,omitempty" gorm:"size:10"`
+	DiscountAmount  float64                  `json:"discount_amount"` // em R$, já abatido do total
+	Total           float64                  `json:"total"`
+
+	// Custo congelado do orçamento, para a margem do pedido
+	UnitCost float64 `json:"unit_cost"`
+	Cost     float64 `json:"cost"`
+
+	// Extras
+	EdgeType       string `json:"edge_type,omitempty"`
+	EdgeSides      string `json:"edge_sides,omitempty" gorm:"size:40"`
+	HasCutout      bool   `json:"has_cutout"`
+	ReferenceImage string `json:"reference_image,omitempty"`
+	Notes          string `json:"notes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (oi *OrderItem) BeforeCreate(tx *gorm.DB) error {
+	if oi.ID == "" {
+		oi.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
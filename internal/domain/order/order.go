@@ -0,0 +1,25 @@
+package order
+
+import "errors"
+
+var (
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderAlreadyExists      = errors.New("an order already exists for this quote")
+	ErrQuoteNotApproved        = errors.New("only approved quotes can be converted into orders")
+	ErrInvalidOrderStatus      = errors.New("invalid order status")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+)
+
+func (req *CreateOrderDTO) Validate() error {
+	if req.QuoteID == "" {
+		return errors.New("quote_id is required")
+	}
+	return nil
+}
+
+func (req *UpdateOrderStatusDTO) Validate() error {
+	if !req.Status.IsValid() {
+		return ErrInvalidOrderStatus
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package order
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, order *Order) error
+	GetByID(ctx context.Context, tenantID, id string) (*Order, error)
+	// GetForUpdate lê o pedido travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Order, error)
+	GetByQuoteID(ctx context.Context, tenantID, quoteID string) (*Order, error)
+	Update(ctx context.Context, order *Order) error
+	List(ctx context.Context, tenantID string, filter ListFilter, limit, offset int) ([]*Order, error)
+	Count(ctx context.Context, tenantID string, filter ListFilter) (int, error)
+}
+
+type ItemRepository interface {
+	Create(ctx context.Context, item *OrderItem) error
+	GetByOrderID(ctx context.Context, orderID string) ([]*OrderItem, error)
+}
@@ -0,0 +1,50 @@
+package order
+
+import "time"
+
+// allowedTransitions define o fluxo do pedido no chão de fábrica.
+//
+// confirmed -> in_production -> ready -> installed -> closed
+var allowedTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusConfirmed:    {OrderStatusInProduction},
+	OrderStatusInProduction: {OrderStatusReady},
+	OrderStatusReady:        {OrderStatusInstalled},
+	OrderStatusInstalled:    {OrderStatusClosed},
+	OrderStatusClosed:       {},
+}
+
+// IsValid indica se o status é conhecido.
+func (s OrderStatus) IsValid() bool {
+	_, ok := allowedTransitions[s]
+	return ok
+}
+
+// CanTransitionTo indica se o pedido pode sair do status atual para o próximo.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Advance muda o status do pedido e registra o momento da mudança.
+func (o *Order) Advance(next OrderStatus, at time.Time) error {
+	if !o.Status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	o.Status = next
+	switch next {
+	case OrderStatusInProduction:
+		o.ProductionStartedAt = &at
+	case OrderStatusReady:
+		o.ReadyAt = &at
+	case OrderStatusInstalled:
+		o.InstalledAt = &at
+	case OrderStatusClosed:
+		o.ClosedAt = &at
+	}
+	return nil
+}
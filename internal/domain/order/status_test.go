@@ -0,0 +1,56 @@
+package order
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrder_Advance(t *testing.T) {
+	at := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	order := &Order{Status: OrderStatusConfirmed}
+
+	steps := []OrderStatus{OrderStatusInProduction, OrderStatusReady, OrderStatusInstalled, OrderStatusClosed}
+	for _, next := range steps {
+		if err := order.Advance(next, at); err != nil {
+			t.Fatalf("Advance(%s) error = %v", next, err)
+		}
+	}
+
+	if order.Status != OrderStatusClosed {
+		t.Errorf("Status = %v, want %v", order.Status, OrderStatusClosed)
+	}
+	for name, stamp := range map[string]*time.Time{
+		"ProductionStartedAt": order.ProductionStartedAt,
+		"ReadyAt":             order.ReadyAt,
+		"InstalledAt":         order.InstalledAt,
+		"ClosedAt":            order.ClosedAt,
+	} {
+		if stamp == nil || !stamp.Equal(at) {
+			t.Errorf("%s = %v, want %v", name, stamp, at)
+		}
+	}
+}
+
+func TestOrder_AdvanceRejectsSkippingStages(t *testing.T) {
+	tests := []struct {
+		from OrderStatus
+		to   OrderStatus
+	}{
+		{OrderStatusConfirmed, OrderStatusReady},
+		{OrderStatusInProduction, OrderStatusConfirmed},
+		{OrderStatusReady, OrderStatusClosed},
+		{OrderStatusClosed, OrderStatusConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			order := &Order{Status: tt.from}
+			if err := order.Advance(tt.to, time.Now()); err != ErrInvalidStatusTransition {
+				t.Errorf("Advance() error = %v, want %v", err, ErrInvalidStatusTransition)
+			}
+			if order.Status != tt.from {
+				t.Errorf("Status changed to %v on invalid transition", order.Status)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ type CreateProductDTO struct {
 	Name        string  `json:"name" binding:"required"`
 	Description string  `json:"description,omitempty"`
 	Price       float64 `json:"price" binding:"required"`
+	CostPrice   float64 `json:"cost_price,omitempty"`
+	PriceType   PriceType `json:"price_type,omitempty"`
 	Stock       int     `json:"stock,omitempty"`
 	SKU         string  `json:"sku,omitempty"`
 	Category    string  `json:"category,omitempty"`
@@ -15,6 +17,8 @@ type UpdateProductDTO struct {
 	Name        string  `json:"name,omitempty"`
 	Description string  `json:"description,omitempty"`
 	Price       *float64 `json:"price,omitempty"`
+	CostPrice   *float64 `json:"cost_price,omitempty"`
+	PriceType   PriceType `json:"price_type,omitempty"`
 	Stock       *int    `json:"stock,omitempty"`
 	SKU         string  `json:"sku,omitempty"`
 	Category    string  `json:"category,omitempty"`
@@ -28,7 +32,11 @@ type ProductDTO struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	CostPrice   float64 `json:"cost_price"`
+	PriceType   PriceType `json:"price_type"`
 	Stock       int     `json:"stock"`
+	ReservedStock int `json:"reserved_stock"`  // reservado por orçamentos aprovados
+	AvailableStock int `json:"available_stock"` // estoque menos reservas
 	SKU         string  `json:"sku"`
 	Category    string  `json:"category"`
 	ImageURL    string  `json:"image_url"`
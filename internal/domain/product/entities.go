@@ -8,14 +8,23 @@ import (
 	"gorm.io/gorm"
 )
 
+type PriceType string
+
+const (
+	PriceTypeUnit        PriceType = "unit"         // preço por peça
+	PriceTypeSquareMeter PriceType = "m2"           // preço por m²
+	PriceTypeLinearMeter PriceType = "linear_meter" // preço por metro linear
+)
+
 type Product struct {
 	ID          dbtypes.UUID   `json:"id" gorm:"primaryKey"`
 	TenantID    dbtypes.UUID   `json:"tenant_id" gorm:"not null"`
 	Name        string         `json:"name" gorm:"not null"`
 	Description string         `json:"description,omitempty"`
 	Price       float64        `json:"price" gorm:"not null"`
-	PriceType   string         `json:"price_type" gorm:"default:'unit'"`
-	Stock       int            `json:"stock" gorm:"default:0"`
+	CostPrice   float64        `json:"cost_price" gorm:"default:0"` // custo na mesma unidade do preço (PriceType)
+	PriceType   PriceType      `json:"price_type" gorm:"default:'unit'"`
+	Stock       int            `json:"stock" gorm:"default:0"` // na unidade do PriceType: peças, m² ou metros lineares
 	SKU         string         `json:"sku,omitempty"`
 	Category    string         `json:"category,omitempty"`
 	ImageURL    string         `json:"image_url,omitempty"`
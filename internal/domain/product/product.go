@@ -1,13 +1,28 @@
 package product
 
 import "errors"
-	
+
 var (
-	ErrProductNotFound     = errors.New("product not found")
+	ErrProductNotFound      = errors.New("product not found")
 	ErrProductAlreadyExists = errors.New("product already exists")
-	ErrInvalidProductType  = errors.New("invalid product type")
+	ErrInvalidProductType   = errors.New("invalid product type")
+	ErrInvalidPriceType     = errors.New("invalid price type")
+	ErrInvalidCostPrice     = errors.New("cost price must not be negative")
+	ErrInvalidStock         = errors.New("stock must not be negative")
+	// ErrPriceTypeInUse impede trocar a unidade de estoque (peça, m², metro linear)
+	// de um produto que já tem lançamentos ou reservas gravados na unidade antiga
+	ErrPriceTypeInUse = errors.New("price type cannot change on a product with stock movements or reservations")
 )
 
+// IsValid indica se o tipo de preço é suportado pelo motor de precificação.
+func (t PriceType) IsValid() bool {
+	switch t {
+	case PriceTypeUnit, PriceTypeSquareMeter, PriceTypeLinearMeter:
+		return true
+	}
+	return false
+}
+
 func (req *CreateProductDTO) Validate() error {
 	if req.Name == "" {
 		return errors.New("name is required")
@@ -15,5 +30,14 @@ func (req *CreateProductDTO) Validate() error {
 	if req.Price <= 0 {
 		return errors.New("price must be greater than zero")
 	}
+	if req.CostPrice < 0 {
+		return ErrInvalidCostPrice
+	}
+	if req.Stock < 0 {
+		return ErrInvalidStock
+	}
+	if req.PriceType != "" && !req.PriceType.IsValid() {
+		return ErrInvalidPriceType
+	}
 	return nil
-} 
\ No newline at end of file
+}
@@ -5,8 +5,14 @@ import "context"
 type Repository interface {
 	Create(ctx context.Context, product *Product) error
 	GetByID(ctx context.Context, tenantID, id string) (*Product, error)
+	// GetForUpdate lê o produto travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Product, error)
 	Update(ctx context.Context, product *Product) error
 	Delete(ctx context.Context, tenantID, id string) error
 	List(ctx context.Context, tenantID string, limit, offset int) ([]*Product, error)
 	Count(ctx context.Context, tenantID string) (int, error)
+	// AddStock e SetStock mexem só no saldo; quem chama registra o lançamento
+	// correspondente no livro de estoque
+	AddStock(ctx context.Context, tenantID, id string, delta int) error
+	SetStock(ctx context.Context, tenantID, id string, stock int) error
 } 
\ No newline at end of file
@@ -0,0 +1,45 @@
+package production
+
+// BuildBoard agrupa as ordens abertas pela etapa atual. As colunas seguem o
+// roteiro configurado; etapas que só existem em ordens antigas (o roteiro mudou
+// depois da criação) entram no fim, na ordem em que aparecem.
+func BuildBoard(configured []string, workOrders []*WorkOrder, stages []*WorkOrderStage) *Board {
+	byOrder := make(map[string][]*WorkOrderStage)
+	for _, stage := range stages {
+		byOrder[stage.WorkOrderID.String()] = append(byOrder[stage.WorkOrderID.String()], stage)
+	}
+
+	board := &Board{Columns: make([]*BoardColumn, 0, len(configured))}
+	columns := make(map[string]*BoardColumn)
+	column := func(stage string) *BoardColumn {
+		if col, ok := columns[stage]; ok {
+			return col
+		}
+		col := &BoardColumn{Stage: stage, WorkOrders: []*BoardCard{}}
+		columns[stage] = col
+		board.Columns = append(board.Columns, col)
+		return col
+	}
+	for _, stage := range configured {
+		column(stage)
+	}
+
+	for _, workOrder := range workOrders {
+		if workOrder.Status == WorkOrderStatusCompleted {
+			continue
+		}
+		col := column(workOrder.CurrentStage)
+		col.WorkOrders = append(col.WorkOrders, &BoardCard{
+			WorkOrder: workOrder,
+			Current:   CurrentStage(workOrder, byOrder[workOrder.ID.String()]),
+		})
+		if workOrder.Status == WorkOrderStatusInProgress {
+			col.InProgress++
+		} else {
+			col.Pending++
+		}
+		board.Total++
+	}
+
+	return board
+}
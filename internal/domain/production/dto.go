@@ -0,0 +1,56 @@
+package production
+
+type CreateWorkOrdersDTO struct {
+	QuoteID string `json:"quote_id" binding:"required"`
+}
+
+// StageActionDTO inicia ou conclui a etapa atual da ordem
+type StageActionDTO struct {
+	// AssigneeID é o responsável pela etapa; vazio mantém o atual (ou quem inicia)
+	AssigneeID string `json:"assignee_id,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+}
+
+type AssignStageDTO struct {
+	AssigneeID string `json:"assignee_id" binding:"required"`
+}
+
+// ListFilter restringe a listagem de ordens; campos vazios não filtram
+type ListFilter struct {
+	QuoteID string
+	Stage   string
+	Status  WorkOrderStatus
+	// Open lista só as ordens ainda não concluídas
+	Open bool
+}
+
+// WorkOrderDetailDTO é a ordem com o roteiro completo
+type WorkOrderDetailDTO struct {
+	*WorkOrder
+	Stages []*WorkOrderStage `json:"stages"`
+}
+
+type WorkOrderListDTO struct {
+	WorkOrders []*WorkOrder `json:"work_orders"`
+	Total      int          `json:"total"`
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+}
+
+// BoardCard é uma ordem no quadro, com a etapa em que está
+type BoardCard struct {
+	*WorkOrder
+	Current *WorkOrderStage `json:"current"`
+}
+
+type BoardColumn struct {
+	Stage      string       `json:"stage"`
+	Pending    int          `json:"pending"`
+	InProgress int          `json:"in_progress"`
+	WorkOrders []*BoardCard `json:"work_orders"`
+}
+
+type Board struct {
+	Columns []*BoardColumn `json:"columns"`
+	Total   int            `json:"total"`
+}
@@ -0,0 +1,80 @@
+package production
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+type WorkOrderStatus string
+
+const (
+	WorkOrderStatusPending    WorkOrderStatus = "pending"     // etapa atual ainda não começou
+	WorkOrderStatusInProgress WorkOrderStatus = "in_progress" // etapa atual em execução
+	WorkOrderStatusCompleted  WorkOrderStatus = "completed"   // todas as etapas concluídas
+)
+
+// WorkOrder é a ordem de produção de um item de orçamento aprovado. Os dados da
+// peça são copiados do item para o chão de fábrica não depender do orçamento.
+type WorkOrder struct {
+	ID          dbtypes.UUID  `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID  `json:"tenant_id" gorm:"not null;index;uniqueIndex:idx_work_orders_tenant_item"`
+	QuoteID     dbtypes.UUID  `json:"quote_id" gorm:"not null;index"`
+	QuoteItemID dbtypes.UUID  `json:"quote_item_id" gorm:"not null;uniqueIndex:idx_work_orders_tenant_item"`
+	ProductID   *dbtypes.UUID `json:"product_id,omitempty"`
+	ProductName string        `json:"product_name"`
+
+	// Peça
+	WidthCM   float64 `json:"width_cm,omitempty"`
+	HeightCM  float64 `json:"height_cm,omitempty"`
+	Thickness float64 `json:"thickness,omitempty"`
+	Quantity  int     `json:"quantity"`
+	EdgeType  string  `json:"edge_type,omitempty"`
+	HasCutout bool    `json:"has_cutout"`
+	Notes     string  `json:"notes,omitempty"`
+
+	// Etapa em que a ordem está; as etapas ficam em WorkOrderStage
+	CurrentStage string          `json:"current_stage" gorm:"size:40;index"`
+	Status       WorkOrderStatus `json:"status" gorm:"size:20;not null;index"`
+
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (w *WorkOrder) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// WorkOrderStage é uma etapa do roteiro da ordem, com responsável e horários.
+// O roteiro é copiado das settings na criação e não muda se elas mudarem depois.
+type WorkOrderStage struct {
+	ID          dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID `json:"tenant_id" gorm:"not null"`
+	WorkOrderID dbtypes.UUID `json:"work_order_id" gorm:"not null;index"`
+	Stage       string       `json:"stage" gorm:"size:40;not null"`
+	Position    int          `json:"position"`
+
+	AssigneeID  *dbtypes.UUID `json:"assignee_id,omitempty" gorm:"index"`
+	StartedAt   *time.Time    `json:"started_at,omitempty"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	CompletedBy *dbtypes.UUID `json:"completed_by,omitempty"`
+	Notes       string        `json:"notes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (s *WorkOrderStage) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
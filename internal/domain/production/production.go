@@ -0,0 +1,70 @@
+package production
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrWorkOrderNotFound      = errors.New("work order not found")
+	ErrWorkOrdersAlreadyExist = errors.New("work orders already exist for this quote")
+	ErrQuoteNotApproved       = errors.New("only approved quotes can go into production")
+	ErrNothingToProduce       = errors.New("quote has no product items to produce")
+	ErrWorkOrderCompleted     = errors.New("work order is already completed")
+	ErrStageAlreadyStarted    = errors.New("current stage has already started")
+	ErrInvalidAssignee        = errors.New("assignee must be a user of the tenant")
+	ErrInvalidWorkOrderStatus = errors.New("invalid work order status")
+)
+
+// SettingStages é a chave em settings com as etapas de produção, em ordem e
+// separadas por vírgula (ex.: "corte,polimento,acabamento_borda,qualidade").
+const SettingStages = "production_stages"
+
+// DefaultStages é o roteiro usado quando o tenant não configurou etapas.
+var DefaultStages = []string{"cutting", "polishing", "edge_finishing", "qa", "ready_for_installation"}
+
+func (req *CreateWorkOrdersDTO) Validate() error {
+	if req.QuoteID == "" {
+		return errors.New("quote_id is required")
+	}
+	return nil
+}
+
+// IsValid indica se o status é conhecido.
+func (s WorkOrderStatus) IsValid() bool {
+	switch s {
+	case WorkOrderStatusPending, WorkOrderStatusInProgress, WorkOrderStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// StagesFromSettings lê o roteiro de produção das settings do tenant. As etapas
+// são normalizadas (minúsculas, espaços viram "_") e repetições são ignoradas;
+// sem nenhuma etapa válida vale DefaultStages.
+func StagesFromSettings(settings map[string]string) []string {
+	var stages []string
+	seen := make(map[string]bool)
+	for _, raw := range strings.Split(settings[SettingStages], ",") {
+		stage := NormalizeStage(raw)
+		if stage == "" || seen[stage] {
+			continue
+		}
+		seen[stage] = true
+		stages = append(stages, stage)
+	}
+	if len(stages) == 0 {
+		return append([]string(nil), DefaultStages...)
+	}
+	return stages
+}
+
+// NormalizeStage padroniza o nome de uma etapa.
+func NormalizeStage(stage string) string {
+	stage = strings.ToLower(strings.TrimSpace(stage))
+	stage = strings.Join(strings.Fields(stage), "_")
+	if len(stage) > 40 {
+		stage = stage[:40]
+	}
+	return stage
+}
@@ -0,0 +1,129 @@
+package production
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+func TestStagesFromSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     []string
+	}{
+		{"missing uses default", map[string]string{}, DefaultStages},
+		{"blank uses default", map[string]string{SettingStages: " , "}, DefaultStages},
+		{
+			"normalized and deduplicated",
+			map[string]string{SettingStages: "Corte, polimento ,Acabamento  de borda,corte"},
+			[]string{"corte", "polimento", "acabamento_de_borda"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StagesFromSettings(tt.settings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StagesFromSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	workOrder := &WorkOrder{ID: "wo1", TenantID: "t1"}
+	stages := PlanStages(workOrder, []string{"cutting", "polishing"})
+	for _, stage := range stages {
+		stage.WorkOrderID = workOrder.ID
+	}
+
+	if workOrder.CurrentStage != "cutting" || workOrder.Status != WorkOrderStatusPending {
+		t.Fatalf("new work order = %s/%s, want cutting/pending", workOrder.CurrentStage, workOrder.Status)
+	}
+
+	cutter := dbtypes.UUID("u1")
+	start := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	stage, err := Start(workOrder, stages, &cutter, start)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if stage.Stage != "cutting" || *stage.AssigneeID != cutter || workOrder.Status != WorkOrderStatusInProgress {
+		t.Errorf("after Start: stage %s assignee %v status %s", stage.Stage, stage.AssigneeID, workOrder.Status)
+	}
+	if _, err := Start(workOrder, stages, nil, start); err != ErrStageAlreadyStarted {
+		t.Errorf("second Start() error = %v, want %v", err, ErrStageAlreadyStarted)
+	}
+
+	// Conclui o corte; o polimento fica pendente
+	if _, err := Complete(workOrder, stages, &cutter, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if workOrder.CurrentStage != "polishing" || workOrder.Status != WorkOrderStatusPending {
+		t.Errorf("after first Complete = %s/%s, want polishing/pending", workOrder.CurrentStage, workOrder.Status)
+	}
+
+	// Concluir sem iniciar registra o início no mesmo horário e finaliza a ordem
+	end := start.Add(5 * time.Hour)
+	stage, err = Complete(workOrder, stages, nil, end)
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if !stage.StartedAt.Equal(end) || workOrder.Status != WorkOrderStatusCompleted || workOrder.CompletedAt == nil {
+		t.Errorf("after last Complete: stage started %v, status %s", stage.StartedAt, workOrder.Status)
+	}
+	if !workOrder.StartedAt.Equal(start) {
+		t.Errorf("StartedAt = %v, want %v", workOrder.StartedAt, start)
+	}
+
+	if _, err := Complete(workOrder, stages, nil, end); err != ErrWorkOrderCompleted {
+		t.Errorf("Complete() on completed order error = %v, want %v", err, ErrWorkOrderCompleted)
+	}
+	if _, err := Assign(workOrder, stages, cutter); err != ErrWorkOrderCompleted {
+		t.Errorf("Assign() on completed order error = %v, want %v", err, ErrWorkOrderCompleted)
+	}
+}
+
+func TestBuildBoard(t *testing.T) {
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	workOrders := []*WorkOrder{
+		{ID: "a", CurrentStage: "cutting", Status: WorkOrderStatusInProgress},
+		{ID: "b", CurrentStage: "cutting", Status: WorkOrderStatusPending},
+		{ID: "c", CurrentStage: "qa", Status: WorkOrderStatusPending},
+		{ID: "d", CurrentStage: "legacy", Status: WorkOrderStatusPending},
+		{ID: "e", CurrentStage: "qa", Status: WorkOrderStatusCompleted},
+	}
+	stages := []*WorkOrderStage{
+		{WorkOrderID: "a", Stage: "cutting", Position: 1, StartedAt: &now},
+		{WorkOrderID: "c", Stage: "cutting", Position: 1, StartedAt: &now, CompletedAt: &now},
+		{WorkOrderID: "c", Stage: "qa", Position: 2},
+	}
+
+	board := BuildBoard([]string{"cutting", "polishing", "qa"}, workOrders, stages)
+
+	var keys []string
+	for _, col := range board.Columns {
+		keys = append(keys, col.Stage)
+	}
+	if want := []string{"cutting", "polishing", "qa", "legacy"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("columns = %v, want %v", keys, want)
+	}
+	if board.Total != 4 {
+		t.Errorf("Total = %d, want 4", board.Total)
+	}
+
+	cutting := board.Columns[0]
+	if len(cutting.WorkOrders) != 2 || cutting.InProgress != 1 || cutting.Pending != 1 {
+		t.Errorf("cutting column = %+v", cutting)
+	}
+	if cutting.WorkOrders[0].Current == nil || cutting.WorkOrders[0].Current.StartedAt == nil {
+		t.Errorf("card should carry the current stage, got %+v", cutting.WorkOrders[0].Current)
+	}
+	if len(board.Columns[1].WorkOrders) != 0 {
+		t.Errorf("polishing should be empty, got %d", len(board.Columns[1].WorkOrders))
+	}
+	if qa := board.Columns[2].WorkOrders; len(qa) != 1 || qa[0].Current.Stage != "qa" {
+		t.Errorf("qa column = %+v", qa)
+	}
+}
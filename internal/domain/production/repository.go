@@ -0,0 +1,22 @@
+package production
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, workOrder *WorkOrder) error
+	GetByID(ctx context.Context, tenantID, id string) (*WorkOrder, error)
+	// GetForUpdate lê a ordem travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*WorkOrder, error)
+	Update(ctx context.Context, workOrder *WorkOrder) error
+	List(ctx context.Context, tenantID string, filter ListFilter, limit, offset int) ([]*WorkOrder, error)
+	Count(ctx context.Context, tenantID string, filter ListFilter) (int, error)
+	// ListOpen retorna todas as ordens não concluídas, para o quadro
+	ListOpen(ctx context.Context, tenantID string) ([]*WorkOrder, error)
+}
+
+type StageRepository interface {
+	Create(ctx context.Context, stage *WorkOrderStage) error
+	Update(ctx context.Context, stage *WorkOrderStage) error
+	ListByWorkOrderID(ctx context.Context, workOrderID string) ([]*WorkOrderStage, error)
+	ListByWorkOrderIDs(ctx context.Context, workOrderIDs []string) ([]*WorkOrderStage, error)
+}
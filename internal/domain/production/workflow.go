@@ -0,0 +1,121 @@
+package production
+
+import (
+	"sort"
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+// PlanStages monta o roteiro da ordem e a deixa pendente na primeira etapa.
+func PlanStages(workOrder *WorkOrder, stages []string) []*WorkOrderStage {
+	rows := make([]*WorkOrderStage, 0, len(stages))
+	for i, stage := range stages {
+		rows = append(rows, &WorkOrderStage{
+			TenantID: workOrder.TenantID,
+			Stage:    stage,
+			Position: i + 1,
+		})
+	}
+
+	workOrder.Status = WorkOrderStatusPending
+	if len(stages) > 0 {
+		workOrder.CurrentStage = stages[0]
+	}
+	return rows
+}
+
+// CurrentStage retorna a etapa em que a ordem está.
+func CurrentStage(workOrder *WorkOrder, stages []*WorkOrderStage) *WorkOrderStage {
+	for _, stage := range stages {
+		if stage.Stage == workOrder.CurrentStage && stage.CompletedAt == nil {
+			return stage
+		}
+	}
+	return nil
+}
+
+// Start inicia a etapa atual. Sem responsável informado, mantém o já atribuído.
+func Start(workOrder *WorkOrder, stages []*WorkOrderStage, assigneeID *dbtypes.UUID, at time.Time) (*WorkOrderStage, error) {
+	current, err := openStage(workOrder, stages)
+	if err != nil {
+		return nil, err
+	}
+	if current.StartedAt != nil {
+		return nil, ErrStageAlreadyStarted
+	}
+
+	current.StartedAt = &at
+	if assigneeID != nil {
+		current.AssigneeID = assigneeID
+	}
+	workOrder.Status = WorkOrderStatusInProgress
+	if workOrder.StartedAt == nil {
+		workOrder.StartedAt = &at
+	}
+	return current, nil
+}
+
+// Complete conclui a etapa atual e leva a ordem para a próxima, que fica pendente.
+// Uma etapa concluída sem ter sido iniciada recebe o mesmo horário de início.
+// Concluída a última etapa, a ordem é finalizada.
+func Complete(workOrder *WorkOrder, stages []*WorkOrderStage, userID *dbtypes.UUID, at time.Time) (*WorkOrderStage, error) {
+	current, err := openStage(workOrder, stages)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.StartedAt == nil {
+		current.StartedAt = &at
+	}
+	if workOrder.StartedAt == nil {
+		workOrder.StartedAt = &at
+	}
+	current.CompletedAt = &at
+	current.CompletedBy = userID
+
+	if next := nextStage(current, stages); next != nil {
+		workOrder.CurrentStage = next.Stage
+		workOrder.Status = WorkOrderStatusPending
+	} else {
+		workOrder.Status = WorkOrderStatusCompleted
+		workOrder.CompletedAt = &at
+	}
+	return current, nil
+}
+
+// Assign troca o responsável pela etapa atual.
+func Assign(workOrder *WorkOrder, stages []*WorkOrderStage, assigneeID dbtypes.UUID) (*WorkOrderStage, error) {
+	current, err := openStage(workOrder, stages)
+	if err != nil {
+		return nil, err
+	}
+	current.AssigneeID = &assigneeID
+	return current, nil
+}
+
+func openStage(workOrder *WorkOrder, stages []*WorkOrderStage) (*WorkOrderStage, error) {
+	if workOrder.Status == WorkOrderStatusCompleted {
+		return nil, ErrWorkOrderCompleted
+	}
+	current := CurrentStage(workOrder, stages)
+	if current == nil {
+		return nil, ErrWorkOrderCompleted
+	}
+	return current, nil
+}
+
+func nextStage(current *WorkOrderStage, stages []*WorkOrderStage) *WorkOrderStage {
+	var next *WorkOrderStage
+	for _, stage := range stages {
+		if stage.Position > current.Position && stage.CompletedAt == nil && (next == nil || stage.Position < next.Position) {
+			next = stage
+		}
+	}
+	return next
+}
+
+// SortStages ordena o roteiro pela posição.
+func SortStages(stages []*WorkOrderStage) {
+	sort.Slice(stages, func(i, j int) bool { return stages[i].Position < stages[j].Position })
+}
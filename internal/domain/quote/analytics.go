@@ -0,0 +1,121 @@
+package quote
+
+import "sort"
+
+// ConversionStats são os indicadores de conversão de um conjunto de orçamentos.
+//
+// Um orçamento conta como convertido quando foi aprovado (ApprovedAt
+// preenchido), mesmo que depois tenha sido cancelado.
+type ConversionStats struct {
+	Quotes   int `json:"quotes"`
+	Approved int `json:"approved"`
+	// ApprovalRate é o percentual de orçamentos aprovados (0 a 100)
+	ApprovalRate float64 `json:"approval_rate"`
+	// AverageTicket é o valor médio dos orçamentos aprovados
+	AverageTicket float64 `json:"average_ticket"`
+	// AvgHoursToApproval é o tempo médio entre a criação e a aprovação
+	AvgHoursToApproval float64 `json:"avg_hours_to_approval"`
+}
+
+// ConversionGroup são os indicadores de um vendedor, mês ou cliente
+type ConversionGroup struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+	ConversionStats
+}
+
+type ConversionReport struct {
+	Overall  ConversionStats    `json:"overall"`
+	ByUser   []*ConversionGroup `json:"by_user"`
+	ByMonth  []*ConversionGroup `json:"by_month"`
+	ByClient []*ConversionGroup `json:"by_client"`
+}
+
+// conversionAccumulator soma os valores brutos antes de calcular as médias
+type conversionAccumulator struct {
+	quotes, approved int
+	approvedTotal    float64
+	hoursToApproval  float64
+}
+
+func (a *conversionAccumulator) add(q *Quote) {
+	a.quotes++
+	if q.ApprovedAt == nil {
+		return
+	}
+	a.approved++
+	a.approvedTotal += q.TotalValue
+	a.hoursToApproval += q.ApprovedAt.Sub(q.CreatedAt).Hours()
+}
+
+func (a *conversionAccumulator) stats() ConversionStats {
+	stats := ConversionStats{Quotes: a.quotes, Approved: a.approved}
+	if a.quotes > 0 {
+		stats.ApprovalRate = roundTo(float64(a.approved)/float64(a.quotes)*100, 2)
+	}
+	if a.approved > 0 {
+		stats.AverageTicket = roundTo(a.approvedTotal/float64(a.approved), 2)
+		stats.AvgHoursToApproval = roundTo(a.hoursToApproval/float64(a.approved), 2)
+	}
+	return stats
+}
+
+// BuildConversionReport calcula os indicadores gerais e agrupados por vendedor,
+// mês de criação (YYYY-MM) e cliente.
+func BuildConversionReport(quotes []*Quote) *ConversionReport {
+	var overall conversionAccumulator
+	byUser := make(map[string]*conversionAccumulator)
+	byMonth := make(map[string]*conversionAccumulator)
+	byClient := make(map[string]*conversionAccumulator)
+
+	for _, q := range quotes {
+		overall.add(q)
+		accumulate(byUser, q.UserID.String(), q)
+		accumulate(byMonth, q.CreatedAt.Format("2006-01"), q)
+		accumulate(byClient, q.ClientID.String(), q)
+	}
+
+	report := &ConversionReport{
+		Overall:  overall.stats(),
+		ByUser:   groups(byUser),
+		ByMonth:  groups(byMonth),
+		ByClient: groups(byClient),
+	}
+
+	// Meses em ordem cronológica; vendedores e clientes pelo volume aprovado
+	sort.Slice(report.ByMonth, func(i, j int) bool { return report.ByMonth[i].Key < report.ByMonth[j].Key })
+	sortByApproved(report.ByUser)
+	sortByApproved(report.ByClient)
+
+	return report
+}
+
+func accumulate(groups map[string]*conversionAccumulator, key string, q *Quote) {
+	acc, ok := groups[key]
+	if !ok {
+		acc = &conversionAccumulator{}
+		groups[key] = acc
+	}
+	acc.add(q)
+}
+
+func groups(accs map[string]*conversionAccumulator) []*ConversionGroup {
+	result := make([]*ConversionGroup, 0, len(accs))
+	for key, acc := range accs {
+		result = append(result, &ConversionGroup{Key: key, ConversionStats: acc.stats()})
+	}
+	return result
+}
+
+func sortByApproved(groups []*ConversionGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Approved != b.Approved {
+			return a.Approved > b.Approved
+		}
+		if a.Quotes != b.Quotes {
+			return a.Quotes > b.Quotes
+		}
+		return a.Key < b.Key
+	})
+}
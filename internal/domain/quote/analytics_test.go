@@ -0,0 +1,60 @@
+package quote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildConversionReport(t *testing.T) {
+	jan := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)
+	approvedJan := jan.Add(48 * time.Hour)
+	approvedFeb := feb.Add(24 * time.Hour)
+
+	quotes := []*Quote{
+		{UserID: "ana", ClientID: "c1", TotalValue: 1000, CreatedAt: jan, ApprovedAt: &approvedJan, Status: QuoteStatusApproved},
+		{UserID: "ana", ClientID: "c2", TotalValue: 500, CreatedAt: jan, Status: QuoteStatusRejected},
+		{UserID: "bruno", ClientID: "c1", TotalValue: 3000, CreatedAt: feb, ApprovedAt: &approvedFeb, Status: QuoteStatusApproved},
+		{UserID: "bruno", ClientID: "c2", TotalValue: 200, CreatedAt: feb, Status: QuoteStatusPending},
+	}
+
+	report := BuildConversionReport(quotes)
+
+	want := ConversionStats{Quotes: 4, Approved: 2, ApprovalRate: 50, AverageTicket: 2000, AvgHoursToApproval: 36}
+	if report.Overall != want {
+		t.Errorf("Overall = %+v, want %+v", report.Overall, want)
+	}
+
+	if len(report.ByMonth) != 2 || report.ByMonth[0].Key != "2026-01" || report.ByMonth[1].Key != "2026-02" {
+		t.Fatalf("ByMonth keys = %+v", report.ByMonth)
+	}
+
+	if len(report.ByClient) != 2 || report.ByClient[0].Key != "c1" {
+		t.Fatalf("ByClient should start with the client with most approvals, got %+v", report.ByClient)
+	}
+	if got := report.ByClient[0].ApprovalRate; got != 100 {
+		t.Errorf("c1 ApprovalRate = %v, want 100", got)
+	}
+
+	byUser := make(map[string]*ConversionGroup)
+	for _, group := range report.ByUser {
+		byUser[group.Key] = group
+	}
+	if got := byUser["bruno"].AverageTicket; got != 3000 {
+		t.Errorf("bruno AverageTicket = %v, want 3000", got)
+	}
+	if got := byUser["ana"].AvgHoursToApproval; got != 48 {
+		t.Errorf("ana AvgHoursToApproval = %v, want 48", got)
+	}
+}
+
+func TestBuildConversionReport_Empty(t *testing.T) {
+	report := BuildConversionReport(nil)
+
+	if report.Overall != (ConversionStats{}) {
+		t.Errorf("Overall = %+v, want zero", report.Overall)
+	}
+	if report.ByUser == nil || report.ByMonth == nil || report.ByClient == nil {
+		t.Error("groups should be empty slices, not nil")
+	}
+}
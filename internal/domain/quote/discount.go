@@ -0,0 +1,102 @@
+package quote
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DiscountType indica como o valor do desconto é interpretado.
+type DiscountType string
+
+const (
+	DiscountTypeAmount  DiscountType = "amount"  // valor absoluto em R$
+	DiscountTypePercent DiscountType = "percent" // percentual sobre o valor bruto
+)
+
+var (
+	ErrInvalidDiscountType        = errors.New("discount_type must be amount or percent")
+	ErrDiscountApprovalNotPending = errors.New("quote is not awaiting discount approval")
+)
+
+// SettingDiscountApprovalPercent é a chave em settings com o desconto máximo, em %,
+// que pode ser concedido sem aprovação de um gerente.
+const SettingDiscountApprovalPercent = "quote_discount_approval_percent"
+
+// IsValid indica se o tipo de desconto é conhecido. Vazio equivale a amount.
+func (t DiscountType) IsValid() bool {
+	return t == "" || t == DiscountTypeAmount || t == DiscountTypePercent
+}
+
+// DiscountAmount converte o desconto informado em R$ sobre o valor bruto base.
+// Percentuais vão de 0 a 100 e valores absolutos não podem exceder a base.
+func DiscountAmount(discountType DiscountType, value, base float64) (float64, error) {
+	if !discountType.IsValid() {
+		return 0, ErrInvalidDiscountType
+	}
+	if value < 0 {
+		return 0, ErrInvalidDiscount
+	}
+
+	if discountType == DiscountTypePercent {
+		if value > 100 {
+			return 0, ErrInvalidDiscount
+		}
+		return roundTo(base*value/100, 2), nil
+	}
+
+	if value > base {
+		return 0, ErrInvalidDiscount
+	}
+	return roundTo(value, 2), nil
+}
+
+// discountPercent calcula o percentual que discount representa sobre gross.
+func discountPercent(discount, gross float64) float64 {
+	if gross <= 0 {
+		return 0
+	}
+	return roundTo(discount/gross*100, 2)
+}
+
+// DiscountPolicy é a política de descontos do tenant.
+type DiscountPolicy struct {
+	// ApprovalPercent é o desconto máximo sem aprovação; zero desativa a política.
+	ApprovalPercent float64
+}
+
+// DiscountPolicyFromSettings lê a política de descontos das settings do tenant.
+// Valores ausentes ou inválidos desativam a política.
+func DiscountPolicyFromSettings(settings map[string]string) DiscountPolicy {
+	percent, err := strconv.ParseFloat(strings.TrimSpace(settings[SettingDiscountApprovalPercent]), 64)
+	if err != nil || percent <= 0 {
+		return DiscountPolicy{}
+	}
+	return DiscountPolicy{ApprovalPercent: percent}
+}
+
+// RequiresApproval indica se o desconto efetivo do orçamento passa do limite e
+// ainda não foi aprovado por um gerente (ou foi aumentado depois da aprovação).
+func (p DiscountPolicy) RequiresApproval(q *Quote) bool {
+	if p.ApprovalPercent <= 0 {
+		return false
+	}
+	return q.DiscountPercent > p.ApprovalPercent && q.DiscountPercent > q.DiscountApprovedPercent
+}
+
+// StatusFor retorna o status de um orçamento em edição após aplicar a política:
+// pendente com desconto acima do limite aguarda aprovação, e volta a ficar
+// pendente quando o desconto é reduzido ou aprovado.
+func (p DiscountPolicy) StatusFor(q *Quote) QuoteStatus {
+	switch q.Status {
+	case QuoteStatusPending:
+		if p.RequiresApproval(q) {
+			return QuoteStatusAwaitingApproval
+		}
+	case QuoteStatusAwaitingApproval:
+		if !p.RequiresApproval(q) {
+			return QuoteStatusPending
+		}
+	}
+	return q.Status
+}
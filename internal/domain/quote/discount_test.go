@@ -0,0 +1,125 @@
+package quote
+
+import (
+	"testing"
+
+	productDomain "erp-api/internal/domain/product"
+)
+
+func TestDiscountAmount(t *testing.T) {
+	tests := []struct {
+		name         string
+		discountType DiscountType
+		value, base  float64
+		want         float64
+		wantErr      error
+	}{
+		{"legacy empty type is amount", "", 100, 1000, 100, nil},
+		{"amount", DiscountTypeAmount, 250.5, 1000, 250.5, nil},
+		{"amount above base", DiscountTypeAmount, 1200, 1000, 0, ErrInvalidDiscount},
+		{"percent", DiscountTypePercent, 12.5, 1999.9, 249.99, nil},
+		{"percent above 100", DiscountTypePercent, 101, 1000, 0, ErrInvalidDiscount},
+		{"negative", DiscountTypePercent, -1, 1000, 0, ErrInvalidDiscount},
+		{"unknown type", DiscountType("cupom"), 10, 1000, 0, ErrInvalidDiscountType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiscountAmount(tt.discountType, tt.value, tt.base)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DiscountAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceItem_Discount(t *testing.T) {
+	item := &QuoteItem{UnitPrice: 250, Quantity: 4, Discount: 10, DiscountType: DiscountTypePercent}
+	if err := PriceItem(item, productDomain.PriceTypeUnit, PricingConfig{}); err != nil {
+		t.Fatalf("PriceItem() error = %v", err)
+	}
+	if item.DiscountAmount != 100 || item.Total != 900 {
+		t.Errorf("discount/total = %v/%v, want 100/900", item.DiscountAmount, item.Total)
+	}
+
+	item.Discount, item.DiscountType = 1500, DiscountTypeAmount
+	if err := PriceItem(item, productDomain.PriceTypeUnit, PricingConfig{}); err != ErrInvalidDiscount {
+		t.Errorf("expected ErrInvalidDiscount, got %v", err)
+	}
+}
+
+func TestCalculateTotals_EffectiveDiscount(t *testing.T) {
+	// Itens com 1000 brutos, sendo 100 de desconto no item
+	items := []*QuoteItem{{Total: 600}, {Total: 300, DiscountAmount: 100}}
+	q := &Quote{Discount: 10, DiscountType: DiscountTypePercent}
+
+	if err := CalculateTotals(q, items); err != nil {
+		t.Fatalf("CalculateTotals() error = %v", err)
+	}
+	if q.Subtotal != 900 || q.DiscountAmount != 90 || q.TotalValue != 810 {
+		t.Errorf("totals = %v/%v/%v, want 900/90/810", q.Subtotal, q.DiscountAmount, q.TotalValue)
+	}
+	// (100 + 90) / 1000
+	if q.DiscountPercent != 19 {
+		t.Errorf("DiscountPercent = %v, want 19", q.DiscountPercent)
+	}
+}
+
+func TestDiscountPolicy(t *testing.T) {
+	if p := DiscountPolicyFromSettings(map[string]string{}); p.ApprovalPercent != 0 {
+		t.Errorf("expected disabled policy, got %v", p.ApprovalPercent)
+	}
+	if p := DiscountPolicyFromSettings(map[string]string{SettingDiscountApprovalPercent: "abc"}); p.ApprovalPercent != 0 {
+		t.Errorf("expected disabled policy for invalid value, got %v", p.ApprovalPercent)
+	}
+
+	policy := DiscountPolicyFromSettings(map[string]string{SettingDiscountApprovalPercent: " 15 "})
+	if policy.ApprovalPercent != 15 {
+		t.Fatalf("ApprovalPercent = %v, want 15", policy.ApprovalPercent)
+	}
+
+	tests := []struct {
+		name     string
+		quote    Quote
+		wantNext QuoteStatus
+	}{
+		{"within limit", Quote{Status: QuoteStatusPending, DiscountPercent: 15}, QuoteStatusPending},
+		{"above limit", Quote{Status: QuoteStatusPending, DiscountPercent: 20}, QuoteStatusAwaitingApproval},
+		{"already approved", Quote{Status: QuoteStatusPending, DiscountPercent: 20, DiscountApprovedPercent: 20}, QuoteStatusPending},
+		{"raised after approval", Quote{Status: QuoteStatusPending, DiscountPercent: 25, DiscountApprovedPercent: 20}, QuoteStatusAwaitingApproval},
+		{"reduced while awaiting", Quote{Status: QuoteStatusAwaitingApproval, DiscountPercent: 10}, QuoteStatusPending},
+		{"still awaiting", Quote{Status: QuoteStatusAwaitingApproval, DiscountPercent: 30}, QuoteStatusAwaitingApproval},
+		{"approved quotes are untouched", Quote{Status: QuoteStatusApproved, DiscountPercent: 30}, QuoteStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.StatusFor(&tt.quote); got != tt.wantNext {
+				t.Errorf("StatusFor() = %v, want %v", got, tt.wantNext)
+			}
+		})
+	}
+
+	if (DiscountPolicy{}).RequiresApproval(&Quote{DiscountPercent: 90}) {
+		t.Error("disabled policy must not require approval")
+	}
+}
+
+func TestQuoteStatus_AwaitingApprovalTransitions(t *testing.T) {
+	// Entrada e saída de awaiting_approval só pela política e pela aprovação do gerente
+	if QuoteStatusPending.CanTransitionTo(QuoteStatusAwaitingApproval) {
+		t.Error("pending must not move to awaiting_approval through the status endpoint")
+	}
+	if QuoteStatusAwaitingApproval.CanTransitionTo(QuoteStatusPending) || QuoteStatusAwaitingApproval.CanTransitionTo(QuoteStatusApproved) {
+		t.Error("awaiting_approval must only leave through the discount approval")
+	}
+	if !QuoteStatusAwaitingApproval.CanTransitionTo(QuoteStatusCancelled) {
+		t.Error("awaiting_approval must be cancellable")
+	}
+	if !QuoteStatusAwaitingApproval.IsEditable() {
+		t.Error("awaiting_approval must stay editable so the discount can be reduced")
+	}
+}
@@ -1,44 +1,134 @@
 package quote
 
+import "time"
+
 type CreateQuoteDTO struct {
-	TenantID       string       `json:"tenant_id" binding:"required"`
-	ClientID       string       `json:"client_id" binding:"required"`
-	UserID         string       `json:"user_id" binding:"required"`
-	Discount       float64      `json:"discount,omitempty"`
-	Status         QuoteStatus  `json:"status,omitempty"`
-	ConversionRate *float64     `json:"conversion_rate,omitempty"`
-	Notes          string       `json:"notes,omitempty"`
-	Items          []QuoteItemDTO `json:"items" binding:"required"`
+	TenantID string      `json:"tenant_id" binding:"required"`
+	ClientID string      `json:"client_id" binding:"required"`
+	UserID   string      `json:"user_id" binding:"required"`
+	Discount float64     `json:"discount,omitempty"`
+	Status   QuoteStatus `json:"status,omitempty"`
+	Notes    string      `json:"notes,omitempty"`
+	// ValidUntil sobrescreve a validade padrão do tenant
+	ValidUntil *time.Time     `json:"valid_until,omitempty"`
+	Items      []QuoteItemDTO `json:"items,omitempty"`
+	// Options cria o orçamento com alternativas; substitui Items
+	Options []QuoteOptionDTO `json:"options,omitempty"`
+	// DiscountType indica se Discount é um valor em R$ (amount, padrão) ou um percentual
+	DiscountType DiscountType `json:"discount_type,omitempty"`
+	// PaymentTerms sobrescreve as condições de pagamento padrão do tenant
+	PaymentTerms *PaymentTerms `json:"payment_terms,omitempty"`
+}
+
+// QuoteOptionDTO é uma alternativa do orçamento com seus itens
+type QuoteOptionDTO struct {
+	Name  string         `json:"name" binding:"required"`
+	Items []QuoteItemDTO `json:"items" binding:"required"`
+}
+
+// CreateOptionDTO adiciona uma opção ao orçamento. Sem Items, copia os itens de
+// CopyFrom (ou da primeira opção), opcionalmente trocando o produto (outra pedra).
+type CreateOptionDTO struct {
+	Name      string         `json:"name" binding:"required"`
+	Items     []QuoteItemDTO `json:"items,omitempty"`
+	CopyFrom  string         `json:"copy_from,omitempty"`
+	ProductID string         `json:"product_id,omitempty"`
+}
+
+type UpdateOptionDTO struct {
+	Name string `json:"name" binding:"required"`
 }
 
 type UpdateQuoteDTO struct {
-	ClientID       string       `json:"client_id,omitempty"`
-	UserID         string       `json:"user_id,omitempty"`
-	Discount       *float64     `json:"discount,omitempty"`
-	Status         QuoteStatus  `json:"status,omitempty"`
-	ConversionRate *float64     `json:"conversion_rate,omitempty"`
-	Notes          string       `json:"notes,omitempty"`
+	ClientID   string      `json:"client_id,omitempty"`
+	UserID     string      `json:"user_id,omitempty"`
+	Discount   *float64    `json:"discount,omitempty"`
+	Status     QuoteStatus `json:"status,omitempty"`
+	Notes      string      `json:"notes,omitempty"`
+	ValidUntil *time.Time  `json:"valid_until,omitempty"`
+	// DiscountType vazio mantém o tipo atual do desconto
+	DiscountType DiscountType `json:"discount_type,omitempty"`
+	// PaymentTerms substitui as condições de pagamento do orçamento
+	PaymentTerms *PaymentTerms `json:"payment_terms,omitempty"`
 }
 
 type QuoteItemDTO struct {
-	ProductID string `json:"product_id" binding:"required"`
-	Quantity  int    `json:"quantity" binding:"required"`
-	Price     float64 `json:"price" binding:"required"`
+	// ProductID é obrigatório em itens de produto; linhas de serviço não têm produto
+	ProductID string `json:"product_id,omitempty"`
+	// OptionID é obrigatório ao adicionar itens em orçamentos com opções
+	OptionID string `json:"option_id,omitempty"`
+	// SlabID escolhe a chapa do pátio para a peça; precisa estar disponível
+	SlabID   string `json:"slab_id,omitempty"`
+	Quantity int    `json:"quantity" binding:"required"`
+	// Price sobrescreve o preço do produto; quando zero, usa o preço de cadastro.
+	Price float64 `json:"price,omitempty"`
+	// Desconto do item, em R$ (amount, padrão) ou percentual
+	Discount     float64      `json:"discount,omitempty"`
+	DiscountType DiscountType `json:"discount_type,omitempty"`
+
+	WidthCM        float64 `json:"width_cm,omitempty"`
+	HeightCM       float64 `json:"height_cm,omitempty"`
+	Thickness      float64 `json:"thickness,omitempty"`
+	EdgeType       string  `json:"edge_type,omitempty"`
+	EdgeSides      string  `json:"edge_sides,omitempty"` // lados com borda; sem lados, só a frente
+	HasCutout      bool    `json:"has_cutout,omitempty"`
+	ReferenceImage string  `json:"reference_image,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+
+	// Linha de serviço: Kind "service" com um serviço do catálogo e/ou descrição livre.
+	// Sem Price, usa o preço padrão do serviço.
+	Kind        ItemKind `json:"kind,omitempty"`
+	ServiceID   string   `json:"service_id,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+type UpdateQuoteItemDTO struct {
+	ProductID      string        `json:"product_id,omitempty"`
+	SlabID         *string       `json:"slab_id,omitempty"` // vazio desvincula a chapa
+	Quantity       *int          `json:"quantity,omitempty"`
+	Price          *float64      `json:"price,omitempty"`
+	Discount       *float64      `json:"discount,omitempty"`
+	DiscountType   *DiscountType `json:"discount_type,omitempty"`
+	WidthCM        *float64      `json:"width_cm,omitempty"`
+	HeightCM       *float64      `json:"height_cm,omitempty"`
+	Thickness      *float64      `json:"thickness,omitempty"`
+	EdgeType       *string       `json:"edge_type,omitempty"`
+	EdgeSides      *string       `json:"edge_sides,omitempty"`
+	HasCutout      *bool         `json:"has_cutout,omitempty"`
+	ReferenceImage *string       `json:"reference_image,omitempty"`
+	Notes          *string       `json:"notes,omitempty"`
+	// Description altera a descrição de uma linha de serviço
+	Description *string `json:"description,omitempty"`
+}
+
+// QuoteDetailDTO é o orçamento completo, com os itens
+type QuoteDetailDTO struct {
+	*Quote
+	Items   []*QuoteItem   `json:"items"`
+	Options []*QuoteOption `json:"options,omitempty"`
+	// PaymentPlan é o cronograma de pagamento calculado a partir de PaymentTerms
+	PaymentPlan *PaymentPlan `json:"payment_plan"`
 }
 
 type QuoteDTO struct {
-	ID             string       `json:"id"`
-	TenantID       string       `json:"tenant_id"`
-	ClientID       string       `json:"client_id"`
-	UserID         string       `json:"user_id"`
-	TotalValue     float64      `json:"total_value"`
-	Discount       float64      `json:"discount"`
-	Status         QuoteStatus  `json:"status"`
-	ConversionRate *float64     `json:"conversion_rate,omitempty"`
-	Notes          string       `json:"notes,omitempty"`
-	Items          []QuoteItemDTO `json:"items,omitempty"`
-	CreatedAt      string       `json:"created_at"`
-	UpdatedAt      string       `json:"updated_at"`
+	ID         string         `json:"id"`
+	Number     string         `json:"number"`
+	TenantID   string         `json:"tenant_id"`
+	ClientID   string         `json:"client_id"`
+	UserID     string         `json:"user_id"`
+	Subtotal   float64        `json:"subtotal"`
+	TotalValue float64        `json:"total_value"`
+	Discount   float64        `json:"discount"`
+	Status     QuoteStatus    `json:"status"`
+	Notes      string         `json:"notes,omitempty"`
+	Items      []QuoteItemDTO `json:"items,omitempty"`
+	ValidUntil *time.Time     `json:"valid_until,omitempty"`
+	CreatedAt  string         `json:"created_at"`
+	UpdatedAt  string         `json:"updated_at"`
+	// Tipo do desconto informado, valor em R$ e desconto efetivo em %
+	DiscountType    DiscountType `json:"discount_type"`
+	DiscountAmount  float64      `json:"discount_amount"`
+	DiscountPercent float64      `json:"discount_percent"`
 }
 
 type QuoteListDTO struct {
@@ -48,6 +138,105 @@ type QuoteListDTO struct {
 	Offset int         `json:"offset"`
 }
 
+// RenewQuoteDTO renova a validade do orçamento; sem ValidUntil usa a validade padrão do tenant
+type RenewQuoteDTO struct {
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// DuplicateQuoteDTO copia um orçamento (itens e observações) para outro cliente
+type DuplicateQuoteDTO struct {
+	ClientID   string     `json:"client_id" binding:"required"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+}
+
+// ApproveDiscountDTO é a aprovação, por um gerente, do desconto acima do limite do tenant
+type ApproveDiscountDTO struct {
+	Reason string `json:"reason,omitempty"`
+}
+
 type UpdateQuoteStatusDTO struct {
 	Status QuoteStatus `json:"status" binding:"required"`
-}
\ No newline at end of file
+	Reason string      `json:"reason,omitempty"`
+	// OptionID é a opção aprovada, quando o orçamento tem mais de uma
+	OptionID string `json:"option_id,omitempty"`
+}
+
+// StatusChange descreve uma mudança de status e quem a fez
+type StatusChange struct {
+	Status    QuoteStatus
+	Reason    string
+	OptionID  string
+	UserID    string
+	Source    StatusChangeSource
+	IPAddress string
+	UserAgent string
+}
+
+// ClientDecisionDTO é a resposta do cliente pelo link público do orçamento
+type ClientDecisionDTO struct {
+	Decision QuoteStatus `json:"decision" binding:"required"`
+	Comment  string      `json:"comment,omitempty"`
+	OptionID string      `json:"option_id,omitempty"`
+}
+
+type ShareLinkDTO struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PublicQuoteDTO é a visão somente leitura exibida ao cliente pelo link público
+type PublicQuoteDTO struct {
+	ID         string               `json:"id"`
+	Number     string               `json:"number"`
+	Status     QuoteStatus          `json:"status"`
+	Subtotal   float64              `json:"subtotal"`
+	Discount   float64              `json:"discount"`
+	TotalValue float64              `json:"total_value"`
+	Notes      string               `json:"notes,omitempty"`
+	CreatedAt  time.Time            `json:"created_at"`
+	ApprovedAt *time.Time           `json:"approved_at,omitempty"`
+	ValidUntil *time.Time           `json:"valid_until,omitempty"`
+	Company    PublicCompanyDTO     `json:"company"`
+	ClientName string               `json:"client_name"`
+	Items      []PublicQuoteItemDTO `json:"items"`
+	// Options lista as alternativas; nesse caso os itens ficam em cada opção
+	Options          []PublicQuoteOptionDTO `json:"options,omitempty"`
+	SelectedOptionID string                 `json:"selected_option_id,omitempty"`
+	PaymentPlan      *PaymentPlan           `json:"payment_plan,omitempty"`
+	CanRespond       bool                   `json:"can_respond"`
+}
+
+type PublicQuoteOptionDTO struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	Subtotal   float64              `json:"subtotal"`
+	Discount   float64              `json:"discount"`
+	TotalValue float64              `json:"total_value"`
+	Items      []PublicQuoteItemDTO `json:"items"`
+}
+
+type PublicCompanyDTO struct {
+	Name         string `json:"name"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Email        string `json:"email,omitempty"`
+}
+
+type PublicQuoteItemDTO struct {
+	ProductName string  `json:"product_name"`
+	WidthCM     float64 `json:"width_cm,omitempty"`
+	HeightCM    float64 `json:"height_cm,omitempty"`
+	Thickness   float64 `json:"thickness,omitempty"`
+	AreaM2      float64 `json:"area_m2,omitempty"`
+	EdgeType    string  `json:"edge_type,omitempty"`
+	EdgeSides   string  `json:"edge_sides,omitempty"`
+	HasCutout   bool    `json:"has_cutout"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Discount    float64 `json:"discount,omitempty"` // desconto do item em R$
+	Total       float64 `json:"total"`
+	// Kind distingue peças de linhas de serviço; em serviços ProductName traz a descrição
+	Kind ItemKind `json:"kind"`
+}
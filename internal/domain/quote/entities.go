@@ -3,6 +3,7 @@ package quote
 import (
 	"time"
 
+	productDomain "erp-api/internal/domain/product"
 	"erp-api/internal/utils/dbtypes"
 
 	"gorm.io/gorm"
@@ -15,22 +16,48 @@ const (
 	QuoteStatusApproved  QuoteStatus = "approved"
 	QuoteStatusRejected  QuoteStatus = "rejected"
 	QuoteStatusCancelled QuoteStatus = "cancelled"
+	QuoteStatusExpired   QuoteStatus = "expired"
+	// QuoteStatusAwaitingApproval: desconto acima do limite do tenant, aguardando um gerente
+	QuoteStatusAwaitingApproval QuoteStatus = "awaiting_approval"
 )
 
 type Quote struct {
 	ID       dbtypes.UUID `json:"id" gorm:"primaryKey"`
-	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null;index"`
+	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null;index;uniqueIndex:idx_quotes_tenant_number"`
+	Number   string       `json:"number" gorm:"size:40;uniqueIndex:idx_quotes_tenant_number"` // ex.: ORC-2026-000123
 	ClientID dbtypes.UUID `json:"client_id" gorm:"not null;index"`
 	UserID   dbtypes.UUID `json:"user_id" gorm:"not null;index"`
 
 	Subtotal   float64 `json:"subtotal"`
-	Discount   float64 `json:"discount"`
+	Discount   float64 `json:"discount"` // valor informado, em R$ ou %, conforme DiscountType
 	TotalValue float64 `json:"total_value"`
 
+	// Desconto
+	DiscountType    DiscountType `json:"discount_type" gorm:"size:10;default:'amount'"`
+	DiscountAmount  float64      `json:"discount_amount"`  // desconto do orçamento em R$, calculado
+	DiscountPercent float64      `json:"discount_percent"` // desconto efetivo (itens + orçamento) sobre o valor bruto
+
+	// Aprovação do desconto por um gerente
+	DiscountApprovedPercent float64       `json:"discount_approved_percent,omitempty"`
+	DiscountApprovedBy      *dbtypes.UUID `json:"discount_approved_by,omitempty"`
+	DiscountApprovedAt      *time.Time    `json:"discount_approved_at,omitempty"`
+
+	// Margem bruta, calculada a partir do custo congelado nos itens
+	CostTotal     float64 `json:"cost_total"`
+	Margin        float64 `json:"margin"`         // TotalValue - CostTotal
+	MarginPercent float64 `json:"margin_percent"` // Margin sobre TotalValue
+
 	Status QuoteStatus `json:"status"`
 	Notes  string      `json:"notes,omitempty"`
 
 	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty" gorm:"index"`
+
+	// SelectedOptionID é a opção aprovada quando o orçamento tem alternativas
+	SelectedOptionID *dbtypes.UUID `json:"selected_option_id,omitempty"`
+
+	// Condições de pagamento; o cronograma é calculado em QuoteDetailDTO.PaymentPlan
+	PaymentTerms PaymentTerms `json:"payment_terms" gorm:"embedded;embeddedPrefix:payment_"`
 
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
@@ -44,10 +71,19 @@ func (q *Quote) BeforeCreate(tx *gorm.DB) error {
 }
 
 type QuoteItem struct {
-	ID        dbtypes.UUID `json:"id" gorm:"primaryKey"`
-	TenantID  dbtypes.UUID `json:"tenant_id" gorm:"not null"`
-	QuoteID   dbtypes.UUID `json:"quote_id" gorm:"not null"`
-	ProductID dbtypes.UUID `json:"product_id" gorm:"not null"`
+	ID        dbtypes.UUID  `json:"id" gorm:"primaryKey"`
+	TenantID  dbtypes.UUID  `json:"tenant_id" gorm:"not null"`
+	QuoteID   dbtypes.UUID  `json:"quote_id" gorm:"not null"`
+	ProductID *dbtypes.UUID `json:"product_id,omitempty"` // vazio em linhas de serviço
+	// OptionID agrupa o item em uma opção do orçamento; vazio quando não há opções
+	OptionID *dbtypes.UUID `json:"option_id,omitempty" gorm:"index"`
+	// SlabID é a chapa escolhida para a peça, reservada quando o orçamento é aprovado
+	SlabID *dbtypes.UUID `json:"slab_id,omitempty" gorm:"index"`
+
+	// Linha de serviço (instalação, frete, mão de obra)
+	Kind        ItemKind      `json:"kind" gorm:"size:10;default:'product'"`
+	ServiceID   *dbtypes.UUID `json:"service_id,omitempty"`                  // serviço do catálogo, quando houver
+	Description string        `json:"description,omitempty" gorm:"size:255"` // descrição livre do serviço
 
 	// Medidas
 	WidthCM   float64 `json:"width_cm,omitempty"`  // largura
@@ -56,12 +92,25 @@ type QuoteItem struct {
 	AreaM2    float64 `json:"area_m2,omitempty"`   // calculado
 
 	// Preço
-	UnitPrice float64 `json:"unit_price" gorm:"not null"` // preço por m² ou unitário
-	Quantity  int     `json:"quantity" gorm:"default:1"`
-	Total     float64 `json:"total"` // calculado
+	PriceType       productDomain.PriceType `json:"price_type" gorm:"default:'unit'"` // copiado do produto
+	UnitPrice       float64                 `json:"unit_price" gorm:"not null"`       // preço por m², metro linear ou unitário
+	Quantity        int                     `json:"quantity" gorm:"default:1"`
+	EdgeSurcharge   float64                 `json:"edge_surcharge"`   // adicional de borda por peça
+	CutoutSurcharge float64                 `json:"cutout_surcharge"` // adicional de recorte por peça
+	Total           float64                 `json:"total"`            // calculado, já com o desconto do item
+
+	// Custo, congelado do produto/serviço no momento em que o item é incluído
+	UnitCost float64 `json:"unit_cost"` // custo na mesma unidade do UnitPrice
+	Cost     float64 `json:"cost"`      // calculado: UnitCost × medida × quantidade
+
+	// Desconto do item
+	Discount       float64      `json:"discount"` // valor informado, em R$ ou %
+	DiscountType   DiscountType `json:"discount_type" gorm:"size:10;default:'amount'"`
+	DiscountAmount float64      `json:"discount_amount"` // em R$, calculado
 
 	// Extras
-	EdgeType       string `json:"edge_type,omitempty"` // borda
+	EdgeType       string `json:"edge_type,omitempty"`                 // borda
+	EdgeSides      string `json:"edge_sides,omitempty" gorm:"size:40"` // lados com borda, ex.: "front,left"
 	HasCutout      bool   `json:"has_cutout" gorm:"default:false"`
 	ReferenceImage string `json:"reference_image,omitempty"`
 	Notes          string `json:"notes,omitempty"`
@@ -76,3 +125,78 @@ func (qi *QuoteItem) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// QuoteOption é uma alternativa do orçamento (ex.: o mesmo serviço em outra pedra),
+// com seus próprios itens. Os totais são calculados a partir dos itens.
+type QuoteOption struct {
+	ID       dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null"`
+	QuoteID  dbtypes.UUID `json:"quote_id" gorm:"not null;index"`
+	Name     string       `json:"name" gorm:"not null;size:120"`
+	Position int          `json:"position"`
+
+	Subtotal   float64 `json:"subtotal" gorm:"-"`
+	Discount   float64 `json:"discount" gorm:"-"` // desconto do orçamento em R$ sobre a opção
+	TotalValue float64 `json:"total_value" gorm:"-"`
+
+	DiscountPercent float64 `json:"discount_percent" gorm:"-"`
+
+	CostTotal     float64 `json:"cost_total" gorm:"-"`
+	Margin        float64 `json:"margin" gorm:"-"`
+	MarginPercent float64 `json:"margin_percent" gorm:"-"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (o *QuoteOption) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// StatusChangeSource indica por onde a mudança de status foi feita
+type StatusChangeSource string
+
+const (
+	StatusSourceUser       StatusChangeSource = "user"
+	StatusSourcePublicLink StatusChangeSource = "public_link"
+	StatusSourceSystem     StatusChangeSource = "system"
+)
+
+// QuoteStatusHistory registra cada mudança de status de um orçamento.
+type QuoteStatusHistory struct {
+	ID       dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID dbtypes.UUID `json:"tenant_id" gorm:"not null;index"`
+	QuoteID  dbtypes.UUID `json:"quote_id" gorm:"not null;index"`
+
+	FromStatus QuoteStatus   `json:"from_status,omitempty"`
+	ToStatus   QuoteStatus   `json:"to_status" gorm:"not null"`
+	UserID     *dbtypes.UUID `json:"user_id,omitempty" gorm:"index"`
+	Reason     string        `json:"reason,omitempty"`
+
+	// Origem da mudança; decisões pelo link público guardam IP e navegador do cliente
+	Source    StatusChangeSource `json:"source" gorm:"default:'user'"`
+	IPAddress string             `json:"ip_address,omitempty"`
+	UserAgent string             `json:"user_agent,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (h *QuoteStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+func (QuoteStatusHistory) TableName() string { return "quote_status_history" }
+
+// QuoteSequence guarda o último número emitido por tenant e escopo (ano ou "all").
+type QuoteSequence struct {
+	TenantID  dbtypes.UUID `gorm:"primaryKey"`
+	Scope     string       `gorm:"primaryKey;size:10"`
+	LastValue int64        `gorm:"not null;default:0"`
+	UpdatedAt time.Time    `gorm:"autoUpdateTime"`
+}
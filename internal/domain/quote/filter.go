@@ -0,0 +1,65 @@
+package quote
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidFilter = errors.New("invalid quote filter")
+
+// SortField é o campo de ordenação da listagem de orçamentos
+type SortField string
+
+const (
+	SortByCreatedAt  SortField = "created_at"
+	SortByTotalValue SortField = "total_value"
+	SortByClientName SortField = "client_name"
+)
+
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByTotalValue, SortByClientName:
+		return true
+	}
+	return false
+}
+
+// ListFilter restringe a listagem de orçamentos; campos vazios não filtram.
+// List e Count aplicam os mesmos filtros; a ordenação só vale para List.
+type ListFilter struct {
+	Status   QuoteStatus
+	ClientID string
+	UserID   string
+	// Number busca por parte do número do orçamento (ex.: "2026-0001")
+	Number string
+
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	ApprovedFrom *time.Time
+	ApprovedTo   *time.Time
+
+	MinTotal *float64
+	MaxTotal *float64
+
+	SortBy  SortField // padrão: created_at
+	SortAsc bool      // padrão: decrescente
+}
+
+func (f *ListFilter) Validate() error {
+	if f.Status != "" && !f.Status.IsValid() {
+		return ErrInvalidQuoteStatus
+	}
+	if f.SortBy != "" && !f.SortBy.IsValid() {
+		return ErrInvalidFilter
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return ErrInvalidFilter
+	}
+	if f.ApprovedFrom != nil && f.ApprovedTo != nil && f.ApprovedFrom.After(*f.ApprovedTo) {
+		return ErrInvalidFilter
+	}
+	if f.MinTotal != nil && f.MaxTotal != nil && *f.MinTotal > *f.MaxTotal {
+		return ErrInvalidFilter
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package quote
+
+import (
+	"errors"
+	"strings"
+)
+
+// ItemKind distingue as peças de pedra (produto) das linhas de serviço,
+// como instalação, frete e mão de obra.
+type ItemKind string
+
+const (
+	ItemKindProduct ItemKind = "product"
+	ItemKindService ItemKind = "service"
+	// ItemKindFreight é a linha de frete calculada pela zona de entrega do cliente
+	ItemKindFreight ItemKind = "freight"
+)
+
+var (
+	ErrInvalidItemKind    = errors.New("invalid item kind")
+	ErrInvalidProductItem = errors.New("product items require product_id")
+	ErrInvalidServiceItem = errors.New("service items require a service_id or description and take no product, measures or finishing")
+	ErrFreightLine        = errors.New("freight lines are calculated from the delivery zone and cannot be edited")
+)
+
+// IsValid indica se o tipo de item pode ser informado pelo usuário; vazio
+// equivale a produto. Linhas de frete só são criadas pelo cálculo de frete.
+func (k ItemKind) IsValid() bool {
+	switch k {
+	case "", ItemKindProduct, ItemKindService:
+		return true
+	}
+	return false
+}
+
+// IsService indica se o item é uma linha sem produto (serviço ou frete).
+func (qi *QuoteItem) IsService() bool {
+	return qi.Kind == ItemKindService || qi.Kind == ItemKindFreight
+}
+
+// IsFreight indica se o item é a linha de frete calculada pela zona de entrega.
+func (qi *QuoteItem) IsFreight() bool {
+	return qi.Kind == ItemKindFreight
+}
+
+// ProductIDString retorna o produto do item, ou vazio em linhas de serviço.
+func (qi *QuoteItem) ProductIDString() string {
+	if qi.ProductID == nil {
+		return ""
+	}
+	return qi.ProductID.String()
+}
+
+// Label é o nome exibido de uma linha de serviço.
+func (qi *QuoteItem) Label() string {
+	if qi.Description != "" {
+		return qi.Description
+	}
+	return "Serviço"
+}
+
+// ProductItems retorna apenas os itens de produto (peças de pedra).
+func ProductItems(items []*QuoteItem) []*QuoteItem {
+	products := make([]*QuoteItem, 0, len(items))
+	for _, item := range items {
+		if !item.IsService() {
+			products = append(products, item)
+		}
+	}
+	return products
+}
+
+// Validate confere os campos exigidos por cada tipo de item. Serviços são
+// cobrados por unidade, sem medidas nem acabamentos.
+func (req *QuoteItemDTO) Validate() error {
+	if req.Kind == ItemKindFreight {
+		return ErrFreightLine
+	}
+	if !req.Kind.IsValid() {
+		return ErrInvalidItemKind
+	}
+	if req.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if req.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+
+	if req.Kind != ItemKindService {
+		if req.ProductID == "" {
+			return ErrInvalidProductItem
+		}
+		return nil
+	}
+
+	if req.ServiceID == "" && strings.TrimSpace(req.Description) == "" {
+		return ErrInvalidServiceItem
+	}
+	if req.ProductID != "" || req.WidthCM != 0 || req.HeightCM != 0 || req.Thickness != 0 || req.EdgeType != "" || req.EdgeSides != "" || req.HasCutout {
+		return ErrInvalidServiceItem
+	}
+	return nil
+}
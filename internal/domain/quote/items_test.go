@@ -0,0 +1,59 @@
+package quote
+
+import "testing"
+
+func TestQuoteItemDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  QuoteItemDTO
+		want error
+	}{
+		{"product", QuoteItemDTO{ProductID: "p1", Quantity: 1, WidthCM: 180, HeightCM: 60}, nil},
+		{"product without product_id", QuoteItemDTO{Quantity: 1}, ErrInvalidProductItem},
+		{"unknown kind", QuoteItemDTO{Kind: "labor", Quantity: 1}, ErrInvalidItemKind},
+		{"zero quantity", QuoteItemDTO{ProductID: "p1"}, ErrInvalidQuantity},
+		{"catalog service", QuoteItemDTO{Kind: ItemKindService, ServiceID: "s1", Quantity: 1}, nil},
+		{"free description service", QuoteItemDTO{Kind: ItemKindService, Description: "Frete", Quantity: 1, Price: 150}, nil},
+		{"service without description", QuoteItemDTO{Kind: ItemKindService, Description: "  ", Quantity: 1}, ErrInvalidServiceItem},
+		{"service with product", QuoteItemDTO{Kind: ItemKindService, Description: "Instalação", ProductID: "p1", Quantity: 1}, ErrInvalidServiceItem},
+		{"service with measures", QuoteItemDTO{Kind: ItemKindService, Description: "Instalação", WidthCM: 100, Quantity: 1}, ErrInvalidServiceItem},
+		{"service with cutout", QuoteItemDTO{Kind: ItemKindService, Description: "Instalação", HasCutout: true, Quantity: 1}, ErrInvalidServiceItem},
+		{"freight is calculated", QuoteItemDTO{Kind: ItemKindFreight, Description: "Frete", Quantity: 1}, ErrFreightLine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceItemCountsInTotals(t *testing.T) {
+	cfg := PricingConfig{CutoutSurcharge: 80}
+	service := &QuoteItem{Kind: ItemKindService, Description: "Instalação", UnitPrice: 250, Quantity: 2}
+	if err := PriceItem(service, "", cfg); err != nil {
+		t.Fatalf("PriceItem() error = %v", err)
+	}
+	if service.Total != 500 || service.CutoutSurcharge != 0 {
+		t.Errorf("service total = %v (cutout %v), want 500 without surcharges", service.Total, service.CutoutSurcharge)
+	}
+
+	piece := &QuoteItem{UnitPrice: 1000, Quantity: 1}
+	if err := PriceItem(piece, "", cfg); err != nil {
+		t.Fatalf("PriceItem() error = %v", err)
+	}
+
+	quote := &Quote{}
+	if err := CalculateTotals(quote, []*QuoteItem{piece, service}); err != nil {
+		t.Fatalf("CalculateTotals() error = %v", err)
+	}
+	if quote.Subtotal != 1500 || quote.TotalValue != 1500 {
+		t.Errorf("subtotal = %v, total = %v, want 1500", quote.Subtotal, quote.TotalValue)
+	}
+
+	if products := ProductItems([]*QuoteItem{piece, service}); len(products) != 1 || products[0] != piece {
+		t.Errorf("ProductItems() = %v, want only the piece", products)
+	}
+}
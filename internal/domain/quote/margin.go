@@ -0,0 +1,58 @@
+package quote
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrMarginBelowMinimum = errors.New("quote margin is below the tenant minimum")
+
+// SettingMinMarginPercent é a chave em settings com a margem bruta mínima, em %,
+// para que um orçamento possa ser aprovado.
+const SettingMinMarginPercent = "quote_min_margin_percent"
+
+// margin calcula custo, margem bruta e margem percentual sobre o total.
+func margin(total, cost float64) (float64, float64, float64) {
+	cost = roundTo(cost, 2)
+	value := roundTo(total-cost, 2)
+	if total <= 0 {
+		return cost, value, 0
+	}
+	return cost, value, roundTo(value/total*100, 2)
+}
+
+// HasCost indica se o orçamento tem custo informado. Sem custo a margem não diz
+// nada e não é considerada pela política nem pelos relatórios.
+func (q *Quote) HasCost() bool {
+	return q.CostTotal > 0
+}
+
+// MarginPolicy é a política de margem mínima do tenant.
+type MarginPolicy struct {
+	// MinPercent é a margem bruta mínima para aprovação; zero desativa a política.
+	MinPercent float64
+}
+
+// MarginPolicyFromSettings lê a política de margem das settings do tenant.
+// Valores ausentes ou inválidos desativam a política.
+func MarginPolicyFromSettings(settings map[string]string) MarginPolicy {
+	percent, err := strconv.ParseFloat(strings.TrimSpace(settings[SettingMinMarginPercent]), 64)
+	if err != nil || percent <= 0 {
+		return MarginPolicy{}
+	}
+	return MarginPolicy{MinPercent: percent}
+}
+
+// BelowMinimum indica se a margem do orçamento está abaixo do mínimo do tenant.
+func (p MarginPolicy) BelowMinimum(q *Quote) bool {
+	return p.MinPercent > 0 && q.HasCost() && q.MarginPercent < p.MinPercent
+}
+
+// Check retorna ErrMarginBelowMinimum quando o orçamento não pode ser aprovado.
+func (p MarginPolicy) Check(q *Quote) error {
+	if p.BelowMinimum(q) {
+		return ErrMarginBelowMinimum
+	}
+	return nil
+}
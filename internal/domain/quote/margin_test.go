@@ -0,0 +1,111 @@
+package quote
+
+import (
+	"testing"
+	"time"
+
+	productDomain "erp-api/internal/domain/product"
+)
+
+func TestPriceItem_Cost(t *testing.T) {
+	cfg := PricingConfig{EdgeSurcharges: map[string]float64{"reto": 20}}
+
+	// 200 × 60 cm = 1,2 m²; o custo segue a mesma medida do preço e ignora adicionais
+	item := &QuoteItem{UnitPrice: 500, UnitCost: 300, Quantity: 2, WidthCM: 200, HeightCM: 60, EdgeType: "reto"}
+	if err := PriceItem(item, productDomain.PriceTypeSquareMeter, cfg); err != nil {
+		t.Fatalf("PriceItem() error = %v", err)
+	}
+	if item.Cost != 720 {
+		t.Errorf("Cost = %v, want 720", item.Cost)
+	}
+
+	linear := &QuoteItem{UnitPrice: 100, UnitCost: 40, Quantity: 3, WidthCM: 250, HeightCM: 10}
+	if err := PriceItem(linear, productDomain.PriceTypeLinearMeter, cfg); err != nil {
+		t.Fatalf("PriceItem() error = %v", err)
+	}
+	if linear.Cost != 300 {
+		t.Errorf("linear Cost = %v, want 300", linear.Cost)
+	}
+}
+
+func TestCalculateTotals_Margin(t *testing.T) {
+	q := &Quote{Discount: 100}
+	items := []*QuoteItem{
+		{Total: 800, Cost: 450},
+		{Total: 300, Cost: 150},
+	}
+
+	if err := CalculateTotals(q, items); err != nil {
+		t.Fatalf("CalculateTotals() error = %v", err)
+	}
+	if q.CostTotal != 600 || q.Margin != 400 || q.MarginPercent != 40 {
+		t.Errorf("cost/margin/percent = %v/%v/%v, want 600/400/40", q.CostTotal, q.Margin, q.MarginPercent)
+	}
+}
+
+func TestMarginPolicy(t *testing.T) {
+	if p := MarginPolicyFromSettings(map[string]string{}); p.MinPercent != 0 {
+		t.Errorf("missing setting should disable the policy, got %v", p.MinPercent)
+	}
+	if p := MarginPolicyFromSettings(map[string]string{SettingMinMarginPercent: "abc"}); p.MinPercent != 0 {
+		t.Errorf("invalid setting should disable the policy, got %v", p.MinPercent)
+	}
+
+	policy := MarginPolicyFromSettings(map[string]string{SettingMinMarginPercent: " 30 "})
+
+	tests := []struct {
+		name  string
+		quote Quote
+		want  error
+	}{
+		{"above minimum", Quote{TotalValue: 1000, CostTotal: 600, MarginPercent: 40}, nil},
+		{"exactly at minimum", Quote{TotalValue: 1000, CostTotal: 700, MarginPercent: 30}, nil},
+		{"below minimum", Quote{TotalValue: 1000, CostTotal: 800, MarginPercent: 20}, ErrMarginBelowMinimum},
+		{"without cost", Quote{TotalValue: 1000}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := policy.Check(&tt.quote); err != tt.want {
+				t.Errorf("Check() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := (MarginPolicy{}).Check(&Quote{TotalValue: 1000, CostTotal: 999, MarginPercent: 0.1}); err != nil {
+		t.Errorf("disabled policy should allow any margin, got %v", err)
+	}
+}
+
+func TestBuildProfitabilityReport(t *testing.T) {
+	jan := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 4, 9, 0, 0, 0, time.UTC)
+
+	quotes := []*Quote{
+		{UserID: "ana", ClientID: "c1", Status: QuoteStatusApproved, ApprovedAt: &jan, TotalValue: 1000, CostTotal: 600},
+		{UserID: "ana", ClientID: "c2", Status: QuoteStatusApproved, ApprovedAt: &feb, TotalValue: 500, CostTotal: 450},
+		{UserID: "bruno", ClientID: "c1", Status: QuoteStatusApproved, ApprovedAt: &feb, TotalValue: 2000},
+		{UserID: "bruno", ClientID: "c2", Status: QuoteStatusPending, TotalValue: 900, CostTotal: 100},
+		{UserID: "bruno", ClientID: "c2", Status: QuoteStatusCancelled, ApprovedAt: &jan, TotalValue: 700, CostTotal: 100},
+	}
+	for _, q := range quotes {
+		q.CostTotal, q.Margin, q.MarginPercent = margin(q.TotalValue, q.CostTotal)
+	}
+
+	report := BuildProfitabilityReport(quotes, MarginPolicy{MinPercent: 25})
+
+	want := ProfitabilityStats{Quotes: 3, Revenue: 1500, Cost: 1050, Margin: 450, MarginPercent: 30, BelowMinimum: 1, WithoutCost: 1}
+	if report.Overall != want {
+		t.Errorf("Overall = %+v, want %+v", report.Overall, want)
+	}
+
+	if len(report.ByMonth) != 2 || report.ByMonth[0].Key != "2026-01" || report.ByMonth[1].Key != "2026-02" {
+		t.Fatalf("ByMonth keys = %+v", report.ByMonth)
+	}
+	if len(report.ByUser) != 2 || report.ByUser[0].Key != "ana" {
+		t.Fatalf("ByUser should start with the seller with the highest margin, got %+v", report.ByUser)
+	}
+	if got := report.ByUser[1]; got.Quotes != 1 || got.WithoutCost != 1 || got.Margin != 0 {
+		t.Errorf("bruno = %+v, want one quote without cost", got.ProfitabilityStats)
+	}
+}
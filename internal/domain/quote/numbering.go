@@ -0,0 +1,59 @@
+package quote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Chaves em settings usadas na numeração dos orçamentos.
+const (
+	SettingNumberPrefix      = "quote_number_prefix"
+	SettingNumberYearlyReset = "quote_number_yearly_reset"
+)
+
+const (
+	DefaultNumberPrefix = "ORC"
+	numberDigits        = 6
+	// sequenceScopeAll é o escopo da sequência quando não há reinício anual
+	sequenceScopeAll = "all"
+)
+
+// NumberingConfig define o formato do número do orçamento (ex.: ORC-2026-000123).
+type NumberingConfig struct {
+	Prefix      string
+	YearlyReset bool
+}
+
+// NumberingConfigFromSettings monta a numeração a partir das settings do tenant.
+// Por padrão usa o prefixo ORC e reinicia a sequência a cada ano.
+func NumberingConfigFromSettings(settings map[string]string) NumberingConfig {
+	cfg := NumberingConfig{Prefix: DefaultNumberPrefix, YearlyReset: true}
+
+	if prefix := strings.TrimSpace(settings[SettingNumberPrefix]); prefix != "" {
+		cfg.Prefix = strings.ToUpper(prefix)
+	}
+	if reset, err := strconv.ParseBool(strings.TrimSpace(settings[SettingNumberYearlyReset])); err == nil {
+		cfg.YearlyReset = reset
+	}
+
+	return cfg
+}
+
+// Scope identifica a sequência usada na data informada: o ano, quando há
+// reinício anual, ou uma sequência única para o tenant.
+func (cfg NumberingConfig) Scope(at time.Time) string {
+	if cfg.YearlyReset {
+		return strconv.Itoa(at.Year())
+	}
+	return sequenceScopeAll
+}
+
+// Format monta o número legível a partir do valor da sequência.
+func (cfg NumberingConfig) Format(at time.Time, seq int64) string {
+	if cfg.YearlyReset {
+		return fmt.Sprintf("%s-%d-%0*d", cfg.Prefix, at.Year(), numberDigits, seq)
+	}
+	return fmt.Sprintf("%s-%0*d", cfg.Prefix, numberDigits, seq)
+}
@@ -0,0 +1,54 @@
+package quote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNumberingConfig(t *testing.T) {
+	at := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		settings  map[string]string
+		wantScope string
+		wantNum   string
+	}{
+		{
+			name:      "defaults",
+			settings:  map[string]string{},
+			wantScope: "2026",
+			wantNum:   "ORC-2026-000123",
+		},
+		{
+			name:      "custom prefix",
+			settings:  map[string]string{SettingNumberPrefix: " prop "},
+			wantScope: "2026",
+			wantNum:   "PROP-2026-000123",
+		},
+		{
+			name:      "without yearly reset",
+			settings:  map[string]string{SettingNumberYearlyReset: "false"},
+			wantScope: "all",
+			wantNum:   "ORC-000123",
+		},
+		{
+			name:      "invalid reset flag keeps default",
+			settings:  map[string]string{SettingNumberYearlyReset: "talvez"},
+			wantScope: "2026",
+			wantNum:   "ORC-2026-000123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NumberingConfigFromSettings(tt.settings)
+			if got := cfg.Scope(at); got != tt.wantScope {
+				t.Errorf("Scope() = %v, want %v", got, tt.wantScope)
+			}
+			if got := cfg.Format(at, 123); got != tt.wantNum {
+				t.Errorf("Format() = %v, want %v", got, tt.wantNum)
+			}
+		})
+	}
+}
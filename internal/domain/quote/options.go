@@ -0,0 +1,157 @@
+package quote
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrQuoteOptionNotFound = errors.New("quote option not found")
+	ErrOptionRequired      = errors.New("an option must be chosen for quotes with several options")
+	ErrInvalidOptionName   = errors.New("option name is required")
+	ErrDuplicateOptionName = errors.New("option names must be unique within the quote")
+	ErrLastOption          = errors.New("the last option of a quote cannot be removed")
+)
+
+// DefaultOptionName nomeia a opção que recebe os itens existentes quando um
+// orçamento sem opções ganha a primeira alternativa.
+const DefaultOptionName = "Opção 1"
+
+func (req *CreateOptionDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidOptionName
+	}
+	return nil
+}
+
+func (req *UpdateOptionDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidOptionName
+	}
+	return nil
+}
+
+func validateOptions(options []QuoteOptionDTO) error {
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		name := strings.ToLower(strings.TrimSpace(option.Name))
+		if name == "" {
+			return ErrInvalidOptionName
+		}
+		if names[name] {
+			return ErrDuplicateOptionName
+		}
+		names[name] = true
+
+		if len(option.Items) == 0 {
+			return ErrInvalidItems
+		}
+	}
+	return nil
+}
+
+// ItemsOf retorna os itens que pertencem à opção.
+func ItemsOf(option *QuoteOption, items []*QuoteItem) []*QuoteItem {
+	var result []*QuoteItem
+	for _, item := range items {
+		if item.OptionID != nil && *item.OptionID == option.ID {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ActiveItems retorna os itens que valem para o orçamento: os da opção aprovada,
+// quando houver, ou todos os itens.
+func ActiveItems(q *Quote, items []*QuoteItem) []*QuoteItem {
+	if q.SelectedOptionID == nil {
+		return items
+	}
+	return ItemsOf(&QuoteOption{ID: *q.SelectedOptionID}, items)
+}
+
+// FindOption busca a opção pelo ID.
+func FindOption(options []*QuoteOption, id string) (*QuoteOption, error) {
+	for _, option := range options {
+		if option.ID.String() == id {
+			return option, nil
+		}
+	}
+	return nil, ErrQuoteOptionNotFound
+}
+
+// ResolveApprovedOption define qual opção é aprovada. Orçamentos sem opções não
+// aceitam option_id; com uma única opção ela é escolhida automaticamente.
+func ResolveApprovedOption(options []*QuoteOption, optionID string) (*QuoteOption, error) {
+	if len(options) == 0 {
+		if optionID != "" {
+			return nil, ErrQuoteOptionNotFound
+		}
+		return nil, nil
+	}
+	if optionID == "" {
+		if len(options) == 1 {
+			return options[0], nil
+		}
+		return nil, ErrOptionRequired
+	}
+	return FindOption(options, optionID)
+}
+
+// CalculateOptionTotals calcula subtotal, total e desconto efetivo de cada opção,
+// aplicando o desconto do orçamento a todas elas.
+func CalculateOptionTotals(q *Quote, options []*QuoteOption, items []*QuoteItem) error {
+	for _, option := range options {
+		subtotal, itemDiscounts, cost := 0.0, 0.0, 0.0
+		for _, item := range ItemsOf(option, items) {
+			subtotal += item.Total
+			itemDiscounts += item.DiscountAmount
+			cost += item.Cost
+		}
+		option.Subtotal = roundTo(subtotal, 2)
+
+		discount, err := DiscountAmount(q.DiscountType, q.Discount, option.Subtotal)
+		if err != nil {
+			return err
+		}
+		option.Discount = discount
+		option.TotalValue = roundTo(option.Subtotal-discount, 2)
+		option.DiscountPercent = discountPercent(itemDiscounts+discount, option.Subtotal+itemDiscounts)
+		option.CostTotal, option.Margin, option.MarginPercent = margin(option.TotalValue, cost)
+	}
+	return nil
+}
+
+// CalculateQuoteTotals recalcula os totais do orçamento. Com opções, o orçamento
+// assume os valores da opção aprovada ou, antes da aprovação, da primeira opção.
+func CalculateQuoteTotals(q *Quote, options []*QuoteOption, items []*QuoteItem) error {
+	if len(options) == 0 {
+		return CalculateTotals(q, items)
+	}
+
+	if err := CalculateOptionTotals(q, options, items); err != nil {
+		return err
+	}
+
+	reference := options[0]
+	if q.SelectedOptionID != nil {
+		if selected, err := FindOption(options, q.SelectedOptionID.String()); err == nil {
+			reference = selected
+		}
+	}
+
+	if err := CalculateTotals(q, ItemsOf(reference, items)); err != nil {
+		return err
+	}
+
+	// Antes da aprovação qualquer opção pode ser escolhida, então a política de
+	// descontos considera a opção com o maior desconto
+	if q.SelectedOptionID == nil {
+		for _, option := range options {
+			if option.DiscountPercent > q.DiscountPercent {
+				q.DiscountPercent = option.DiscountPercent
+			}
+		}
+	}
+	return nil
+}
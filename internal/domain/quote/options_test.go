@@ -0,0 +1,110 @@
+package quote
+
+import (
+	"testing"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+func TestResolveApprovedOption(t *testing.T) {
+	granito := &QuoteOption{ID: "opt-1", Name: "Granito"}
+	quartzo := &QuoteOption{ID: "opt-2", Name: "Quartzo"}
+
+	tests := []struct {
+		name     string
+		options  []*QuoteOption
+		optionID string
+		want     *QuoteOption
+		wantErr  error
+	}{
+		{"no options", nil, "", nil, nil},
+		{"no options with option_id", nil, "opt-1", nil, ErrQuoteOptionNotFound},
+		{"single option is chosen automatically", []*QuoteOption{granito}, "", granito, nil},
+		{"several options need a choice", []*QuoteOption{granito, quartzo}, "", nil, ErrOptionRequired},
+		{"chosen option", []*QuoteOption{granito, quartzo}, "opt-2", quartzo, nil},
+		{"unknown option", []*QuoteOption{granito, quartzo}, "opt-9", nil, ErrQuoteOptionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveApprovedOption(tt.options, tt.optionID)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("option = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateQuoteTotals_Options(t *testing.T) {
+	opt1, opt2 := dbtypes.UUID("opt-1"), dbtypes.UUID("opt-2")
+	options := []*QuoteOption{{ID: opt1, Name: "Granito"}, {ID: opt2, Name: "Quartzo"}}
+	items := []*QuoteItem{
+		{OptionID: &opt1, Total: 800},
+		{OptionID: &opt1, Total: 200},
+		{OptionID: &opt2, Total: 1500},
+	}
+	q := &Quote{Discount: 100}
+
+	if err := CalculateQuoteTotals(q, options, items); err != nil {
+		t.Fatalf("CalculateQuoteTotals() error = %v", err)
+	}
+
+	if options[0].Subtotal != 1000 || options[0].TotalValue != 900 {
+		t.Errorf("option 1 totals = %v/%v, want 1000/900", options[0].Subtotal, options[0].TotalValue)
+	}
+	if options[1].Subtotal != 1500 || options[1].TotalValue != 1400 {
+		t.Errorf("option 2 totals = %v/%v, want 1500/1400", options[1].Subtotal, options[1].TotalValue)
+	}
+	// Antes da aprovação o orçamento assume a primeira opção
+	if q.Subtotal != 1000 || q.TotalValue != 900 {
+		t.Errorf("quote totals = %v/%v, want 1000/900", q.Subtotal, q.TotalValue)
+	}
+
+	q.SelectedOptionID = &opt2
+	if err := CalculateQuoteTotals(q, options, items); err != nil {
+		t.Fatalf("CalculateQuoteTotals() error = %v", err)
+	}
+	if q.Subtotal != 1500 || q.TotalValue != 1400 {
+		t.Errorf("quote totals after approval = %v/%v, want 1500/1400", q.Subtotal, q.TotalValue)
+	}
+	if got := ActiveItems(q, items); len(got) != 1 || got[0].Total != 1500 {
+		t.Errorf("ActiveItems() = %v, want only the selected option items", got)
+	}
+
+	// O desconto não pode passar do subtotal de nenhuma opção
+	q.Discount = 1200
+	if err := CalculateQuoteTotals(q, options, items); err != ErrInvalidDiscount {
+		t.Errorf("error = %v, want %v", err, ErrInvalidDiscount)
+	}
+}
+
+func TestCreateQuoteDTO_ValidateOptions(t *testing.T) {
+	item := QuoteItemDTO{ProductID: "p1", Quantity: 1}
+	base := CreateQuoteDTO{ClientID: "c1", UserID: "u1"}
+
+	tests := []struct {
+		name    string
+		options []QuoteOptionDTO
+		items   []QuoteItemDTO
+		wantErr error
+	}{
+		{"valid options", []QuoteOptionDTO{{Name: "Granito", Items: []QuoteItemDTO{item}}, {Name: "Quartzo", Items: []QuoteItemDTO{item}}}, nil, nil},
+		{"blank name", []QuoteOptionDTO{{Name: " ", Items: []QuoteItemDTO{item}}}, nil, ErrInvalidOptionName},
+		{"duplicate name", []QuoteOptionDTO{{Name: "Granito", Items: []QuoteItemDTO{item}}, {Name: "granito", Items: []QuoteItemDTO{item}}}, nil, ErrDuplicateOptionName},
+		{"option without items", []QuoteOptionDTO{{Name: "Granito"}}, nil, ErrInvalidItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			req.Options = tt.options
+			req.Items = tt.items
+			if err := req.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
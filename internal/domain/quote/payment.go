@@ -0,0 +1,254 @@
+package quote
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentMethod é uma forma de pagamento aceita no orçamento.
+type PaymentMethod string
+
+const (
+	PaymentMethodPix    PaymentMethod = "pix"
+	PaymentMethodBoleto PaymentMethod = "boleto"
+	PaymentMethodCard   PaymentMethod = "card"
+)
+
+var (
+	ErrInvalidPaymentMethod = errors.New("payment method must be pix, boleto or card")
+	ErrInvalidPaymentTerms  = errors.New("invalid payment terms")
+)
+
+// Limites das condições de pagamento
+const (
+	MaxInstallments     = 48
+	DefaultIntervalDays = 30
+)
+
+// Chaves em settings com as condições de pagamento padrão do tenant, ex.:
+// "payment_methods" = "pix,boleto,card", "payment_installments" = "3".
+const (
+	SettingPaymentMethods             = "payment_methods"
+	SettingPaymentDownPaymentPercent  = "payment_down_payment_percent"
+	SettingPaymentInstallments        = "payment_installments"
+	SettingPaymentIntervalDays        = "payment_interval_days"
+	SettingPaymentInterestRate        = "payment_interest_rate"
+	SettingPaymentCashDiscountPercent = "payment_cash_discount_percent"
+)
+
+// IsValid indica se a forma de pagamento é conhecida.
+func (m PaymentMethod) IsValid() bool {
+	return m == PaymentMethodPix || m == PaymentMethodBoleto || m == PaymentMethodCard
+}
+
+// PaymentMethods é gravada como texto separado por vírgulas (ex.: "pix,boleto").
+type PaymentMethods []PaymentMethod
+
+func (m PaymentMethods) Value() (driver.Value, error) {
+	values := make([]string, len(m))
+	for i, method := range m {
+		values[i] = string(method)
+	}
+	return strings.Join(values, ","), nil
+}
+
+func (m *PaymentMethods) Scan(value any) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("unsupported payment methods value %T", value)
+	}
+
+	*m = parsePaymentMethods(raw)
+	return nil
+}
+
+func parsePaymentMethods(raw string) PaymentMethods {
+	var methods PaymentMethods
+	for _, part := range strings.Split(raw, ",") {
+		if method := PaymentMethod(strings.ToLower(strings.TrimSpace(part))); method != "" {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// PaymentTerms são as condições de pagamento do orçamento.
+//
+// Installments zero significa pagamento à vista, com o desconto à vista. Com
+// parcelas, a entrada (percentual do total) é paga no fechamento e o saldo é
+// dividido em parcelas a cada IntervalDays, com juros compostos mensais
+// (tabela Price) quando InterestRate é informado.
+type PaymentTerms struct {
+	Methods             PaymentMethods `json:"methods" gorm:"type:varchar(60)"`
+	DownPaymentPercent  float64        `json:"down_payment_percent"`
+	Installments        int            `json:"installments"`
+	IntervalDays        int            `json:"interval_days"`
+	InterestRate        float64        `json:"interest_rate"`         // % ao mês
+	CashDiscountPercent float64        `json:"cash_discount_percent"` // desconto para pagamento à vista
+}
+
+func (t *PaymentTerms) Validate() error {
+	for _, method := range t.Methods {
+		if !method.IsValid() {
+			return ErrInvalidPaymentMethod
+		}
+	}
+	if t.Installments < 0 || t.Installments > MaxInstallments {
+		return fmt.Errorf("%w: installments must be between 0 and %d", ErrInvalidPaymentTerms, MaxInstallments)
+	}
+	if t.DownPaymentPercent < 0 || t.DownPaymentPercent >= 100 {
+		return fmt.Errorf("%w: down_payment_percent must be between 0 and 100", ErrInvalidPaymentTerms)
+	}
+	if t.IntervalDays < 0 || t.IntervalDays > 365 {
+		return fmt.Errorf("%w: interval_days must be between 0 and 365", ErrInvalidPaymentTerms)
+	}
+	if t.InterestRate < 0 || t.InterestRate > 100 {
+		return fmt.Errorf("%w: interest_rate must be between 0 and 100", ErrInvalidPaymentTerms)
+	}
+	if t.CashDiscountPercent < 0 || t.CashDiscountPercent > 100 {
+		return fmt.Errorf("%w: cash_discount_percent must be between 0 and 100", ErrInvalidPaymentTerms)
+	}
+	return nil
+}
+
+// PaymentTermsFromSettings monta as condições padrão a partir das settings do tenant.
+// Valores ausentes ou inválidos são ignorados.
+func PaymentTermsFromSettings(settings map[string]string) PaymentTerms {
+	terms := PaymentTerms{IntervalDays: DefaultIntervalDays}
+
+	for _, method := range parsePaymentMethods(settings[SettingPaymentMethods]) {
+		if method.IsValid() {
+			terms.Methods = append(terms.Methods, method)
+		}
+	}
+
+	if v, ok := settingFloat(settings, SettingPaymentDownPaymentPercent); ok && v < 100 {
+		terms.DownPaymentPercent = v
+	}
+	if v, err := strconv.Atoi(strings.TrimSpace(settings[SettingPaymentInstallments])); err == nil && v >= 0 && v <= MaxInstallments {
+		terms.Installments = v
+	}
+	if v, err := strconv.Atoi(strings.TrimSpace(settings[SettingPaymentIntervalDays])); err == nil && v > 0 && v <= 365 {
+		terms.IntervalDays = v
+	}
+	if v, ok := settingFloat(settings, SettingPaymentInterestRate); ok && v <= 100 {
+		terms.InterestRate = v
+	}
+	if v, ok := settingFloat(settings, SettingPaymentCashDiscountPercent); ok && v <= 100 {
+		terms.CashDiscountPercent = v
+	}
+
+	return terms
+}
+
+func settingFloat(settings map[string]string, key string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(settings[key]), 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// Installment é um pagamento do cronograma; Number zero é a entrada.
+type Installment struct {
+	Number    int        `json:"number"`
+	DueInDays int        `json:"due_in_days"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	Amount    float64    `json:"amount"`
+}
+
+// PaymentPlan é o cronograma de pagamento calculado a partir das condições.
+type PaymentPlan struct {
+	Methods      PaymentMethods `json:"methods"`
+	BaseValue    float64        `json:"base_value"` // total do orçamento
+	CashDiscount float64        `json:"cash_discount,omitempty"`
+	DownPayment  float64        `json:"down_payment,omitempty"`
+	Interest     float64        `json:"interest,omitempty"`
+	TotalValue   float64        `json:"total_value"` // valor final a pagar
+	Schedule     []Installment  `json:"schedule"`
+}
+
+// BuildPaymentPlan calcula o cronograma para o valor total. Com start (data de
+// aprovação), cada pagamento recebe também a data de vencimento.
+func BuildPaymentPlan(terms PaymentTerms, total float64, start *time.Time) *PaymentPlan {
+	plan := &PaymentPlan{Methods: terms.Methods, BaseValue: total}
+	if plan.Methods == nil {
+		plan.Methods = PaymentMethods{}
+	}
+
+	// À vista: um único pagamento no fechamento
+	if terms.Installments == 0 {
+		plan.CashDiscount = roundTo(total*terms.CashDiscountPercent/100, 2)
+		plan.TotalValue = roundTo(total-plan.CashDiscount, 2)
+		plan.Schedule = []Installment{{Number: 1, Amount: plan.TotalValue}}
+		plan.setDueDates(start)
+		return plan
+	}
+
+	interval := terms.IntervalDays
+	if interval <= 0 {
+		interval = DefaultIntervalDays
+	}
+
+	plan.DownPayment = roundTo(total*terms.DownPaymentPercent/100, 2)
+	if plan.DownPayment > 0 {
+		plan.Schedule = append(plan.Schedule, Installment{Number: 0, Amount: plan.DownPayment})
+	}
+
+	financed := roundTo(total-plan.DownPayment, 2)
+	n := terms.Installments
+	amounts := make([]float64, n)
+
+	if rate := terms.InterestRate / 100; rate > 0 {
+		// Tabela Price: parcelas iguais com juros compostos sobre o saldo
+		pmt := roundTo(financed*rate/(1-math.Pow(1+rate, -float64(n))), 2)
+		for i := range amounts {
+			amounts[i] = pmt
+		}
+	} else {
+		// Sem juros: a última parcela absorve a diferença de centavos
+		each := math.Floor(financed/float64(n)*100) / 100
+		for i := range amounts {
+			amounts[i] = each
+		}
+		amounts[n-1] = roundTo(financed-each*float64(n-1), 2)
+	}
+
+	sum := 0.0
+	for i, amount := range amounts {
+		sum += amount
+		plan.Schedule = append(plan.Schedule, Installment{
+			Number:    i + 1,
+			DueInDays: (i + 1) * interval,
+			Amount:    amount,
+		})
+	}
+
+	plan.TotalValue = roundTo(plan.DownPayment+sum, 2)
+	plan.Interest = roundTo(plan.TotalValue-total, 2)
+	plan.setDueDates(start)
+	return plan
+}
+
+func (p *PaymentPlan) setDueDates(start *time.Time) {
+	if start == nil {
+		return
+	}
+	for i := range p.Schedule {
+		due := start.AddDate(0, 0, p.Schedule[i].DueInDays)
+		p.Schedule[i].DueDate = &due
+	}
+}
@@ -0,0 +1,124 @@
+package quote
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuildPaymentPlan_Cash(t *testing.T) {
+	plan := BuildPaymentPlan(PaymentTerms{CashDiscountPercent: 5}, 2000, nil)
+
+	if plan.CashDiscount != 100 || plan.TotalValue != 1900 {
+		t.Errorf("cash discount/total = %v/%v, want 100/1900", plan.CashDiscount, plan.TotalValue)
+	}
+	if len(plan.Schedule) != 1 || plan.Schedule[0].Amount != 1900 || plan.Schedule[0].DueDate != nil {
+		t.Errorf("unexpected schedule %+v", plan.Schedule)
+	}
+}
+
+func TestBuildPaymentPlan_InstallmentsWithoutInterest(t *testing.T) {
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	terms := PaymentTerms{DownPaymentPercent: 30, Installments: 3, IntervalDays: 30, CashDiscountPercent: 5}
+
+	plan := BuildPaymentPlan(terms, 1000, &start)
+
+	// Entrada de 300 e 700 em 3x: 233,33 + 233,33 + 233,34
+	want := []float64{300, 233.33, 233.33, 233.34}
+	if len(plan.Schedule) != len(want) {
+		t.Fatalf("schedule has %d payments, want %d", len(plan.Schedule), len(want))
+	}
+	for i, amount := range want {
+		if plan.Schedule[i].Amount != amount {
+			t.Errorf("payment %d = %v, want %v", i, plan.Schedule[i].Amount, amount)
+		}
+	}
+	if plan.TotalValue != 1000 || plan.Interest != 0 || plan.CashDiscount != 0 {
+		t.Errorf("total/interest/cash discount = %v/%v/%v, want 1000/0/0", plan.TotalValue, plan.Interest, plan.CashDiscount)
+	}
+
+	if plan.Schedule[0].Number != 0 || !plan.Schedule[0].DueDate.Equal(start) {
+		t.Errorf("down payment must be due on approval, got %+v", plan.Schedule[0])
+	}
+	if due := plan.Schedule[3].DueDate; due == nil || !due.Equal(start.AddDate(0, 0, 90)) {
+		t.Errorf("last installment due %v, want %v", due, start.AddDate(0, 0, 90))
+	}
+}
+
+func TestBuildPaymentPlan_InstallmentsWithInterest(t *testing.T) {
+	plan := BuildPaymentPlan(PaymentTerms{Installments: 12, InterestRate: 2}, 1000, nil)
+
+	// Tabela Price: 1000 a 2% a.m. em 12x
+	for _, installment := range plan.Schedule {
+		if installment.Amount != 94.56 {
+			t.Fatalf("installment = %v, want 94.56", installment.Amount)
+		}
+		if installment.DueInDays != installment.Number*DefaultIntervalDays {
+			t.Errorf("installment %d due in %d days", installment.Number, installment.DueInDays)
+		}
+	}
+	if plan.TotalValue != 1134.72 || plan.Interest != 134.72 {
+		t.Errorf("total/interest = %v/%v, want 1134.72/134.72", plan.TotalValue, plan.Interest)
+	}
+}
+
+func TestPaymentTermsFromSettings(t *testing.T) {
+	terms := PaymentTermsFromSettings(map[string]string{
+		SettingPaymentMethods:             "PIX, boleto,cheque",
+		SettingPaymentDownPaymentPercent:  "20",
+		SettingPaymentInstallments:        "6",
+		SettingPaymentInterestRate:        "abc",
+		SettingPaymentCashDiscountPercent: "5",
+	})
+
+	if len(terms.Methods) != 2 || terms.Methods[0] != PaymentMethodPix || terms.Methods[1] != PaymentMethodBoleto {
+		t.Errorf("Methods = %v, want [pix boleto]", terms.Methods)
+	}
+	if terms.DownPaymentPercent != 20 || terms.Installments != 6 || terms.CashDiscountPercent != 5 {
+		t.Errorf("unexpected terms %+v", terms)
+	}
+	if terms.InterestRate != 0 || terms.IntervalDays != DefaultIntervalDays {
+		t.Errorf("invalid or missing values must fall back to defaults, got %+v", terms)
+	}
+}
+
+func TestPaymentTerms_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   PaymentTerms
+		wantErr error
+	}{
+		{"valid", PaymentTerms{Methods: PaymentMethods{PaymentMethodCard}, Installments: 10, InterestRate: 1.99}, nil},
+		{"unknown method", PaymentTerms{Methods: PaymentMethods{"cheque"}}, ErrInvalidPaymentMethod},
+		{"too many installments", PaymentTerms{Installments: MaxInstallments + 1}, ErrInvalidPaymentTerms},
+		{"full down payment", PaymentTerms{DownPaymentPercent: 100, Installments: 2}, ErrInvalidPaymentTerms},
+		{"negative interest", PaymentTerms{InterestRate: -1}, ErrInvalidPaymentTerms},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.terms.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentMethods_ValueScan(t *testing.T) {
+	value, err := PaymentMethods{PaymentMethodPix, PaymentMethodCard}.Value()
+	if err != nil || value != "pix,card" {
+		t.Fatalf("Value() = %v, %v", value, err)
+	}
+
+	var methods PaymentMethods
+	if err := methods.Scan([]byte("pix,card")); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(methods) != 2 || methods[1] != PaymentMethodCard {
+		t.Errorf("Scan() = %v", methods)
+	}
+
+	if err := methods.Scan(""); err != nil || len(methods) != 0 {
+		t.Errorf("Scan(\"\") = %v, %v", methods, err)
+	}
+}
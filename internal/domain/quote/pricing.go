@@ -0,0 +1,242 @@
+package quote
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+)
+
+var (
+	ErrInvalidDimensions = errors.New("invalid item dimensions")
+	ErrInvalidQuantity   = errors.New("item quantity must be greater than zero")
+	ErrInvalidDiscount   = errors.New("invalid discount")
+	ErrInvalidEdgeSides  = errors.New("edge_sides must list front, back, left or right")
+)
+
+// Lados da peça que podem receber borda acabada. Frente e fundo correm ao longo
+// da largura; esquerda e direita, ao longo da altura.
+const (
+	EdgeSideFront = "front"
+	EdgeSideBack  = "back"
+	EdgeSideLeft  = "left"
+	EdgeSideRight = "right"
+)
+
+var edgeSideOrder = []string{EdgeSideFront, EdgeSideBack, EdgeSideLeft, EdgeSideRight}
+
+// Chaves em settings usadas pelo motor de precificação.
+//
+// Os adicionais de borda são cadastrados por tipo de acabamento, ex.:
+// "edge_surcharge_reto" = "35.00" (R$ por metro linear de borda).
+const (
+	SettingEdgeSurchargePrefix = "edge_surcharge_"
+	SettingCutoutSurcharge     = "cutout_surcharge"
+)
+
+// PricingConfig reúne os adicionais de acabamento configurados por tenant.
+type PricingConfig struct {
+	// EdgeSurcharges é o valor por metro linear de borda, indexado pelo tipo de borda.
+	EdgeSurcharges map[string]float64
+	// CutoutSurcharge é o valor cobrado por peça com recorte (cuba, cooktop etc.).
+	CutoutSurcharge float64
+}
+
+// PricingConfigFromSettings monta a configuração de preços a partir das settings do tenant.
+// Valores ausentes ou inválidos são ignorados.
+func PricingConfigFromSettings(settings map[string]string) PricingConfig {
+	cfg := PricingConfig{EdgeSurcharges: make(map[string]float64)}
+
+	for key, value := range settings {
+		amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || amount < 0 {
+			continue
+		}
+
+		switch {
+		case key == SettingCutoutSurcharge:
+			cfg.CutoutSurcharge = amount
+		case strings.HasPrefix(key, SettingEdgeSurchargePrefix):
+			edgeType := normalizeEdgeType(strings.TrimPrefix(key, SettingEdgeSurchargePrefix))
+			if edgeType != "" {
+				cfg.EdgeSurcharges[edgeType] = amount
+			}
+		}
+	}
+
+	return cfg
+}
+
+// EdgeSurchargeFor retorna o adicional por metro linear para o tipo de borda informado.
+func (cfg PricingConfig) EdgeSurchargeFor(edgeType string) float64 {
+	if cfg.EdgeSurcharges == nil {
+		return 0
+	}
+	return cfg.EdgeSurcharges[normalizeEdgeType(edgeType)]
+}
+
+// NormalizeEdgeSides valida a lista de lados com borda (ex.: "front,left") e a
+// devolve sem repetições, na ordem frente, fundo, esquerda e direita.
+func NormalizeEdgeSides(sides string) (string, error) {
+	chosen := make(map[string]bool)
+	for _, side := range strings.Split(sides, ",") {
+		side = strings.ToLower(strings.TrimSpace(side))
+		if side == "" {
+			continue
+		}
+		if !slices.Contains(edgeSideOrder, side) {
+			return "", ErrInvalidEdgeSides
+		}
+		chosen[side] = true
+	}
+
+	normalized := make([]string, 0, len(chosen))
+	for _, side := range edgeSideOrder {
+		if chosen[side] {
+			normalized = append(normalized, side)
+		}
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+// FinishedEdges lista os lados da peça que recebem borda. Itens com tipo de borda
+// e sem lados informados têm acabamento só na frente.
+func FinishedEdges(item *QuoteItem) []string {
+	if item.EdgeType == "" {
+		return nil
+	}
+	if item.EdgeSides == "" {
+		return []string{EdgeSideFront}
+	}
+	return strings.Split(item.EdgeSides, ",")
+}
+
+// finishedEdgeM é o comprimento, em metros, dos lados com borda acabada.
+func finishedEdgeM(item *QuoteItem) float64 {
+	var lengthCM float64
+	for _, side := range FinishedEdges(item) {
+		switch side {
+		case EdgeSideFront, EdgeSideBack:
+			lengthCM += item.WidthCM
+		case EdgeSideLeft, EdgeSideRight:
+			lengthCM += item.HeightCM
+		}
+	}
+	return lengthCM / 100
+}
+
+// PriceItem calcula área, adicionais e total de um item a partir das medidas
+// e do tipo de preço do produto.
+//
+//   - unit:         UnitPrice por peça
+//   - m2:           UnitPrice × área da peça (largura × altura)
+//   - linear_meter: UnitPrice × maior dimensão da peça
+//
+// O adicional de borda é cobrado só sobre os lados acabados (EdgeSides) e o de
+// recorte uma vez por peça; ambos são multiplicados pela quantidade. O desconto do item
+// é aplicado por último, sobre o total bruto. O custo usa a mesma medida do
+// preço: UnitCost × (1, área ou comprimento) × quantidade.
+func PriceItem(item *QuoteItem, priceType productDomain.PriceType, cfg PricingConfig) error {
+	if item.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if item.WidthCM < 0 || item.HeightCM < 0 || item.Thickness < 0 {
+		return ErrInvalidDimensions
+	}
+	if priceType == "" {
+		priceType = productDomain.PriceTypeUnit
+	}
+	sides, err := NormalizeEdgeSides(item.EdgeSides)
+	if err != nil {
+		return err
+	}
+	item.EdgeSides = sides
+
+	item.PriceType = priceType
+	item.AreaM2 = roundTo(item.WidthCM*item.HeightCM/10000, 4)
+
+	var measure float64
+	switch priceType {
+	case productDomain.PriceTypeSquareMeter:
+		if item.WidthCM == 0 || item.HeightCM == 0 {
+			return ErrInvalidDimensions
+		}
+		measure = item.AreaM2
+	case productDomain.PriceTypeLinearMeter:
+		measure = math.Max(item.WidthCM, item.HeightCM) / 100
+		if measure == 0 {
+			return ErrInvalidDimensions
+		}
+	case productDomain.PriceTypeUnit:
+		measure = 1
+	default:
+		return productDomain.ErrInvalidPriceType
+	}
+	base := item.UnitPrice * measure
+
+	item.EdgeSurcharge = 0
+	if item.EdgeType != "" {
+		item.EdgeSurcharge = roundTo(cfg.EdgeSurchargeFor(item.EdgeType)*finishedEdgeM(item), 2)
+	}
+
+	item.CutoutSurcharge = 0
+	if item.HasCutout {
+		item.CutoutSurcharge = roundTo(cfg.CutoutSurcharge, 2)
+	}
+
+	quantity := float64(item.Quantity)
+	gross := roundTo((base+item.EdgeSurcharge+item.CutoutSurcharge)*quantity, 2)
+
+	discount, err := DiscountAmount(item.DiscountType, item.Discount, gross)
+	if err != nil {
+		return err
+	}
+	item.DiscountAmount = discount
+	item.Total = roundTo(gross-discount, 2)
+	item.Cost = roundTo(item.UnitCost*measure*quantity, 2)
+
+	return nil
+}
+
+// CalculateTotals recalcula Subtotal, TotalValue e o desconto efetivo do orçamento
+// a partir dos itens. O Subtotal já considera os descontos dos itens.
+func CalculateTotals(q *Quote, items []*QuoteItem) error {
+	subtotal, itemDiscounts, cost := 0.0, 0.0, 0.0
+	for _, item := range items {
+		subtotal += item.Total
+		itemDiscounts += item.DiscountAmount
+		cost += item.Cost
+	}
+	q.Subtotal = roundTo(subtotal, 2)
+
+	if err := ApplyDiscount(q); err != nil {
+		return err
+	}
+	q.DiscountPercent = discountPercent(itemDiscounts+q.DiscountAmount, q.Subtotal+itemDiscounts)
+	q.CostTotal, q.Margin, q.MarginPercent = margin(q.TotalValue, cost)
+	return nil
+}
+
+// ApplyDiscount recalcula DiscountAmount e TotalValue a partir do Subtotal já calculado.
+// O desconto pode ser absoluto ou percentual (DiscountType) e não pode exceder o subtotal.
+func ApplyDiscount(q *Quote) error {
+	discount, err := DiscountAmount(q.DiscountType, q.Discount, q.Subtotal)
+	if err != nil {
+		return err
+	}
+	q.DiscountAmount = discount
+	q.TotalValue = roundTo(q.Subtotal-discount, 2)
+	return nil
+}
+
+func normalizeEdgeType(edgeType string) string {
+	return strings.ToLower(strings.TrimSpace(edgeType))
+}
+
+func roundTo(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,149 @@
+package quote
+
+import (
+	"testing"
+
+	productDomain "erp-api/internal/domain/product"
+)
+
+func TestPriceItem(t *testing.T) {
+	cfg := PricingConfig{
+		EdgeSurcharges:  map[string]float64{"reto": 20},
+		CutoutSurcharge: 150,
+	}
+
+	tests := []struct {
+		name      string
+		item      QuoteItem
+		priceType productDomain.PriceType
+		wantArea  float64
+		wantTotal float64
+		wantErr   error
+	}{
+		{
+			name:      "unit price ignores dimensions",
+			item:      QuoteItem{UnitPrice: 80, Quantity: 3},
+			priceType: productDomain.PriceTypeUnit,
+			wantTotal: 240,
+		},
+		{
+			name:      "square meter",
+			item:      QuoteItem{UnitPrice: 500, Quantity: 2, WidthCM: 200, HeightCM: 60},
+			priceType: productDomain.PriceTypeSquareMeter,
+			wantArea:  1.2,
+			wantTotal: 1200,
+		},
+		{
+			name:      "linear meter uses the longest side",
+			item:      QuoteItem{UnitPrice: 90, Quantity: 1, WidthCM: 15, HeightCM: 250},
+			priceType: productDomain.PriceTypeLinearMeter,
+			wantArea:  0.375,
+			wantTotal: 225,
+		},
+		{
+			name: "edge and cutout surcharges",
+			item: QuoteItem{
+				UnitPrice: 500, Quantity: 1, WidthCM: 100, HeightCM: 50,
+				EdgeType: "Reto", HasCutout: true,
+			},
+			priceType: productDomain.PriceTypeSquareMeter,
+			wantArea:  0.5,
+			// 0.5 m² × 500 + 1 m de frente × 20 + 150
+			wantTotal: 420,
+		},
+		{
+			name: "edge surcharge only on finished sides",
+			item: QuoteItem{
+				UnitPrice: 500, Quantity: 1, WidthCM: 100, HeightCM: 50,
+				EdgeType: "reto", EdgeSides: "front, Left,left",
+			},
+			priceType: productDomain.PriceTypeSquareMeter,
+			wantArea:  0.5,
+			// 0.5 m² × 500 + (1 m + 0.5 m) × 20
+			wantTotal: 280,
+		},
+		{
+			name:      "unknown edge side",
+			item:      QuoteItem{UnitPrice: 500, Quantity: 1, WidthCM: 100, HeightCM: 50, EdgeType: "reto", EdgeSides: "top"},
+			priceType: productDomain.PriceTypeSquareMeter,
+			wantErr:   ErrInvalidEdgeSides,
+		},
+		{
+			name:      "square meter without dimensions",
+			item:      QuoteItem{UnitPrice: 500, Quantity: 1},
+			priceType: productDomain.PriceTypeSquareMeter,
+			wantErr:   ErrInvalidDimensions,
+		},
+		{
+			name:      "zero quantity",
+			item:      QuoteItem{UnitPrice: 500},
+			priceType: productDomain.PriceTypeUnit,
+			wantErr:   ErrInvalidQuantity,
+		},
+		{
+			name:      "unknown price type",
+			item:      QuoteItem{UnitPrice: 500, Quantity: 1},
+			priceType: "box",
+			wantErr:   productDomain.ErrInvalidPriceType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			err := PriceItem(&item, tt.priceType, cfg)
+
+			if err != tt.wantErr {
+				t.Fatalf("PriceItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if item.AreaM2 != tt.wantArea {
+				t.Errorf("AreaM2 = %v, want %v", item.AreaM2, tt.wantArea)
+			}
+			if item.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", item.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestCalculateTotals(t *testing.T) {
+	items := []*QuoteItem{{Total: 1200}, {Total: 460.55}}
+
+	q := &Quote{Discount: 100}
+	if err := CalculateTotals(q, items); err != nil {
+		t.Fatalf("CalculateTotals() error = %v", err)
+	}
+	if q.Subtotal != 1660.55 {
+		t.Errorf("Subtotal = %v, want 1660.55", q.Subtotal)
+	}
+	if q.TotalValue != 1560.55 {
+		t.Errorf("TotalValue = %v, want 1560.55", q.TotalValue)
+	}
+
+	q.Discount = 5000
+	if err := CalculateTotals(q, items); err != ErrInvalidDiscount {
+		t.Errorf("expected ErrInvalidDiscount, got %v", err)
+	}
+}
+
+func TestPricingConfigFromSettings(t *testing.T) {
+	cfg := PricingConfigFromSettings(map[string]string{
+		"company_name":            "Marmoraria",
+		"cutout_surcharge":        "120.5",
+		"edge_surcharge_Boleado":  "35",
+		"edge_surcharge_invalido": "abc",
+	})
+
+	if cfg.CutoutSurcharge != 120.5 {
+		t.Errorf("CutoutSurcharge = %v, want 120.5", cfg.CutoutSurcharge)
+	}
+	if got := cfg.EdgeSurchargeFor("boleado"); got != 35 {
+		t.Errorf("EdgeSurchargeFor(boleado) = %v, want 35", got)
+	}
+	if _, ok := cfg.EdgeSurcharges["invalido"]; ok {
+		t.Errorf("expected invalid surcharge to be ignored")
+	}
+}
@@ -0,0 +1,118 @@
+package quote
+
+import "sort"
+
+// ProfitabilityStats são os indicadores de margem dos orçamentos aprovados.
+//
+// Orçamentos sem custo informado entram em WithoutCost e ficam fora de
+// receita, custo e margem, para não inflar a margem média.
+type ProfitabilityStats struct {
+	Quotes        int     `json:"quotes"`
+	Revenue       float64 `json:"revenue"`
+	Cost          float64 `json:"cost"`
+	Margin        float64 `json:"margin"`
+	MarginPercent float64 `json:"margin_percent"`
+	// BelowMinimum conta os orçamentos com margem abaixo do mínimo do tenant
+	BelowMinimum int `json:"below_minimum"`
+	WithoutCost  int `json:"without_cost"`
+}
+
+// ProfitabilityGroup são os indicadores de um vendedor, mês ou cliente
+type ProfitabilityGroup struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+	ProfitabilityStats
+}
+
+type ProfitabilityReport struct {
+	MinMarginPercent float64               `json:"min_margin_percent,omitempty"`
+	Overall          ProfitabilityStats    `json:"overall"`
+	ByUser           []*ProfitabilityGroup `json:"by_user"`
+	ByMonth          []*ProfitabilityGroup `json:"by_month"`
+	ByClient         []*ProfitabilityGroup `json:"by_client"`
+}
+
+type profitabilityAccumulator struct {
+	stats ProfitabilityStats
+}
+
+func (a *profitabilityAccumulator) add(q *Quote, policy MarginPolicy) {
+	a.stats.Quotes++
+	if !q.HasCost() {
+		a.stats.WithoutCost++
+		return
+	}
+	a.stats.Revenue += q.TotalValue
+	a.stats.Cost += q.CostTotal
+	if policy.BelowMinimum(q) {
+		a.stats.BelowMinimum++
+	}
+}
+
+func (a *profitabilityAccumulator) result() ProfitabilityStats {
+	stats := a.stats
+	stats.Cost, stats.Margin, stats.MarginPercent = margin(stats.Revenue, stats.Cost)
+	stats.Revenue = roundTo(stats.Revenue, 2)
+	return stats
+}
+
+// BuildProfitabilityReport calcula a margem bruta dos orçamentos aprovados, no
+// geral e agrupada por vendedor, mês de aprovação (YYYY-MM) e cliente.
+func BuildProfitabilityReport(quotes []*Quote, policy MarginPolicy) *ProfitabilityReport {
+	var overall profitabilityAccumulator
+	byUser := make(map[string]*profitabilityAccumulator)
+	byMonth := make(map[string]*profitabilityAccumulator)
+	byClient := make(map[string]*profitabilityAccumulator)
+
+	for _, q := range quotes {
+		if q.Status != QuoteStatusApproved || q.ApprovedAt == nil {
+			continue
+		}
+		overall.add(q, policy)
+		accumulateProfit(byUser, q.UserID.String(), q, policy)
+		accumulateProfit(byMonth, q.ApprovedAt.Format("2006-01"), q, policy)
+		accumulateProfit(byClient, q.ClientID.String(), q, policy)
+	}
+
+	report := &ProfitabilityReport{
+		MinMarginPercent: policy.MinPercent,
+		Overall:          overall.result(),
+		ByUser:           profitGroups(byUser),
+		ByMonth:          profitGroups(byMonth),
+		ByClient:         profitGroups(byClient),
+	}
+
+	// Meses em ordem cronológica; vendedores e clientes pela margem gerada
+	sort.Slice(report.ByMonth, func(i, j int) bool { return report.ByMonth[i].Key < report.ByMonth[j].Key })
+	sortByMargin(report.ByUser)
+	sortByMargin(report.ByClient)
+
+	return report
+}
+
+func accumulateProfit(groups map[string]*profitabilityAccumulator, key string, q *Quote, policy MarginPolicy) {
+	acc, ok := groups[key]
+	if !ok {
+		acc = &profitabilityAccumulator{}
+		groups[key] = acc
+	}
+	acc.add(q, policy)
+}
+
+func profitGroups(accs map[string]*profitabilityAccumulator) []*ProfitabilityGroup {
+	result := make([]*ProfitabilityGroup, 0, len(accs))
+	for key, acc := range accs {
+		result = append(result, &ProfitabilityGroup{Key: key, ProfitabilityStats: acc.result()})
+	}
+	return result
+}
+
+func sortByMargin(groups []*ProfitabilityGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Margin != b.Margin {
+			return a.Margin > b.Margin
+		}
+		return a.Key < b.Key
+	})
+}
@@ -2,14 +2,26 @@ package quote
 
 import (
 	"errors"
+	"strings"
+	"time"
 )
 
 var (
-	ErrQuoteNotFound     = errors.New("quote not found")
-	ErrQuoteAlreadyExists = errors.New("quote already exists")
-	ErrInvalidQuoteStatus = errors.New("invalid quote status")
-	ErrInvalidDate       = errors.New("invalid date")
-	ErrInvalidItems      = errors.New("quote must have at least one item")
+	ErrQuoteNotFound           = errors.New("quote not found")
+	ErrQuoteAlreadyExists      = errors.New("quote already exists")
+	ErrInvalidQuoteStatus      = errors.New("invalid quote status")
+	ErrInvalidDate             = errors.New("invalid date")
+	ErrInvalidItems            = errors.New("quote must have at least one item")
+	ErrQuoteItemNotFound       = errors.New("quote item not found")
+	ErrQuoteNotEditable        = errors.New("quote can only be edited while pending or awaiting approval")
+	ErrInvalidStatusTransition = errors.New("invalid quote status transition")
+	ErrStatusChangeNotAllowed  = errors.New("quote status must be changed through the status endpoint")
+	ErrInvalidDecision         = errors.New("decision must be approved or rejected")
+	ErrQuoteExpired            = errors.New("quote validity has expired")
+	ErrQuoteNotRenewable       = errors.New("only pending, awaiting approval or expired quotes can be renewed")
+	ErrQuoteNotApproved        = errors.New("quote must be approved")
+	ErrQuoteHasOrder           = errors.New("quote already has a sales order")
+	ErrQuoteInProduction       = errors.New("quote already has work orders in production")
 )
 
 func (req *CreateQuoteDTO) Validate() error {
@@ -19,22 +31,82 @@ func (req *CreateQuoteDTO) Validate() error {
 	if req.UserID == "" {
 		return errors.New("user_id is required")
 	}
-	if len(req.Items) == 0 {
+	if len(req.Options) > 0 {
+		if len(req.Items) > 0 {
+			return errors.New("items must be sent inside the options")
+		}
+		if err := validateOptions(req.Options); err != nil {
+			return err
+		}
+	} else if len(req.Items) == 0 {
 		return ErrInvalidItems
 	}
-	if req.Status != "" {
-		if req.Status != QuoteStatusPending && req.Status != QuoteStatusApproved && 
-		   req.Status != QuoteStatusRejected && req.Status != QuoteStatusCancelled {
-			return ErrInvalidQuoteStatus
-		}
+	if !req.DiscountType.IsValid() {
+		return ErrInvalidDiscountType
+	}
+	if req.Discount < 0 {
+		return ErrInvalidDiscount
+	}
+	// Todo orçamento nasce pendente; aprovação, recusa e cancelamento passam
+	// pelas transições de status
+	if req.Status != "" && req.Status != QuoteStatusPending {
+		return ErrInvalidQuoteStatus
+	}
+	if req.ValidUntil != nil && req.ValidUntil.Before(time.Now()) {
+		return ErrInvalidDate
+	}
+	if req.PaymentTerms != nil {
+		return req.PaymentTerms.Validate()
 	}
 	return nil
 }
 
 func (req *UpdateQuoteStatusDTO) Validate() error {
-	if req.Status != QuoteStatusPending && req.Status != QuoteStatusApproved && 
-	   req.Status != QuoteStatusRejected && req.Status != QuoteStatusCancelled {
+	if !req.Status.IsValid() {
 		return ErrInvalidQuoteStatus
 	}
 	return nil
-} 
\ No newline at end of file
+}
+
+func (req *ClientDecisionDTO) Validate() error {
+	if req.Decision != QuoteStatusApproved && req.Decision != QuoteStatusRejected {
+		return ErrInvalidDecision
+	}
+	return nil
+}
+
+func (req *UpdateQuoteItemDTO) Validate() error {
+	if req.Quantity != nil && *req.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if req.Price != nil && *req.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	if req.Discount != nil && *req.Discount < 0 {
+		return ErrInvalidDiscount
+	}
+	if req.DiscountType != nil && !req.DiscountType.IsValid() {
+		return ErrInvalidDiscountType
+	}
+	if req.Description != nil && strings.TrimSpace(*req.Description) == "" {
+		return ErrInvalidServiceItem
+	}
+	return nil
+}
+
+func (req *DuplicateQuoteDTO) Validate() error {
+	if req.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+	if req.ValidUntil != nil && req.ValidUntil.Before(time.Now()) {
+		return ErrInvalidDate
+	}
+	return nil
+}
+
+func (req *RenewQuoteDTO) Validate() error {
+	if req.ValidUntil != nil && req.ValidUntil.Before(time.Now()) {
+		return ErrInvalidDate
+	}
+	return nil
+}
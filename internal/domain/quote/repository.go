@@ -1,19 +1,58 @@
 package quote
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
 	Create(ctx context.Context, quote *Quote) error
 	GetByID(ctx context.Context, tenantID, id string) (*Quote, error)
+	// GetForUpdate lê o orçamento travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Quote, error)
+	// Update grava o orçamento sem mexer em status e approved_at, que mudam por UpdateStatus
 	Update(ctx context.Context, quote *Quote) error
 	Delete(ctx context.Context, tenantID, id string) error
-	List(ctx context.Context, tenantID string, limit, offset int) ([]*Quote, error)
-	Count(ctx context.Context, tenantID string) (int, error)
-	UpdateStatus(ctx context.Context, tenantID, id string, status QuoteStatus) error
+	List(ctx context.Context, tenantID string, filter ListFilter, limit, offset int) ([]*Quote, error)
+	Count(ctx context.Context, tenantID string, filter ListFilter) (int, error)
+	// ListForAnalytics retorna, sem paginação, os campos usados nos indicadores de conversão
+	ListForAnalytics(ctx context.Context, tenantID string, filter ListFilter) ([]*Quote, error)
+	// UpdateStatus só altera o orçamento que ainda está em from; se outra
+	// transição chegou antes, retorna ErrInvalidStatusTransition
+	UpdateStatus(ctx context.Context, tenantID, id string, from, status QuoteStatus, approvedAt *time.Time) error
+	// ApproveDiscount grava só as colunas da aprovação do desconto, sem desfazer
+	// uma edição concorrente do desconto ou dos totais
+	ApproveDiscount(ctx context.Context, quote *Quote) error
+	// ListOverdue busca, em todos os tenants, orçamentos pendentes com validade vencida antes de now
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]*Quote, error)
 }
 
 type ItemRepository interface {
 	Create(ctx context.Context, item *QuoteItem) error
+	GetByID(ctx context.Context, quoteID, id string) (*QuoteItem, error)
 	GetByQuoteID(ctx context.Context, quoteID string) ([]*QuoteItem, error)
+	Update(ctx context.Context, item *QuoteItem) error
+	Delete(ctx context.Context, quoteID, id string) error
 	DeleteByQuoteID(ctx context.Context, quoteID string) error
-} 
\ No newline at end of file
+	DeleteByOptionID(ctx context.Context, quoteID, optionID string) error
+}
+
+type OptionRepository interface {
+	Create(ctx context.Context, option *QuoteOption) error
+	GetByID(ctx context.Context, quoteID, id string) (*QuoteOption, error)
+	ListByQuoteID(ctx context.Context, quoteID string) ([]*QuoteOption, error)
+	Update(ctx context.Context, option *QuoteOption) error
+	Delete(ctx context.Context, quoteID, id string) error
+}
+
+type StatusHistoryRepository interface {
+	Create(ctx context.Context, history *QuoteStatusHistory) error
+	ListByQuoteID(ctx context.Context, tenantID, quoteID string) ([]*QuoteStatusHistory, error)
+}
+
+type SequenceRepository interface {
+	// Next reserva o próximo número da sequência. Deve rodar dentro da mesma
+	// transação que grava o orçamento: a linha fica bloqueada até o commit e um
+	// rollback devolve o número, mantendo a sequência sem lacunas.
+	Next(ctx context.Context, tenantID, scope string) (int64, error)
+}
@@ -0,0 +1,74 @@
+package quote
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+)
+
+// SettingBlockOversell é a chave em settings que impede a aprovação de orçamentos
+// sem saldo disponível para reservar o material. A trava vem ligada; o tenant
+// pode desligá-la gravando "false".
+const SettingBlockOversell = "stock_block_oversell"
+
+// BlockOversellFromSettings lê a trava de venda sem saldo das settings do tenant.
+// Valores ausentes ou inválidos mantêm a trava ligada.
+func BlockOversellFromSettings(settings map[string]string) bool {
+	block, err := strconv.ParseBool(strings.TrimSpace(settings[SettingBlockOversell]))
+	return err != nil || block
+}
+
+// ReleasesStock indica se o orçamento, ao entrar no status, devolve o material
+// reservado para o estoque disponível.
+func (s QuoteStatus) ReleasesStock() bool {
+	return s == QuoteStatusCancelled || s == QuoteStatusRejected || s == QuoteStatusExpired
+}
+
+// SlabNeeds lista as chapas escolhidas nos itens ativos do orçamento, com o
+// produto que cada uma precisa ter.
+func SlabNeeds(q *Quote, items []*QuoteItem) map[string]string {
+	slabs := make(map[string]string)
+	for _, item := range ProductItems(ActiveItems(q, items)) {
+		if item.SlabID == nil || item.ProductID == nil {
+			continue
+		}
+		slabs[item.SlabID.String()] = item.ProductID.String()
+	}
+	return slabs
+}
+
+// MaterialNeeds soma, por produto, o material dos itens ativos do orçamento na
+// unidade de estoque do produto: peças, m² ou metros lineares, conforme o tipo de
+// preço copiado para o item. O total de cada produto é arredondado para cima.
+// Serviços, frete e peças com chapa escolhida (reservada por SlabNeeds) ficam de fora.
+func MaterialNeeds(q *Quote, items []*QuoteItem) map[string]int {
+	amounts := make(map[string]float64)
+	for _, item := range ProductItems(ActiveItems(q, items)) {
+		if item.ProductID == nil || item.SlabID != nil || item.Quantity <= 0 {
+			continue
+		}
+		amounts[item.ProductID.String()] += stockMeasure(item) * float64(item.Quantity)
+	}
+
+	needs := make(map[string]int, len(amounts))
+	for productID, amount := range amounts {
+		if amount > 0 {
+			needs[productID] = int(math.Ceil(roundTo(amount, 4)))
+		}
+	}
+	return needs
+}
+
+// stockMeasure é quanto uma peça do item consome do estoque do produto.
+func stockMeasure(item *QuoteItem) float64 {
+	switch item.PriceType {
+	case productDomain.PriceTypeSquareMeter:
+		return item.WidthCM * item.HeightCM / 10000
+	case productDomain.PriceTypeLinearMeter:
+		return math.Max(item.WidthCM, item.HeightCM) / 100
+	default:
+		return 1
+	}
+}
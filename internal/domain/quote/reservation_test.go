@@ -0,0 +1,76 @@
+package quote
+
+import (
+	"testing"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+func TestMaterialNeeds(t *testing.T) {
+	granito := dbtypes.UUID("prod-1")
+	quartzo := dbtypes.UUID("prod-2")
+	optA := dbtypes.UUID("opt-a")
+	optB := dbtypes.UUID("opt-b")
+
+	items := []*QuoteItem{
+		{Kind: ItemKindProduct, ProductID: &granito, Quantity: 2},
+		{Kind: ItemKindProduct, ProductID: &granito, Quantity: 1},
+		{Kind: ItemKindService, Quantity: 1},
+		{Kind: ItemKindFreight, Quantity: 1},
+		{Kind: ItemKindProduct, ProductID: &quartzo, Quantity: 4, OptionID: &optA},
+		{Kind: ItemKindProduct, ProductID: &granito, Quantity: 5, OptionID: &optB},
+	}
+
+	needs := MaterialNeeds(&Quote{SelectedOptionID: &optA}, items)
+	if len(needs) != 1 || needs["prod-2"] != 4 {
+		t.Errorf("needs with selected option = %v, want prod-2:4", needs)
+	}
+
+	needs = MaterialNeeds(&Quote{}, items[:4])
+	if len(needs) != 1 || needs["prod-1"] != 3 {
+		t.Errorf("needs without options = %v, want prod-1:3", needs)
+	}
+}
+
+func TestMaterialNeeds_StockUnit(t *testing.T) {
+	marmore := dbtypes.UUID("prod-1")
+	rodape := dbtypes.UUID("prod-2")
+	slab := dbtypes.UUID("slab-1")
+
+	items := []*QuoteItem{
+		{Kind: ItemKindProduct, ProductID: &marmore, PriceType: "m2", WidthCM: 120, HeightCM: 60, Quantity: 2},
+		{Kind: ItemKindProduct, ProductID: &marmore, PriceType: "m2", WidthCM: 50, HeightCM: 50, Quantity: 1},
+		{Kind: ItemKindProduct, ProductID: &rodape, PriceType: "linear_meter", WidthCM: 250, HeightCM: 10, Quantity: 2},
+		{Kind: ItemKindProduct, ProductID: &marmore, PriceType: "m2", WidthCM: 300, HeightCM: 180, Quantity: 1, SlabID: &slab},
+	}
+
+	needs := MaterialNeeds(&Quote{}, items)
+	// 1,44 + 0,25 = 1,69 m² sobem para 2; a peça com chapa já está reservada.
+	if len(needs) != 2 || needs["prod-1"] != 2 || needs["prod-2"] != 5 {
+		t.Errorf("needs = %v, want prod-1:2 prod-2:5", needs)
+	}
+}
+
+func TestQuoteStatus_ReleasesStock(t *testing.T) {
+	for status, want := range map[QuoteStatus]bool{
+		QuoteStatusPending:          false,
+		QuoteStatusAwaitingApproval: false,
+		QuoteStatusApproved:         false,
+		QuoteStatusRejected:         true,
+		QuoteStatusCancelled:        true,
+		QuoteStatusExpired:          true,
+	} {
+		if got := status.ReleasesStock(); got != want {
+			t.Errorf("%s.ReleasesStock() = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestBlockOversellFromSettings(t *testing.T) {
+	tests := map[string]bool{"": true, "true": true, " 1 ": true, "false": false, " 0 ": false, "sim": true}
+	for value, want := range tests {
+		if got := BlockOversellFromSettings(map[string]string{SettingBlockOversell: value}); got != want {
+			t.Errorf("BlockOversellFromSettings(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
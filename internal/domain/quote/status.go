@@ -0,0 +1,44 @@
+package quote
+
+// allowedTransitions define o fluxo de status do orçamento.
+//
+// pending           -> approved | rejected | cancelled | expired
+// awaiting_approval -> cancelled | expired
+// approved          -> cancelled
+// rejected          -> pending (reabertura para renegociação)
+// expired           -> cancelled
+// cancelled         -> (final)
+//
+// A entrada e a saída de awaiting_approval não passam por aqui: são feitas pela
+// política de descontos e pela aprovação do gerente. Da mesma forma, expirado
+// só volta a pendente pela renovação, que atualiza preços e validade.
+var allowedTransitions = map[QuoteStatus][]QuoteStatus{
+	QuoteStatusPending:          {QuoteStatusApproved, QuoteStatusRejected, QuoteStatusCancelled, QuoteStatusExpired},
+	QuoteStatusAwaitingApproval: {QuoteStatusCancelled, QuoteStatusExpired},
+	QuoteStatusApproved:         {QuoteStatusCancelled},
+	QuoteStatusRejected:         {QuoteStatusPending},
+	QuoteStatusExpired:          {QuoteStatusCancelled},
+	QuoteStatusCancelled:        {},
+}
+
+// IsValid indica se o status é conhecido.
+func (s QuoteStatus) IsValid() bool {
+	_, ok := allowedTransitions[s]
+	return ok
+}
+
+// CanTransitionTo indica se o orçamento pode sair do status atual para o próximo.
+func (s QuoteStatus) CanTransitionTo(next QuoteStatus) bool {
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEditable indica se itens e valores do orçamento ainda podem ser alterados.
+// Aguardando aprovação continua editável para que o desconto possa ser reduzido.
+func (s QuoteStatus) IsEditable() bool {
+	return s == QuoteStatusPending || s == QuoteStatusAwaitingApproval
+}
@@ -0,0 +1,84 @@
+package quote
+
+import "testing"
+
+func TestQuoteStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from QuoteStatus
+		to   QuoteStatus
+		want bool
+	}{
+		{QuoteStatusPending, QuoteStatusApproved, true},
+		{QuoteStatusPending, QuoteStatusRejected, true},
+		{QuoteStatusPending, QuoteStatusCancelled, true},
+		{QuoteStatusApproved, QuoteStatusCancelled, true},
+		{QuoteStatusApproved, QuoteStatusPending, false},
+		{QuoteStatusRejected, QuoteStatusPending, true},
+		{QuoteStatusRejected, QuoteStatusApproved, false},
+		{QuoteStatusCancelled, QuoteStatusApproved, false},
+		{QuoteStatusCancelled, QuoteStatusPending, false},
+		{QuoteStatusPending, QuoteStatusPending, false},
+		{QuoteStatusPending, QuoteStatusExpired, true},
+		{QuoteStatusExpired, QuoteStatusPending, false},
+		{QuoteStatusExpired, QuoteStatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientDecisionDTO_Validate(t *testing.T) {
+	tests := []struct {
+		decision QuoteStatus
+		wantErr  error
+	}{
+		{QuoteStatusApproved, nil},
+		{QuoteStatusRejected, nil},
+		{QuoteStatusCancelled, ErrInvalidDecision},
+		{QuoteStatusPending, ErrInvalidDecision},
+		{"", ErrInvalidDecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.decision), func(t *testing.T) {
+			req := &ClientDecisionDTO{Decision: tt.decision}
+			if err := req.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateQuoteDTO_ValidateStatus(t *testing.T) {
+	tests := []struct {
+		status  QuoteStatus
+		wantErr error
+	}{
+		{"", nil},
+		{QuoteStatusPending, nil},
+		{QuoteStatusApproved, ErrInvalidQuoteStatus},
+		{QuoteStatusAwaitingApproval, ErrInvalidQuoteStatus},
+		{QuoteStatusRejected, ErrInvalidQuoteStatus},
+		{QuoteStatusCancelled, ErrInvalidQuoteStatus},
+		{QuoteStatusExpired, ErrInvalidQuoteStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			req := &CreateQuoteDTO{
+				ClientID: "c1",
+				UserID:   "u1",
+				Items:    []QuoteItemDTO{{ProductID: "p1", Quantity: 1}},
+				Status:   tt.status,
+			}
+			if err := req.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package quote
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SettingValidityDays é a chave em settings com a validade padrão dos orçamentos, em dias.
+const SettingValidityDays = "quote_validity_days"
+
+// DefaultValidityDays é usada quando o tenant não configurou a validade.
+const DefaultValidityDays = 15
+
+// ValidityDaysFromSettings lê a validade padrão das settings do tenant.
+// Valores ausentes ou inválidos caem no padrão.
+func ValidityDaysFromSettings(settings map[string]string) int {
+	days, err := strconv.Atoi(strings.TrimSpace(settings[SettingValidityDays]))
+	if err != nil || days <= 0 {
+		return DefaultValidityDays
+	}
+	return days
+}
+
+// ValidUntilFrom calcula o fim da validade: o último instante do dia, days dias após from.
+func ValidUntilFrom(from time.Time, days int) time.Time {
+	y, m, d := from.AddDate(0, 0, days).Date()
+	return time.Date(y, m, d, 23, 59, 59, 0, from.Location())
+}
+
+// IsOverdue indica se o orçamento em aberto já passou da validade.
+func (q *Quote) IsOverdue(now time.Time) bool {
+	return q.Status.IsEditable() && q.ValidUntil != nil && now.After(*q.ValidUntil)
+}
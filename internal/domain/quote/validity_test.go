@@ -0,0 +1,62 @@
+package quote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidityDaysFromSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     int
+	}{
+		{"configured", map[string]string{SettingValidityDays: "30"}, 30},
+		{"missing", map[string]string{}, DefaultValidityDays},
+		{"invalid", map[string]string{SettingValidityDays: "abc"}, DefaultValidityDays},
+		{"negative", map[string]string{SettingValidityDays: "-5"}, DefaultValidityDays},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidityDaysFromSettings(tt.settings); got != tt.want {
+				t.Errorf("ValidityDaysFromSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidUntilFrom(t *testing.T) {
+	from := time.Date(2026, 1, 30, 10, 15, 0, 0, time.UTC)
+
+	got := ValidUntilFrom(from, 15)
+	want := time.Date(2026, 2, 14, 23, 59, 59, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("ValidUntilFrom() = %v, want %v", got, want)
+	}
+}
+
+func TestQuote_IsOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		quote Quote
+		want  bool
+	}{
+		{"pending past validity", Quote{Status: QuoteStatusPending, ValidUntil: &past}, true},
+		{"pending within validity", Quote{Status: QuoteStatusPending, ValidUntil: &future}, false},
+		{"pending without validity", Quote{Status: QuoteStatusPending}, false},
+		{"approved past validity", Quote{Status: QuoteStatusApproved, ValidUntil: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quote.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package quotetemplate
+
+import (
+	"time"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+type TemplateItemDTO struct {
+	ProductID string  `json:"product_id" binding:"required"`
+	Quantity  int     `json:"quantity" binding:"required"`
+	WidthCM   float64 `json:"width_cm,omitempty"`
+	HeightCM  float64 `json:"height_cm,omitempty"`
+	Thickness float64 `json:"thickness,omitempty"`
+	EdgeType  string  `json:"edge_type,omitempty"`
+	EdgeSides string  `json:"edge_sides,omitempty"`
+	HasCutout bool    `json:"has_cutout,omitempty"`
+	Notes     string  `json:"notes,omitempty"`
+}
+
+type CreateTemplateDTO struct {
+	Name        string            `json:"name" binding:"required"`
+	Description string            `json:"description,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	Items       []TemplateItemDTO `json:"items" binding:"required"`
+}
+
+// UpdateTemplateDTO altera o modelo; quando Items é informado, substitui todos os itens
+type UpdateTemplateDTO struct {
+	Name        *string            `json:"name,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Notes       *string            `json:"notes,omitempty"`
+	Items       *[]TemplateItemDTO `json:"items,omitempty"`
+}
+
+// InstantiateTemplateDTO cria um orçamento a partir do modelo
+type InstantiateTemplateDTO struct {
+	ClientID   string     `json:"client_id" binding:"required"`
+	Discount   float64    `json:"discount,omitempty"`
+	Notes      *string    `json:"notes,omitempty"` // sobrescreve as observações do modelo
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// DiscountType indica se Discount é em R$ (amount, padrão) ou percentual
+	DiscountType quoteDomain.DiscountType `json:"discount_type,omitempty"`
+}
+
+// TemplateDetailDTO é o modelo completo, com os itens
+type TemplateDetailDTO struct {
+	*QuoteTemplate
+	Items []*QuoteTemplateItem `json:"items"`
+}
+
+type TemplateListDTO struct {
+	Templates []*QuoteTemplate `json:"templates"`
+	Total     int              `json:"total"`
+	Limit     int              `json:"limit"`
+	Offset    int              `json:"offset"`
+}
@@ -0,0 +1,56 @@
+package quotetemplate
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+// QuoteTemplate é um modelo de orçamento do tenant (ex.: bancada de cozinha
+// padrão) usado para criar novos orçamentos com os preços atuais dos produtos.
+type QuoteTemplate struct {
+	ID          dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID `json:"tenant_id" gorm:"not null;uniqueIndex:idx_quote_templates_tenant_name"`
+	Name        string       `json:"name" gorm:"not null;size:120;uniqueIndex:idx_quote_templates_tenant_name"`
+	Description string       `json:"description,omitempty"`
+	Notes       string       `json:"notes,omitempty"` // copiadas para o orçamento
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (t *QuoteTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// QuoteTemplateItem é um item do modelo com as medidas padrão; não guarda preço.
+type QuoteTemplateItem struct {
+	ID         dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID   dbtypes.UUID `json:"tenant_id" gorm:"not null"`
+	TemplateID dbtypes.UUID `json:"template_id" gorm:"not null;index"`
+	ProductID  dbtypes.UUID `json:"product_id" gorm:"not null"`
+	Position   int          `json:"position"` // ordem do item no modelo
+
+	Quantity  int     `json:"quantity" gorm:"default:1"`
+	WidthCM   float64 `json:"width_cm,omitempty"`
+	HeightCM  float64 `json:"height_cm,omitempty"`
+	Thickness float64 `json:"thickness,omitempty"`
+	EdgeType  string  `json:"edge_type,omitempty"`
+	EdgeSides string  `json:"edge_sides,omitempty" gorm:"size:40"`
+	HasCutout bool    `json:"has_cutout"`
+	Notes     string  `json:"notes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ti *QuoteTemplateItem) BeforeCreate(tx *gorm.DB) error {
+	if ti.ID == "" {
+		ti.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
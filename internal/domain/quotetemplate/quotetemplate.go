@@ -0,0 +1,56 @@
+package quotetemplate
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrTemplateNotFound      = errors.New("quote template not found")
+	ErrTemplateAlreadyExists = errors.New("a quote template with this name already exists")
+	ErrInvalidName           = errors.New("template name is required")
+	ErrInvalidItems          = errors.New("template must have at least one item")
+	ErrInvalidItem           = errors.New("template items need a product, a positive quantity and non-negative dimensions")
+)
+
+func (req *CreateTemplateDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidName
+	}
+	return validateItems(req.Items)
+}
+
+func (req *UpdateTemplateDTO) Validate() error {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return ErrInvalidName
+	}
+	if req.Items != nil {
+		return validateItems(*req.Items)
+	}
+	return nil
+}
+
+func (req *InstantiateTemplateDTO) Validate() error {
+	if req.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+	if req.Discount < 0 {
+		return errors.New("discount must not be negative")
+	}
+	return nil
+}
+
+func validateItems(items []TemplateItemDTO) error {
+	if len(items) == 0 {
+		return ErrInvalidItems
+	}
+	for _, item := range items {
+		if item.ProductID == "" || item.Quantity <= 0 {
+			return ErrInvalidItem
+		}
+		if item.WidthCM < 0 || item.HeightCM < 0 || item.Thickness < 0 {
+			return ErrInvalidItem
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package quotetemplate
+
+import "testing"
+
+func TestCreateTemplateDTO_Validate(t *testing.T) {
+	item := TemplateItemDTO{ProductID: "p1", Quantity: 1, WidthCM: 180, HeightCM: 60}
+
+	tests := []struct {
+		name string
+		req  CreateTemplateDTO
+		want error
+	}{
+		{"valid", CreateTemplateDTO{Name: "Bancada cozinha", Items: []TemplateItemDTO{item}}, nil},
+		{"blank name", CreateTemplateDTO{Name: "  ", Items: []TemplateItemDTO{item}}, ErrInvalidName},
+		{"no items", CreateTemplateDTO{Name: "Lavatório"}, ErrInvalidItems},
+		{"zero quantity", CreateTemplateDTO{Name: "Lavatório", Items: []TemplateItemDTO{{ProductID: "p1"}}}, ErrInvalidItem},
+		{"negative width", CreateTemplateDTO{Name: "Lavatório", Items: []TemplateItemDTO{{ProductID: "p1", Quantity: 1, WidthCM: -1}}}, ErrInvalidItem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateTemplateDTO_Validate(t *testing.T) {
+	blank := ""
+	empty := []TemplateItemDTO{}
+
+	if err := (&UpdateTemplateDTO{}).Validate(); err != nil {
+		t.Errorf("empty update should be valid, got %v", err)
+	}
+	if err := (&UpdateTemplateDTO{Name: &blank}).Validate(); err != ErrInvalidName {
+		t.Errorf("blank name: got %v, want %v", err, ErrInvalidName)
+	}
+	if err := (&UpdateTemplateDTO{Items: &empty}).Validate(); err != ErrInvalidItems {
+		t.Errorf("empty items: got %v, want %v", err, ErrInvalidItems)
+	}
+}
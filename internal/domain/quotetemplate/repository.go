@@ -0,0 +1,19 @@
+package quotetemplate
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, template *QuoteTemplate) error
+	GetByID(ctx context.Context, tenantID, id string) (*QuoteTemplate, error)
+	GetByName(ctx context.Context, tenantID, name string) (*QuoteTemplate, error)
+	Update(ctx context.Context, template *QuoteTemplate) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*QuoteTemplate, error)
+	Count(ctx context.Context, tenantID string) (int, error)
+}
+
+type ItemRepository interface {
+	Create(ctx context.Context, item *QuoteTemplateItem) error
+	GetByTemplateID(ctx context.Context, templateID string) ([]*QuoteTemplateItem, error)
+	DeleteByTemplateID(ctx context.Context, templateID string) error
+}
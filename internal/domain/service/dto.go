@@ -0,0 +1,25 @@
+package service
+
+type CreateServiceDTO struct {
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description,omitempty"`
+	Unit        string  `json:"unit,omitempty"`
+	Price       float64 `json:"price"`
+	CostPrice   float64 `json:"cost_price,omitempty"`
+}
+
+type UpdateServiceDTO struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Unit        *string  `json:"unit,omitempty"`
+	Price       *float64 `json:"price,omitempty"`
+	CostPrice   *float64 `json:"cost_price,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+}
+
+type ServiceListDTO struct {
+	Services []*Service `json:"services"`
+	Total    int        `json:"total"`
+	Limit    int        `json:"limit"`
+	Offset   int        `json:"offset"`
+}
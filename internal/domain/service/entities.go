@@ -0,0 +1,32 @@
+package service
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+// Service é um serviço do catálogo do tenant (instalação, frete, mão de obra)
+// cobrado como linha avulsa no orçamento, sem medidas nem produto.
+type Service struct {
+	ID          dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID    dbtypes.UUID `json:"tenant_id" gorm:"not null;uniqueIndex:idx_services_tenant_name"`
+	Name        string       `json:"name" gorm:"not null;size:120;uniqueIndex:idx_services_tenant_name"`
+	Description string       `json:"description,omitempty"`
+	Unit        string       `json:"unit,omitempty" gorm:"size:20"` // unidade de cobrança exibida (un, h, km)
+	Price       float64      `json:"price" gorm:"not null"`         // preço padrão por unidade
+	CostPrice   float64      `json:"cost_price" gorm:"default:0"`   // custo por unidade
+	IsActive    bool         `json:"is_active" gorm:"default:true"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (s *Service) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, service *Service) error
+	GetByID(ctx context.Context, tenantID, id string) (*Service, error)
+	GetByName(ctx context.Context, tenantID, name string) (*Service, error)
+	Update(ctx context.Context, service *Service) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, activeOnly bool, limit, offset int) ([]*Service, error)
+	Count(ctx context.Context, tenantID string, activeOnly bool) (int, error)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrServiceNotFound      = errors.New("service not found")
+	ErrServiceAlreadyExists = errors.New("a service with this name already exists")
+	ErrServiceInactive      = errors.New("service is inactive")
+	ErrInvalidName          = errors.New("service name is required")
+	ErrInvalidPrice         = errors.New("service price and cost must not be negative")
+)
+
+func (req *CreateServiceDTO) Validate() error {
+	if strings.TrimSpace(req.Name) == "" {
+		return ErrInvalidName
+	}
+	if req.Price < 0 || req.CostPrice < 0 {
+		return ErrInvalidPrice
+	}
+	return nil
+}
+
+func (req *UpdateServiceDTO) Validate() error {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return ErrInvalidName
+	}
+	if (req.Price != nil && *req.Price < 0) || (req.CostPrice != nil && *req.CostPrice < 0) {
+		return ErrInvalidPrice
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import "testing"
+
+func TestCreateServiceDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  CreateServiceDTO
+		want error
+	}{
+		{"valid", CreateServiceDTO{Name: "Instalação", Unit: "un", Price: 250}, nil},
+		{"free service", CreateServiceDTO{Name: "Visita técnica"}, nil},
+		{"blank name", CreateServiceDTO{Name: " ", Price: 10}, ErrInvalidName},
+		{"negative price", CreateServiceDTO{Name: "Frete", Price: -1}, ErrInvalidPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateServiceDTO_Validate(t *testing.T) {
+	blank, negative := "", -5.0
+
+	if err := (&UpdateServiceDTO{}).Validate(); err != nil {
+		t.Errorf("empty update should be valid, got %v", err)
+	}
+	if err := (&UpdateServiceDTO{Name: &blank}).Validate(); err != ErrInvalidName {
+		t.Errorf("blank name: got %v, want %v", err, ErrInvalidName)
+	}
+	if err := (&UpdateServiceDTO{Price: &negative}).Validate(); err != ErrInvalidPrice {
+		t.Errorf("negative price: got %v, want %v", err, ErrInvalidPrice)
+	}
+}
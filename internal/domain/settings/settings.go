@@ -1,13 +1,22 @@
 package settings
 
-import "errors"
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
 
 var (
 	ErrSettingsNotFound = errors.New("settings not found")
 	ErrInvalidCNPJ      = errors.New("invalid CNPJ")
 	ErrInvalidEmail     = errors.New("invalid email")
+	ErrInvalidLogoURL   = errors.New("logo_url must be an https url")
 )
 
+// SettingLogoURL é a URL do logo impresso na proposta. O servidor baixa a
+// imagem, então só URLs https são aceitas.
+const SettingLogoURL = "logo_url"
+
 func (req *UpdateSettingsDTO) Validate() error {
 	if req.TenantID == "" {
 		return errors.New("tenant_id is required")
@@ -15,6 +24,12 @@ func (req *UpdateSettingsDTO) Validate() error {
 	if req.Settings == nil {
 		return errors.New("settings is required")
 	}
+	if logo := strings.TrimSpace(req.Settings[SettingLogoURL]); logo != "" {
+		u, err := url.Parse(logo)
+		if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+			return ErrInvalidLogoURL
+		}
+	}
 	return nil
 }
 
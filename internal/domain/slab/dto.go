@@ -0,0 +1,84 @@
+package slab
+
+type CreateSlabDTO struct {
+	ProductID string   `json:"product_id" binding:"required"`
+	Lot       string   `json:"lot,omitempty"`
+	Bundle    string   `json:"bundle,omitempty"`
+	WidthCM   float64  `json:"width_cm" binding:"required"`
+	HeightCM  float64  `json:"height_cm" binding:"required"`
+	Thickness float64  `json:"thickness,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Notes     string   `json:"notes,omitempty"`
+	PhotoURLs []string `json:"photo_urls,omitempty"`
+}
+
+// UpdateSlabDTO altera dados de cadastro e situação; as medidas não mudam,
+// uma chapa cortada gera retalhos novos
+type UpdateSlabDTO struct {
+	Lot       *string  `json:"lot,omitempty"`
+	Bundle    *string  `json:"bundle,omitempty"`
+	Thickness *float64 `json:"thickness,omitempty"`
+	Location  *string  `json:"location,omitempty"`
+	Status    *Status  `json:"status,omitempty"`
+	Notes     *string  `json:"notes,omitempty"`
+}
+
+type RemnantDTO struct {
+	WidthCM  float64 `json:"width_cm" binding:"required"`
+	HeightCM float64 `json:"height_cm" binding:"required"`
+	Location string  `json:"location,omitempty"`
+	Notes    string  `json:"notes,omitempty"`
+}
+
+// RegisterRemnantsDTO registra as sobras do corte de uma chapa
+type RegisterRemnantsDTO struct {
+	Remnants []RemnantDTO `json:"remnants" binding:"required,min=1,dive"`
+}
+
+type AddPhotoDTO struct {
+	URL     string `json:"url" binding:"required"`
+	Caption string `json:"caption,omitempty"`
+}
+
+type ListFilter struct {
+	ProductID    string
+	Lot          string
+	Status       Status
+	RemnantsOnly bool
+	// QuoteID traz as chapas reservadas para o orçamento
+	QuoteID string
+}
+
+type SlabListDTO struct {
+	Slabs  []*Slab `json:"slabs"`
+	Total  int     `json:"total"`
+	Limit  int     `json:"limit"`
+	Offset int     `json:"offset"`
+}
+
+// RemnantSearch descreve a peça procurada entre os retalhos
+type RemnantSearch struct {
+	ProductID     string
+	WidthCM       float64
+	HeightCM      float64
+	Thickness     float64
+	AllowRotation bool
+	MarginCM      float64 // folga somada a cada lado da peça para o corte
+}
+
+// RemnantMatch é um retalho que comporta a peça
+type RemnantMatch struct {
+	*Slab
+	Rotated bool `json:"rotated"`
+	// LeftoverM2 é a área do retalho que sobra depois de cortar a peça
+	LeftoverM2 float64 `json:"leftover_m2"`
+}
+
+type RemnantSearchDTO struct {
+	QuoteItemID string          `json:"quote_item_id"`
+	ProductID   string          `json:"product_id"`
+	WidthCM     float64         `json:"width_cm"`
+	HeightCM    float64         `json:"height_cm"`
+	Thickness   float64         `json:"thickness,omitempty"`
+	Matches     []*RemnantMatch `json:"matches"`
+}
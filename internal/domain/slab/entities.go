@@ -0,0 +1,74 @@
+package slab
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+type Status string
+
+const (
+	StatusAvailable Status = "available" // no pátio, livre para uso
+	StatusReserved  Status = "reserved"  // separada para um orçamento ou pedido
+	StatusUsed      Status = "used"      // cortada; o que sobrou virou retalho
+	StatusDiscarded Status = "discarded" // quebrada ou descartada
+)
+
+// Slab é uma chapa física de pedra. Chapas do mesmo produto variam em medida,
+// lote e tonalidade, por isso cada uma é cadastrada individualmente. Retalhos
+// são chapas menores com ParentID apontando para a chapa de onde saíram.
+type Slab struct {
+	ID        dbtypes.UUID  `json:"id" gorm:"primaryKey"`
+	TenantID  dbtypes.UUID  `json:"tenant_id" gorm:"not null;index"`
+	ProductID dbtypes.UUID  `json:"product_id" gorm:"not null;index"`
+	ParentID  *dbtypes.UUID `json:"parent_id,omitempty" gorm:"index"`
+	IsRemnant bool          `json:"is_remnant" gorm:"default:false"`
+
+	Lot    string `json:"lot,omitempty" gorm:"size:60;index"` // lote do fornecedor
+	Bundle string `json:"bundle,omitempty" gorm:"size:60"`    // número do bloco/pacote dentro do lote
+
+	// Medidas
+	WidthCM   float64 `json:"width_cm" gorm:"not null"`
+	HeightCM  float64 `json:"height_cm" gorm:"not null"`
+	Thickness float64 `json:"thickness,omitempty"` // espessura, na mesma unidade dos itens de orçamento
+
+	Location string `json:"location,omitempty" gorm:"size:120"` // posição no pátio ou cavalete
+	Status   Status `json:"status" gorm:"size:20;not null;default:'available';index"`
+	Notes    string `json:"notes,omitempty"`
+	// QuoteID é o orçamento aprovado para o qual a chapa está reservada
+	QuoteID *dbtypes.UUID `json:"quote_id,omitempty" gorm:"index"`
+
+	Photos []*Photo `json:"photos,omitempty" gorm:"foreignKey:SlabID"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (s *Slab) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
+
+// Photo é uma foto da chapa, para o cliente escolher a tonalidade e os veios.
+type Photo struct {
+	ID        dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID  dbtypes.UUID `json:"tenant_id" gorm:"not null"`
+	SlabID    dbtypes.UUID `json:"slab_id" gorm:"not null;index"`
+	URL       string       `json:"url" gorm:"size:500;not null"`
+	Caption   string       `json:"caption,omitempty" gorm:"size:255"`
+	CreatedAt time.Time    `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (Photo) TableName() string { return "slab_photos" }
+
+func (p *Photo) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
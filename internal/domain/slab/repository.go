@@ -0,0 +1,22 @@
+package slab
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, slab *Slab) error
+	// GetByID carrega a chapa com as fotos
+	GetByID(ctx context.Context, tenantID, id string) (*Slab, error)
+	// GetForUpdate lê a chapa, sem fotos, travando a linha até o fim da transação
+	GetForUpdate(ctx context.Context, tenantID, id string) (*Slab, error)
+	Update(ctx context.Context, slab *Slab) error
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, filter ListFilter, limit, offset int) ([]*Slab, error)
+	Count(ctx context.Context, tenantID string, filter ListFilter) (int, error)
+	// ListAll traz, sem paginação, as chapas do filtro; usado na busca de retalhos
+	ListAll(ctx context.Context, tenantID string, filter ListFilter) ([]*Slab, error)
+}
+
+type PhotoRepository interface {
+	Create(ctx context.Context, photo *Photo) error
+	Delete(ctx context.Context, tenantID, slabID, id string) error
+}
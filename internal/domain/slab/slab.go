@@ -0,0 +1,242 @@
+package slab
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+var (
+	ErrSlabNotFound         = errors.New("slab not found")
+	ErrPhotoNotFound        = errors.New("slab photo not found")
+	ErrInvalidDimensions    = errors.New("width_cm and height_cm must be greater than zero")
+	ErrInvalidThickness     = errors.New("thickness must not be negative")
+	ErrInvalidStatus        = errors.New("status must be available, reserved, used or discarded")
+	ErrInvalidStatusChange  = errors.New("used and discarded slabs cannot change status")
+	ErrSlabDiscarded        = errors.New("discarded slabs cannot have remnants")
+	ErrRemnantTooLarge      = errors.New("remnants must fit inside the original slab")
+	ErrInvalidPhotoURL      = errors.New("photo url is required")
+	ErrItemWithoutProduct   = errors.New("quote item has no product")
+	ErrItemWithoutDimension = errors.New("quote item has no width and height")
+	ErrSlabUnavailable      = errors.New("slab is not available")
+	ErrSlabProductMismatch  = errors.New("slab belongs to another product")
+	ErrReservedByQuote      = errors.New("reserved slabs are managed by their quote")
+)
+
+// thicknessTolerance absorve arredondamentos na comparação de espessuras.
+const thicknessTolerance = 0.01
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusAvailable, StatusReserved, StatusUsed, StatusDiscarded:
+		return true
+	}
+	return false
+}
+
+// IsFinal indica se a chapa saiu do estoque.
+func (s Status) IsFinal() bool {
+	return s == StatusUsed || s == StatusDiscarded
+}
+
+func (req *CreateSlabDTO) Validate() error {
+	if req.WidthCM <= 0 || req.HeightCM <= 0 {
+		return ErrInvalidDimensions
+	}
+	if req.Thickness < 0 {
+		return ErrInvalidThickness
+	}
+	for _, url := range req.PhotoURLs {
+		if strings.TrimSpace(url) == "" {
+			return ErrInvalidPhotoURL
+		}
+	}
+	return nil
+}
+
+func (req *UpdateSlabDTO) Validate() error {
+	if req.Thickness != nil && *req.Thickness < 0 {
+		return ErrInvalidThickness
+	}
+	if req.Status != nil && !req.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+func (req *AddPhotoDTO) Validate() error {
+	if strings.TrimSpace(req.URL) == "" {
+		return ErrInvalidPhotoURL
+	}
+	return nil
+}
+
+// ChangeStatus troca a situação da chapa; usada e descartada são finais.
+func (s *Slab) ChangeStatus(next Status) error {
+	if !next.IsValid() {
+		return ErrInvalidStatus
+	}
+	if s.Status == next {
+		return nil
+	}
+	if s.Status.IsFinal() {
+		return ErrInvalidStatusChange
+	}
+	s.Status = next
+	if next == StatusAvailable {
+		s.QuoteID = nil
+	}
+	return nil
+}
+
+// ChangeStatusManually é a troca feita no cadastro da chapa. A reserva pertence
+// ao orçamento: só a aprovação reserva e só o orçamento libera.
+func (s *Slab) ChangeStatusManually(next Status) error {
+	if s.Status != next && (s.Status == StatusReserved || next == StatusReserved) {
+		return ErrReservedByQuote
+	}
+	return s.ChangeStatus(next)
+}
+
+// ReserveFor separa a chapa disponível para o orçamento. Reservar de novo para o
+// mesmo orçamento não muda nada.
+func (s *Slab) ReserveFor(quoteID dbtypes.UUID) error {
+	if s.Status == StatusReserved && s.QuoteID != nil && *s.QuoteID == quoteID {
+		return nil
+	}
+	if s.Status != StatusAvailable {
+		return ErrSlabUnavailable
+	}
+	s.Status = StatusReserved
+	s.QuoteID = &quoteID
+	return nil
+}
+
+// UseFor dá baixa na chapa reservada para o orçamento quando o pedido dele entra
+// em produção. O vínculo com o orçamento fica como histórico do consumo.
+func (s *Slab) UseFor(quoteID dbtypes.UUID) error {
+	if s.QuoteID == nil || *s.QuoteID != quoteID {
+		return ErrSlabUnavailable
+	}
+	if s.Status == StatusUsed {
+		return nil
+	}
+	if s.Status != StatusReserved {
+		return ErrSlabUnavailable
+	}
+	s.Status = StatusUsed
+	return nil
+}
+
+// AreaM2 é a área da chapa em m².
+func (s *Slab) AreaM2() float64 {
+	return s.WidthCM * s.HeightCM / 10000
+}
+
+// Fits indica se uma peça width×height cabe na chapa e se foi preciso girá-la.
+func (s *Slab) Fits(width, height float64, allowRotation bool) (fits, rotated bool) {
+	if width <= s.WidthCM && height <= s.HeightCM {
+		return true, false
+	}
+	if allowRotation && height <= s.WidthCM && width <= s.HeightCM {
+		return true, true
+	}
+	return false, false
+}
+
+// NewRemnants monta os retalhos cortados de parent. Cada retalho herda produto,
+// lote, bloco e espessura da chapa de origem e precisa caber nela; juntos, não
+// podem ter área maior que a dela. Chapa ainda reservada para um orçamento só é
+// cortada depois que o pedido entra em produção e dá baixa nela.
+func NewRemnants(parent *Slab, req *RegisterRemnantsDTO) ([]*Slab, error) {
+	if parent.Status == StatusDiscarded {
+		return nil, ErrSlabDiscarded
+	}
+	if parent.Status == StatusReserved {
+		return nil, ErrReservedByQuote
+	}
+
+	var area float64
+	remnants := make([]*Slab, 0, len(req.Remnants))
+	for _, r := range req.Remnants {
+		if r.WidthCM <= 0 || r.HeightCM <= 0 {
+			return nil, ErrInvalidDimensions
+		}
+		if fits, _ := parent.Fits(r.WidthCM, r.HeightCM, true); !fits {
+			return nil, ErrRemnantTooLarge
+		}
+		area += r.WidthCM * r.HeightCM
+
+		location := strings.TrimSpace(r.Location)
+		if location == "" {
+			location = parent.Location
+		}
+		parentID := parent.ID
+		remnants = append(remnants, &Slab{
+			TenantID:  parent.TenantID,
+			ProductID: parent.ProductID,
+			ParentID:  &parentID,
+			IsRemnant: true,
+			Lot:       parent.Lot,
+			Bundle:    parent.Bundle,
+			WidthCM:   r.WidthCM,
+			HeightCM:  r.HeightCM,
+			Thickness: parent.Thickness,
+			Location:  location,
+			Status:    StatusAvailable,
+			Notes:     r.Notes,
+		})
+	}
+	if area > parent.WidthCM*parent.HeightCM {
+		return nil, ErrRemnantTooLarge
+	}
+
+	return remnants, nil
+}
+
+// FindRemnants filtra os retalhos disponíveis que comportam a peça procurada,
+// do que desperdiça menos para o que desperdiça mais.
+func FindRemnants(candidates []*Slab, search RemnantSearch) []*RemnantMatch {
+	width := search.WidthCM + 2*search.MarginCM
+	height := search.HeightCM + 2*search.MarginCM
+	pieceArea := width * height / 10000
+
+	matches := make([]*RemnantMatch, 0)
+	for _, candidate := range candidates {
+		if candidate.Status != StatusAvailable || candidate.ProductID.String() != search.ProductID {
+			continue
+		}
+		if search.Thickness > 0 && candidate.Thickness > 0 &&
+			math.Abs(candidate.Thickness-search.Thickness) > thicknessTolerance {
+			continue
+		}
+
+		fits, rotated := candidate.Fits(width, height, search.AllowRotation)
+		if !fits {
+			continue
+		}
+		matches = append(matches, &RemnantMatch{
+			Slab:       candidate,
+			Rotated:    rotated,
+			LeftoverM2: math.Round((candidate.AreaM2()-pieceArea)*10000) / 10000,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].LeftoverM2 < matches[j].LeftoverM2
+	})
+	return matches
+}
+
+// NewPhoto monta a foto da chapa.
+func NewPhoto(slab *Slab, url, caption string) *Photo {
+	return &Photo{
+		TenantID: slab.TenantID,
+		SlabID:   slab.ID,
+		URL:      strings.TrimSpace(url),
+		Caption:  strings.TrimSpace(caption),
+	}
+}
@@ -0,0 +1,221 @@
+package slab
+
+import (
+	"testing"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+func TestFits(t *testing.T) {
+	slab := &Slab{WidthCM: 120, HeightCM: 60}
+
+	tests := []struct {
+		name          string
+		width, height float64
+		allowRotation bool
+		wantFits      bool
+		wantRotated   bool
+	}{
+		{"fits as is", 100, 50, false, true, false},
+		{"exact size", 120, 60, false, true, false},
+		{"needs rotation", 50, 100, true, true, true},
+		{"rotation not allowed", 50, 100, false, false, false},
+		{"too large", 130, 50, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fits, rotated := slab.Fits(tt.width, tt.height, tt.allowRotation)
+			if fits != tt.wantFits || rotated != tt.wantRotated {
+				t.Errorf("Fits() = (%v, %v), want (%v, %v)", fits, rotated, tt.wantFits, tt.wantRotated)
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	slab := &Slab{Status: StatusAvailable}
+	if err := slab.ChangeStatus(StatusReserved); err != nil {
+		t.Fatalf("ChangeStatus(reserved) error = %v", err)
+	}
+	if err := slab.ChangeStatus(StatusUsed); err != nil {
+		t.Fatalf("ChangeStatus(used) error = %v", err)
+	}
+	if err := slab.ChangeStatus(StatusAvailable); err != ErrInvalidStatusChange {
+		t.Fatalf("ChangeStatus() on used slab error = %v, want %v", err, ErrInvalidStatusChange)
+	}
+	if err := slab.ChangeStatus("broken"); err != ErrInvalidStatus {
+		t.Fatalf("ChangeStatus(broken) error = %v, want %v", err, ErrInvalidStatus)
+	}
+}
+
+func TestReserveFor(t *testing.T) {
+	slab := &Slab{Status: StatusAvailable}
+	if err := slab.ReserveFor("quote-1"); err != nil {
+		t.Fatalf("ReserveFor(quote-1) error = %v", err)
+	}
+	if slab.Status != StatusReserved || slab.QuoteID == nil || *slab.QuoteID != "quote-1" {
+		t.Fatalf("slab = %s/%v, want reserved for quote-1", slab.Status, slab.QuoteID)
+	}
+	if err := slab.ReserveFor("quote-1"); err != nil {
+		t.Errorf("ReserveFor(quote-1) again error = %v", err)
+	}
+	if err := slab.ReserveFor("quote-2"); err != ErrSlabUnavailable {
+		t.Errorf("ReserveFor(quote-2) error = %v, want %v", err, ErrSlabUnavailable)
+	}
+
+	// Voltar ao pátio desfaz o vínculo com o orçamento
+	if err := slab.ChangeStatus(StatusAvailable); err != nil {
+		t.Fatalf("ChangeStatus(available) error = %v", err)
+	}
+	if slab.QuoteID != nil {
+		t.Errorf("quote = %v, want none", slab.QuoteID)
+	}
+}
+
+func TestUseFor(t *testing.T) {
+	slab := &Slab{Status: StatusAvailable}
+	if err := slab.UseFor("quote-1"); err != ErrSlabUnavailable {
+		t.Errorf("UseFor() on a free slab error = %v, want %v", err, ErrSlabUnavailable)
+	}
+
+	if err := slab.ReserveFor("quote-1"); err != nil {
+		t.Fatalf("ReserveFor(quote-1) error = %v", err)
+	}
+	if err := slab.UseFor("quote-2"); err != ErrSlabUnavailable {
+		t.Errorf("UseFor(quote-2) error = %v, want %v", err, ErrSlabUnavailable)
+	}
+	if err := slab.UseFor("quote-1"); err != nil {
+		t.Fatalf("UseFor(quote-1) error = %v", err)
+	}
+	if slab.Status != StatusUsed || slab.QuoteID == nil || *slab.QuoteID != "quote-1" {
+		t.Errorf("slab = %s/%v, want used by quote-1", slab.Status, slab.QuoteID)
+	}
+	if err := slab.UseFor("quote-1"); err != nil {
+		t.Errorf("UseFor(quote-1) again error = %v", err)
+	}
+}
+
+func TestChangeStatusManually(t *testing.T) {
+	slab := &Slab{Status: StatusAvailable}
+	if err := slab.ChangeStatusManually(StatusReserved); err != ErrReservedByQuote {
+		t.Errorf("ChangeStatusManually(reserved) error = %v, want %v", err, ErrReservedByQuote)
+	}
+
+	if err := slab.ReserveFor("quote-1"); err != nil {
+		t.Fatalf("ReserveFor(quote-1) error = %v", err)
+	}
+	for _, next := range []Status{StatusAvailable, StatusUsed, StatusDiscarded} {
+		if err := slab.ChangeStatusManually(next); err != ErrReservedByQuote {
+			t.Errorf("ChangeStatusManually(%s) on reserved slab error = %v, want %v", next, err, ErrReservedByQuote)
+		}
+	}
+	if slab.Status != StatusReserved {
+		t.Fatalf("status = %s, want %s", slab.Status, StatusReserved)
+	}
+
+	other := &Slab{Status: StatusAvailable}
+	if err := other.ChangeStatusManually(StatusDiscarded); err != nil || other.Status != StatusDiscarded {
+		t.Errorf("ChangeStatusManually(discarded) = %v, status %s", err, other.Status)
+	}
+}
+
+func TestNewRemnants(t *testing.T) {
+	parent := &Slab{
+		ID:        "s1",
+		TenantID:  "t1",
+		ProductID: "p1",
+		Lot:       "L-22",
+		Bundle:    "7",
+		WidthCM:   300,
+		HeightCM:  180,
+		Thickness: 2,
+		Location:  "Cavalete 3",
+		Status:    StatusAvailable,
+	}
+
+	quoteID := dbtypes.UUID("quote-1")
+
+	remnants, err := NewRemnants(parent, &RegisterRemnantsDTO{Remnants: []RemnantDTO{
+		{WidthCM: 100, HeightCM: 60},
+		{WidthCM: 170, HeightCM: 40, Location: "Cavalete 9"},
+	}})
+	if err != nil {
+		t.Fatalf("NewRemnants() error = %v", err)
+	}
+	if len(remnants) != 2 {
+		t.Fatalf("len(remnants) = %d, want 2", len(remnants))
+	}
+
+	first := remnants[0]
+	if !first.IsRemnant || first.ParentID == nil || *first.ParentID != parent.ID {
+		t.Errorf("remnant not linked to parent: %+v", first)
+	}
+	if first.ProductID != parent.ProductID || first.Lot != parent.Lot || first.Bundle != parent.Bundle || first.Thickness != parent.Thickness {
+		t.Errorf("remnant did not inherit product, lot, bundle and thickness: %+v", first)
+	}
+	if first.Location != "Cavalete 3" || remnants[1].Location != "Cavalete 9" {
+		t.Errorf("locations = %q, %q", first.Location, remnants[1].Location)
+	}
+	if first.Status != StatusAvailable {
+		t.Errorf("status = %s, want %s", first.Status, StatusAvailable)
+	}
+
+	tests := []struct {
+		name     string
+		parent   *Slab
+		remnants []RemnantDTO
+		want     error
+	}{
+		{"larger than parent", parent, []RemnantDTO{{WidthCM: 310, HeightCM: 10}}, ErrRemnantTooLarge},
+		{"total area too large", parent, []RemnantDTO{{WidthCM: 300, HeightCM: 100}, {WidthCM: 300, HeightCM: 100}}, ErrRemnantTooLarge},
+		{"invalid size", parent, []RemnantDTO{{WidthCM: 0, HeightCM: 10}}, ErrInvalidDimensions},
+		{"discarded parent", &Slab{WidthCM: 100, HeightCM: 100, Status: StatusDiscarded}, []RemnantDTO{{WidthCM: 10, HeightCM: 10}}, ErrSlabDiscarded},
+		{"reserved parent", &Slab{WidthCM: 100, HeightCM: 100, Status: StatusReserved, QuoteID: &quoteID}, []RemnantDTO{{WidthCM: 10, HeightCM: 10}}, ErrReservedByQuote},
+		{"parent used by its quote", &Slab{WidthCM: 100, HeightCM: 100, Status: StatusUsed, QuoteID: &quoteID}, []RemnantDTO{{WidthCM: 10, HeightCM: 10}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRemnants(tt.parent, &RegisterRemnantsDTO{Remnants: tt.remnants}); err != tt.want {
+				t.Errorf("NewRemnants() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRemnants(t *testing.T) {
+	candidates := []*Slab{
+		{ID: "big", ProductID: "p1", WidthCM: 150, HeightCM: 80, Thickness: 2, Status: StatusAvailable},
+		{ID: "tight", ProductID: "p1", WidthCM: 70, HeightCM: 110, Thickness: 2, Status: StatusAvailable},
+		{ID: "small", ProductID: "p1", WidthCM: 60, HeightCM: 60, Thickness: 2, Status: StatusAvailable},
+		{ID: "thick", ProductID: "p1", WidthCM: 200, HeightCM: 100, Thickness: 3, Status: StatusAvailable},
+		{ID: "reserved", ProductID: "p1", WidthCM: 200, HeightCM: 100, Thickness: 2, Status: StatusReserved},
+		{ID: "other", ProductID: "p2", WidthCM: 200, HeightCM: 100, Thickness: 2, Status: StatusAvailable},
+	}
+
+	search := RemnantSearch{ProductID: "p1", WidthCM: 100, HeightCM: 60, Thickness: 2, AllowRotation: true}
+	matches := FindRemnants(candidates, search)
+	if len(matches) != 2 {
+		t.Fatalf("len(matches) = %d, want 2", len(matches))
+	}
+	if matches[0].ID != "tight" || !matches[0].Rotated {
+		t.Errorf("best match = %s (rotated %v), want tight rotated", matches[0].ID, matches[0].Rotated)
+	}
+	if matches[0].LeftoverM2 != 0.17 {
+		t.Errorf("LeftoverM2 = %v, want 0.17", matches[0].LeftoverM2)
+	}
+	if matches[1].ID != "big" || matches[1].Rotated {
+		t.Errorf("second match = %s (rotated %v), want big", matches[1].ID, matches[1].Rotated)
+	}
+
+	search.AllowRotation = false
+	if matches := FindRemnants(candidates, search); len(matches) != 1 || matches[0].ID != "big" {
+		t.Errorf("without rotation matches = %v, want only big", matches)
+	}
+
+	search.MarginCM = 11
+	if matches := FindRemnants(candidates, search); len(matches) != 0 {
+		t.Errorf("with margin matches = %d, want 0", len(matches))
+	}
+}
@@ -0,0 +1,32 @@
+package stock
+
+// CreateMovementDTO lança um movimento manual: entrada, saída ou ajuste. Em
+// entradas e saídas a quantidade é sempre positiva; em ajustes o sinal indica o sentido.
+type CreateMovementDTO struct {
+	Type          MovementType `json:"type" binding:"required"`
+	Quantity      int          `json:"quantity" binding:"required"`
+	Reason        string       `json:"reason" binding:"required"`
+	ReferenceType string       `json:"reference_type,omitempty"`
+	ReferenceID   string       `json:"reference_id,omitempty"`
+}
+
+// ListFilter restringe o histórico do produto
+type ListFilter struct {
+	Type MovementType
+}
+
+type MovementListDTO struct {
+	Movements []*Movement `json:"movements"`
+	Total     int         `json:"total"`
+	Limit     int         `json:"limit"`
+	Offset    int         `json:"offset"`
+}
+
+// ReconcileDTO compara o saldo gravado no produto com o saldo do livro
+type ReconcileDTO struct {
+	ProductID   string `json:"product_id"`
+	Stock       int    `json:"stock"`        // saldo que estava no produto
+	LedgerStock int    `json:"ledger_stock"` // saldo calculado pelos lançamentos
+	Reserved    int    `json:"reserved"`
+	Corrected   bool   `json:"corrected"`
+}
@@ -0,0 +1,48 @@
+package stock
+
+import (
+	"time"
+
+	"erp-api/internal/utils/dbtypes"
+
+	"gorm.io/gorm"
+)
+
+type MovementType string
+
+const (
+	TypeEntry       MovementType = "entry"       // entrada: compra, devolução, produção
+	TypeExit        MovementType = "exit"        // saída: venda, consumo, perda
+	TypeAdjustment  MovementType = "adjustment"  // acerto de inventário, positivo ou negativo
+	TypeReservation MovementType = "reservation" // reserva (positiva) ou liberação (negativa); não mexe no saldo físico
+)
+
+// Movement é um lançamento no livro de estoque. Quantity tem sinal: entradas
+// somam, saídas subtraem. O saldo físico do produto é a soma dos lançamentos
+// que não são reserva; Product.Stock guarda esse saldo para consultas rápidas.
+type Movement struct {
+	ID        dbtypes.UUID `json:"id" gorm:"primaryKey"`
+	TenantID  dbtypes.UUID `json:"tenant_id" gorm:"not null;index"`
+	ProductID dbtypes.UUID `json:"product_id" gorm:"not null;index:idx_stock_movements_product"`
+	Type      MovementType `json:"type" gorm:"size:20;not null"`
+	Quantity  int          `json:"quantity" gorm:"not null"`
+	// Saldo físico do produto logo após o lançamento
+	Balance int    `json:"balance"`
+	Reason  string `json:"reason" gorm:"size:255;not null"`
+
+	// Documento de origem, ex.: quote + ID do orçamento ou invoice + número da nota
+	ReferenceType string `json:"reference_type,omitempty" gorm:"size:30"`
+	ReferenceID   string `json:"reference_id,omitempty" gorm:"size:64"`
+
+	UserID    *dbtypes.UUID `json:"user_id,omitempty"`
+	CreatedAt time.Time     `json:"created_at" gorm:"autoCreateTime;index:idx_stock_movements_product"`
+}
+
+func (Movement) TableName() string { return "stock_movements" }
+
+func (m *Movement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = dbtypes.NewUUID()
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package stock
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, movement *Movement) error
+	List(ctx context.Context, tenantID, productID string, filter ListFilter, limit, offset int) ([]*Movement, error)
+	Count(ctx context.Context, tenantID, productID string, filter ListFilter) (int, error)
+	// Totals soma os lançamentos do produto: saldo físico e quantidade reservada
+	Totals(ctx context.Context, tenantID, productID string) (onHand, reserved int, err error)
+	// ReservedByProduct soma as reservas em aberto de cada produto informado
+	ReservedByProduct(ctx context.Context, tenantID string, productIDs []string) (map[string]int, error)
+	// ReservedByReference soma, por produto, o que segue reservado para o documento
+	ReservedByReference(ctx context.Context, tenantID, referenceType, referenceID string) (map[string]int, error)
+}
@@ -0,0 +1,103 @@
+package stock
+
+import (
+	"errors"
+	"strings"
+
+	"erp-api/internal/utils/dbtypes"
+)
+
+var (
+	ErrInvalidType         = errors.New("movement type must be entry, exit, adjustment or reservation")
+	ErrInvalidQuantity     = errors.New("quantity must be greater than zero for entries and exits and non-zero otherwise")
+	ErrReasonRequired      = errors.New("reason is required")
+	ErrInsufficientStock   = errors.New("insufficient stock for this movement")
+	ErrInvalidReservation  = errors.New("cannot release more than is reserved")
+	ErrReferenceIncomplete = errors.New("reference_type and reference_id must be informed together")
+	ErrManualReservation   = errors.New("reservations are made by approving quotes, use entry, exit or adjustment")
+)
+
+// Motivos dos lançamentos gerados pelo cadastro do produto
+const (
+	ReasonOpeningBalance = "Saldo inicial"
+	ReasonProductEdit    = "Ajuste pelo cadastro do produto"
+)
+
+// ReferenceQuote identifica lançamentos gerados pelo fluxo do orçamento.
+const ReferenceQuote = "quote"
+
+func (t MovementType) IsValid() bool {
+	switch t {
+	case TypeEntry, TypeExit, TypeAdjustment, TypeReservation:
+		return true
+	}
+	return false
+}
+
+// AffectsStock indica se o lançamento altera o saldo físico.
+func (t MovementType) AffectsStock() bool {
+	return t != TypeReservation
+}
+
+func (req *CreateMovementDTO) Validate() error {
+	if !req.Type.IsValid() {
+		return ErrInvalidType
+	}
+	// Reservas e liberações acompanham o orçamento; lançadas à mão, deixariam
+	// reserva sem dono no livro
+	if req.Type == TypeReservation {
+		return ErrManualReservation
+	}
+	if req.Quantity == 0 {
+		return ErrInvalidQuantity
+	}
+	if (req.Type == TypeEntry || req.Type == TypeExit) && req.Quantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return ErrReasonRequired
+	}
+	if (req.ReferenceType == "") != (req.ReferenceID == "") {
+		return ErrReferenceIncomplete
+	}
+	return nil
+}
+
+// NewMovement monta o lançamento com a quantidade já com sinal: saídas viram
+// negativas, os demais tipos mantêm o sinal informado.
+func NewMovement(tenantID, productID string, movementType MovementType, quantity int, reason string) *Movement {
+	if movementType == TypeExit && quantity > 0 {
+		quantity = -quantity
+	}
+	return &Movement{
+		TenantID:  dbtypes.UUID(tenantID),
+		ProductID: dbtypes.UUID(productID),
+		Type:      movementType,
+		Quantity:  quantity,
+		Reason:    strings.TrimSpace(reason),
+	}
+}
+
+// WithReference associa o documento de origem ao lançamento.
+func (m *Movement) WithReference(referenceType, referenceID string) *Movement {
+	m.ReferenceType = referenceType
+	m.ReferenceID = referenceID
+	return m
+}
+
+// WithUser registra quem fez o lançamento; vazio mantém o lançamento sem usuário.
+func (m *Movement) WithUser(userID string) *Movement {
+	if userID != "" {
+		id := dbtypes.UUID(userID)
+		m.UserID = &id
+	}
+	return m
+}
+
+// StockDelta é quanto o lançamento muda o saldo físico.
+func (m *Movement) StockDelta() int {
+	if !m.Type.AffectsStock() {
+		return 0
+	}
+	return m.Quantity
+}
@@ -0,0 +1,63 @@
+package stock
+
+import "testing"
+
+func TestCreateMovementDTOValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  CreateMovementDTO
+		want error
+	}{
+		{"entry", CreateMovementDTO{Type: TypeEntry, Quantity: 5, Reason: "Compra"}, nil},
+		{"negative adjustment", CreateMovementDTO{Type: TypeAdjustment, Quantity: -2, Reason: "Quebra"}, nil},
+		{"manual release", CreateMovementDTO{Type: TypeReservation, Quantity: -1, Reason: "Orçamento cancelado"}, ErrManualReservation},
+		{"unknown type", CreateMovementDTO{Type: "transfer", Quantity: 1, Reason: "x"}, ErrInvalidType},
+		{"zero quantity", CreateMovementDTO{Type: TypeAdjustment, Quantity: 0, Reason: "x"}, ErrInvalidQuantity},
+		{"negative exit", CreateMovementDTO{Type: TypeExit, Quantity: -1, Reason: "x"}, ErrInvalidQuantity},
+		{"blank reason", CreateMovementDTO{Type: TypeEntry, Quantity: 1, Reason: "  "}, ErrReasonRequired},
+		{"half reference", CreateMovementDTO{Type: TypeEntry, Quantity: 1, Reason: "x", ReferenceType: "invoice"}, ErrReferenceIncomplete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMovementStockDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      MovementType
+		quantity int
+		want     int
+	}{
+		{"entry adds", TypeEntry, 3, 3},
+		{"exit subtracts", TypeExit, 3, -3},
+		{"adjustment keeps sign", TypeAdjustment, -4, -4},
+		{"reservation does not touch stock", TypeReservation, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMovement("t1", "p1", tt.typ, tt.quantity, " motivo ")
+			if got := m.StockDelta(); got != tt.want {
+				t.Errorf("StockDelta() = %d, want %d", got, tt.want)
+			}
+			if m.Reason != "motivo" {
+				t.Errorf("Reason = %q, want trimmed", m.Reason)
+			}
+		})
+	}
+}
+
+func TestMovementWithUser(t *testing.T) {
+	if m := NewMovement("t1", "p1", TypeEntry, 1, "x").WithUser(""); m.UserID != nil {
+		t.Errorf("UserID = %v, want nil for empty user", *m.UserID)
+	}
+	if m := NewMovement("t1", "p1", TypeEntry, 1, "x").WithUser("u1"); m.UserID == nil || *m.UserID != "u1" {
+		t.Errorf("UserID = %v, want u1", m.UserID)
+	}
+}
@@ -8,19 +8,35 @@ import (
 	"strings"
 	"time"
 
+	appointmentDomain "erp-api/internal/domain/appointment"
 	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
 	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	serviceDomain "erp-api/internal/domain/service"
 	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	tenantDomain "erp-api/internal/domain/tenant"
 	userDomain "erp-api/internal/domain/user"
 	"erp-api/internal/infra/database"
 	"erp-api/internal/infra/factory"
 	"erp-api/internal/infra/migrate"
+	appointmentUseCase "erp-api/internal/usecase/appointment"
 	clientUseCase "erp-api/internal/usecase/client"
+	zoneUseCase "erp-api/internal/usecase/deliveryzone"
+	orderUseCase "erp-api/internal/usecase/order"
 	productUseCase "erp-api/internal/usecase/product"
+	productionUseCase "erp-api/internal/usecase/production"
 	quoteUseCase "erp-api/internal/usecase/quote"
+	templateUseCase "erp-api/internal/usecase/quotetemplate"
+	serviceUseCase "erp-api/internal/usecase/service"
 	settingsUseCase "erp-api/internal/usecase/settings"
+	slabUseCase "erp-api/internal/usecase/slab"
+	stockUseCase "erp-api/internal/usecase/stock"
 	tenantUseCase "erp-api/internal/usecase/tenant"
 	userUseCase "erp-api/internal/usecase/user"
 	"erp-api/pkg/auth"
@@ -37,21 +53,47 @@ type Container struct {
 	DB *gorm.DB
 
 	// Repositories
-	TenantRepo      tenantDomain.Repository
-	TenantUseCase   tenantUseCase.UseCaseInterface
-	UserRepo        userDomain.Repository
-	UserUseCase     userUseCase.UseCaseInterface
-	ClientRepo      clientDomain.Repository
-	ClientUseCase   clientUseCase.UseCaseInterface
-	ProductRepo     productDomain.Repository
-	ProductUseCase  productUseCase.UseCaseInterface
-	QuoteRepo       quoteDomain.Repository
-	QuoteItemRepo   quoteDomain.ItemRepository
-	QuoteUseCase    quoteUseCase.UseCaseInterface
-	SettingsRepo    settingsDomain.Repository
-	SettingsUseCase settingsUseCase.UseCaseInterface
-	JWTManager      *auth.JWTManager
-	PassHasher      *auth.PasswordHasher
+	TenantRepo       tenantDomain.Repository
+	TenantUseCase    tenantUseCase.UseCaseInterface
+	UserRepo         userDomain.Repository
+	UserUseCase      userUseCase.UseCaseInterface
+	ClientRepo       clientDomain.Repository
+	ClientUseCase    clientUseCase.UseCaseInterface
+	ProductRepo      productDomain.Repository
+	ProductUseCase   productUseCase.UseCaseInterface
+	QuoteRepo        quoteDomain.Repository
+	QuoteItemRepo    quoteDomain.ItemRepository
+	QuoteOptionRepo  quoteDomain.OptionRepository
+	QuoteHistoryRepo quoteDomain.StatusHistoryRepository
+	QuoteUseCase     quoteUseCase.UseCaseInterface
+	TemplateRepo     templateDomain.Repository
+	TemplateItemRepo templateDomain.ItemRepository
+	TemplateUseCase  templateUseCase.UseCaseInterface
+	ServiceRepo      serviceDomain.Repository
+	ServiceUseCase   serviceUseCase.UseCaseInterface
+	ZoneRepo         zoneDomain.Repository
+	ZoneUseCase      zoneUseCase.UseCaseInterface
+	OrderRepo        orderDomain.Repository
+	OrderItemRepo    orderDomain.ItemRepository
+	OrderUseCase     orderUseCase.UseCaseInterface
+	WorkOrderRepo    productionDomain.Repository
+	WorkStageRepo    productionDomain.StageRepository
+	WorkOrderUseCase productionUseCase.UseCaseInterface
+	TeamRepo         appointmentDomain.TeamRepository
+	AppointmentRepo  appointmentDomain.Repository
+	ScheduleUseCase  appointmentUseCase.UseCaseInterface
+	StockRepo        stockDomain.Repository
+	StockUseCase     stockUseCase.UseCaseInterface
+	SlabRepo         slabDomain.Repository
+	SlabPhotoRepo    slabDomain.PhotoRepository
+	SlabUseCase      slabUseCase.UseCaseInterface
+	SettingsRepo     settingsDomain.Repository
+	SettingsUseCase  settingsUseCase.UseCaseInterface
+	JWTManager       *auth.JWTManager
+	ShareTokens      *auth.ShareTokenManager
+	ShareBaseURL     string
+	CalendarBaseURL  string
+	PassHasher       *auth.PasswordHasher
 }
 
 func NewContainer() *Container {
@@ -245,6 +287,21 @@ func (c *Container) initializeRepositories() error {
 	c.ProductRepo = c.RepoFactory.CreateProductRepository()
 	c.QuoteRepo = c.RepoFactory.CreateQuoteRepository()
 	c.QuoteItemRepo = c.RepoFactory.CreateQuoteItemRepository()
+	c.QuoteOptionRepo = c.RepoFactory.CreateQuoteOptionRepository()
+	c.QuoteHistoryRepo = c.RepoFactory.CreateQuoteStatusHistoryRepository()
+	c.TemplateRepo = c.RepoFactory.CreateQuoteTemplateRepository()
+	c.TemplateItemRepo = c.RepoFactory.CreateQuoteTemplateItemRepository()
+	c.ServiceRepo = c.RepoFactory.CreateServiceRepository()
+	c.ZoneRepo = c.RepoFactory.CreateDeliveryZoneRepository()
+	c.OrderRepo = c.RepoFactory.CreateOrderRepository()
+	c.OrderItemRepo = c.RepoFactory.CreateOrderItemRepository()
+	c.WorkOrderRepo = c.RepoFactory.CreateWorkOrderRepository()
+	c.WorkStageRepo = c.RepoFactory.CreateWorkOrderStageRepository()
+	c.TeamRepo = c.RepoFactory.CreateTeamRepository()
+	c.AppointmentRepo = c.RepoFactory.CreateAppointmentRepository()
+	c.StockRepo = c.RepoFactory.CreateStockMovementRepository()
+	c.SlabRepo = c.RepoFactory.CreateSlabRepository()
+	c.SlabPhotoRepo = c.RepoFactory.CreateSlabPhotoRepository()
 	c.SettingsRepo = c.RepoFactory.CreateSettingsRepository()
 
 	log.Println("Repositories initialized successfully using Factory Pattern")
@@ -259,9 +316,17 @@ func (c *Container) initializeUseCases() error {
 	c.TenantUseCase = tenantUseCase.NewUseCase(c.TenantRepo)
 	c.UserUseCase = userUseCase.NewUseCase(c.UserRepo)
 	c.ClientUseCase = clientUseCase.NewUseCase(c.ClientRepo)
-	c.ProductUseCase = productUseCase.NewUseCase(c.ProductRepo)
-	c.QuoteUseCase = quoteUseCase.NewUseCase(c.QuoteRepo, c.QuoteItemRepo)
-	c.SettingsUseCase = settingsUseCase.NewUseCase(c.SettingsRepo)
+	c.ProductUseCase = productUseCase.NewUseCase(c.ProductRepo, c.StockRepo, c.RepoFactory)
+	c.QuoteUseCase = quoteUseCase.NewUseCase(c.QuoteRepo, c.QuoteItemRepo, c.QuoteOptionRepo, c.QuoteHistoryRepo, c.ProductRepo, c.SlabRepo, c.ServiceRepo, c.ClientRepo, c.ZoneRepo, c.SettingsRepo, c.RepoFactory)
+	c.ServiceUseCase = serviceUseCase.NewUseCase(c.ServiceRepo)
+	c.ZoneUseCase = zoneUseCase.NewUseCase(c.ZoneRepo)
+	c.TemplateUseCase = templateUseCase.NewUseCase(c.TemplateRepo, c.TemplateItemRepo, c.ProductRepo, c.QuoteUseCase, c.RepoFactory)
+	c.OrderUseCase = orderUseCase.NewUseCase(c.OrderRepo, c.OrderItemRepo, c.RepoFactory)
+	c.WorkOrderUseCase = productionUseCase.NewUseCase(c.WorkOrderRepo, c.WorkStageRepo, c.QuoteRepo, c.QuoteItemRepo, c.ProductRepo, c.UserRepo, c.SettingsRepo, c.RepoFactory)
+	c.StockUseCase = stockUseCase.NewUseCase(c.ProductRepo, c.StockRepo, c.RepoFactory)
+	c.SlabUseCase = slabUseCase.NewUseCase(c.SlabRepo, c.SlabPhotoRepo, c.ProductRepo, c.QuoteRepo, c.QuoteItemRepo, c.RepoFactory)
+	c.ScheduleUseCase = appointmentUseCase.NewUseCase(c.TeamRepo, c.AppointmentRepo, c.ClientRepo, c.QuoteRepo, c.OrderRepo, c.RepoFactory)
+	c.SettingsUseCase = settingsUseCase.NewUseCase(c.SettingsRepo, c.RepoFactory)
 	log.Printf("Use cases initialized successfully - TenantRepo: %v, UserRepo: %v, ClientRepo: %v, ProductRepo: %v, QuoteRepo: %v, SettingsRepo: %v, JWTManager: %v, PassHasher: %v",
 		c.TenantRepo != nil, c.UserRepo != nil, c.ClientRepo != nil, c.ProductRepo != nil, c.QuoteRepo != nil, c.SettingsRepo != nil, c.JWTManager != nil, c.PassHasher != nil)
 	return nil
@@ -294,6 +359,34 @@ func (c *Container) initializeAuth() error {
 	}
 
 	c.JWTManager = auth.NewJWTManager(jwtSecret, accessExpiry, refreshExpiry)
+
+	// Links públicos de orçamento exigem um segredo próprio: assinados com o
+	// JWT_SECRET, o mesmo token poderia ser apresentado como token de acesso
+	shareSecret := os.Getenv("QUOTE_SHARE_SECRET")
+	if shareSecret == "" {
+		return fmt.Errorf("QUOTE_SHARE_SECRET is required")
+	}
+	if shareSecret == jwtSecret {
+		return fmt.Errorf("QUOTE_SHARE_SECRET must differ from JWT_SECRET")
+	}
+
+	shareExpiry, err := time.ParseDuration(os.Getenv("QUOTE_SHARE_EXPIRATION"))
+	if err != nil || shareExpiry <= 0 {
+		shareExpiry = 15 * 24 * time.Hour
+	}
+
+	c.ShareTokens = auth.NewShareTokenManager(shareSecret, shareExpiry)
+
+	c.ShareBaseURL = os.Getenv("QUOTE_SHARE_BASE_URL")
+	if c.ShareBaseURL == "" {
+		c.ShareBaseURL = "/public/quotes"
+	}
+
+	// Endereço público dos calendários .ics das equipes
+	c.CalendarBaseURL = os.Getenv("CALENDAR_FEED_BASE_URL")
+	if c.CalendarBaseURL == "" {
+		c.CalendarBaseURL = "/api/v1/public/calendars"
+	}
 	c.PassHasher = auth.DefaultPasswordHasher()
 
 	log.Printf("Auth initialized successfully - JWTManager: %v, PassHasher: %v", c.JWTManager != nil, c.PassHasher != nil)
@@ -347,10 +440,82 @@ func (c *Container) GetQuoteItemRepository() quoteDomain.ItemRepository {
 	return c.QuoteItemRepo
 }
 
+func (c *Container) GetQuoteStatusHistoryRepository() quoteDomain.StatusHistoryRepository {
+	return c.QuoteHistoryRepo
+}
+
 func (c *Container) GetQuoteUseCase() quoteUseCase.UseCaseInterface {
 	return c.QuoteUseCase
 }
 
+func (c *Container) GetQuoteTemplateRepository() templateDomain.Repository {
+	return c.TemplateRepo
+}
+
+func (c *Container) GetQuoteTemplateUseCase() templateUseCase.UseCaseInterface {
+	return c.TemplateUseCase
+}
+
+func (c *Container) GetServiceRepository() serviceDomain.Repository {
+	return c.ServiceRepo
+}
+
+func (c *Container) GetServiceUseCase() serviceUseCase.UseCaseInterface {
+	return c.ServiceUseCase
+}
+
+func (c *Container) GetDeliveryZoneRepository() zoneDomain.Repository {
+	return c.ZoneRepo
+}
+
+func (c *Container) GetDeliveryZoneUseCase() zoneUseCase.UseCaseInterface {
+	return c.ZoneUseCase
+}
+
+func (c *Container) GetOrderRepository() orderDomain.Repository {
+	return c.OrderRepo
+}
+
+func (c *Container) GetOrderUseCase() orderUseCase.UseCaseInterface {
+	return c.OrderUseCase
+}
+
+func (c *Container) GetWorkOrderRepository() productionDomain.Repository {
+	return c.WorkOrderRepo
+}
+
+func (c *Container) GetProductionUseCase() productionUseCase.UseCaseInterface {
+	return c.WorkOrderUseCase
+}
+
+func (c *Container) GetTeamRepository() appointmentDomain.TeamRepository {
+	return c.TeamRepo
+}
+
+func (c *Container) GetAppointmentRepository() appointmentDomain.Repository {
+	return c.AppointmentRepo
+}
+
+func (c *Container) GetAppointmentUseCase() appointmentUseCase.UseCaseInterface {
+	return c.ScheduleUseCase
+}
+
+func (c *Container) GetStockMovementRepository() stockDomain.Repository {
+	return c.StockRepo
+}
+
+func (c *Container) GetStockUseCase() stockUseCase.UseCaseInterface {
+	return c.StockUseCase
+}
+
+func (c *Container) GetSlabRepository() slabDomain.Repository {
+	return c.SlabRepo
+}
+
+func (c *Container) GetSlabUseCase() slabUseCase.UseCaseInterface {
+	return c.SlabUseCase
+}
+
 func (c *Container) GetSettingsRepository() settingsDomain.Repository {
 	return c.SettingsRepo
 }
@@ -371,6 +536,18 @@ func (c *Container) GetJWTManager() *auth.JWTManager {
 	return c.JWTManager
 }
 
+func (c *Container) GetShareTokenManager() *auth.ShareTokenManager {
+	return c.ShareTokens
+}
+
+func (c *Container) GetShareBaseURL() string {
+	return c.ShareBaseURL
+}
+
+func (c *Container) GetCalendarBaseURL() string {
+	return c.CalendarBaseURL
+}
+
 func (c *Container) GetPassHasher() *auth.PasswordHasher {
 	return c.PassHasher
 }
@@ -5,10 +5,18 @@ import (
 	"database/sql"
 	"time"
 
+	appointmentDomain "erp-api/internal/domain/appointment"
 	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
 	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	serviceDomain "erp-api/internal/domain/service"
 	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	tenantDomain "erp-api/internal/domain/tenant"
 	userDomain "erp-api/internal/domain/user"
 )
@@ -33,15 +41,42 @@ type Database interface {
 	GetSQLDB() (*sql.DB, error)
 }
 
+// UnitOfWork runs writes spanning several repositories atomically.
+//
+// The factory handed to fn is bound to the transaction: every repository it
+// creates shares it. Returning an error (or panicking) from fn rolls back all
+// writes; returning nil commits them.
+type UnitOfWork interface {
+	Transaction(ctx context.Context, fn func(tx RepositoryFactory) error) error
+}
+
 // RepositoryFactory interface for creating repositories
 // This allows different database implementations to provide their own repository factories
 type RepositoryFactory interface {
+	UnitOfWork
+
 	CreateTenantRepository() tenantDomain.Repository
 	CreateUserRepository() userDomain.Repository
 	CreateClientRepository() clientDomain.Repository
 	CreateProductRepository() productDomain.Repository
 	CreateQuoteRepository() quoteDomain.Repository
 	CreateQuoteItemRepository() quoteDomain.ItemRepository
+	CreateQuoteOptionRepository() quoteDomain.OptionRepository
+	CreateQuoteStatusHistoryRepository() quoteDomain.StatusHistoryRepository
+	CreateQuoteSequenceRepository() quoteDomain.SequenceRepository
+	CreateQuoteTemplateRepository() templateDomain.Repository
+	CreateQuoteTemplateItemRepository() templateDomain.ItemRepository
+	CreateServiceRepository() serviceDomain.Repository
+	CreateDeliveryZoneRepository() zoneDomain.Repository
+	CreateOrderRepository() orderDomain.Repository
+	CreateOrderItemRepository() orderDomain.ItemRepository
+	CreateWorkOrderRepository() productionDomain.Repository
+	CreateWorkOrderStageRepository() productionDomain.StageRepository
+	CreateTeamRepository() appointmentDomain.TeamRepository
+	CreateAppointmentRepository() appointmentDomain.Repository
+	CreateStockMovementRepository() stockDomain.Repository
+	CreateSlabRepository() slabDomain.Repository
+	CreateSlabPhotoRepository() slabDomain.PhotoRepository
 	CreateSettingsRepository() settingsDomain.Repository
 
 	// Get the underlying database instance
@@ -1,12 +1,21 @@
 package factory
 
 import (
+	"context"
 	"fmt"
 
+	appointmentDomain "erp-api/internal/domain/appointment"
 	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
 	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	serviceDomain "erp-api/internal/domain/service"
 	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	tenantDomain "erp-api/internal/domain/tenant"
 	userDomain "erp-api/internal/domain/user"
 	"erp-api/internal/infra/database"
@@ -18,6 +27,7 @@ import (
 // PostgreSQLFactory implements RepositoryFactory for PostgreSQL using GORM
 type PostgreSQLFactory struct {
 	db database.Database
+	tx *gorm.DB // set on factories handed out by Transaction
 }
 
 // NewPostgreSQLFactory creates a new PostgreSQL repository factory
@@ -38,6 +48,10 @@ func (f *PostgreSQLFactory) GetDatabase() database.Database {
 
 // getGormDB extracts the GORM DB instance from the Database interface
 func (f *PostgreSQLFactory) getGormDB() (*gorm.DB, error) {
+	if f.tx != nil {
+		return f.tx, nil
+	}
+
 	dbInstance := f.db.GetDB()
 	if dbInstance == nil {
 		return nil, fmt.Errorf("database instance is nil")
@@ -51,6 +65,19 @@ func (f *PostgreSQLFactory) getGormDB() (*gorm.DB, error) {
 	return gormDB, nil
 }
 
+// Transaction runs fn inside a database transaction. Repositories created from
+// the factory passed to fn share the transaction; nested calls use savepoints
+func (f *PostgreSQLFactory) Transaction(ctx context.Context, fn func(tx database.RepositoryFactory) error) error {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		return err
+	}
+
+	return gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PostgreSQLFactory{db: f.db, tx: tx})
+	})
+}
+
 func (f *PostgreSQLFactory) CreateTenantRepository() tenantDomain.Repository {
 	gormDB, err := f.getGormDB()
 	if err != nil {
@@ -104,6 +131,150 @@ func (f *PostgreSQLFactory) CreateQuoteItemRepository() quoteDomain.ItemReposito
 	return repository.NewQuoteItemRepository(gormDB)
 }
 
+// CreateQuoteOptionRepository creates a quote option repository
+func (f *PostgreSQLFactory) CreateQuoteOptionRepository() quoteDomain.OptionRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewQuoteOptionRepository(gormDB)
+}
+
+// CreateQuoteStatusHistoryRepository creates a quote status history repository
+func (f *PostgreSQLFactory) CreateQuoteStatusHistoryRepository() quoteDomain.StatusHistoryRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewQuoteStatusHistoryRepository(gormDB)
+}
+
+// CreateQuoteSequenceRepository creates a quote number sequence repository
+func (f *PostgreSQLFactory) CreateQuoteSequenceRepository() quoteDomain.SequenceRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewQuoteSequenceRepository(gormDB)
+}
+
+// CreateQuoteTemplateRepository creates a quote template repository
+func (f *PostgreSQLFactory) CreateQuoteTemplateRepository() templateDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewQuoteTemplateRepository(gormDB)
+}
+
+// CreateQuoteTemplateItemRepository creates a quote template item repository
+func (f *PostgreSQLFactory) CreateQuoteTemplateItemRepository() templateDomain.ItemRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewQuoteTemplateItemRepository(gormDB)
+}
+
+// CreateServiceRepository creates a service catalog repository
+func (f *PostgreSQLFactory) CreateServiceRepository() serviceDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewServiceRepository(gormDB)
+}
+
+// CreateDeliveryZoneRepository creates a delivery zone repository
+func (f *PostgreSQLFactory) CreateDeliveryZoneRepository() zoneDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewDeliveryZoneRepository(gormDB)
+}
+
+// CreateOrderRepository creates an order repository
+func (f *PostgreSQLFactory) CreateOrderRepository() orderDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewOrderRepository(gormDB)
+}
+
+// CreateOrderItemRepository creates an order item repository
+func (f *PostgreSQLFactory) CreateOrderItemRepository() orderDomain.ItemRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewOrderItemRepository(gormDB)
+}
+
+// CreateWorkOrderRepository creates a work order repository
+func (f *PostgreSQLFactory) CreateWorkOrderRepository() productionDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewWorkOrderRepository(gormDB)
+}
+
+// CreateWorkOrderStageRepository creates a work order stage repository
+func (f *PostgreSQLFactory) CreateWorkOrderStageRepository() productionDomain.StageRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewWorkOrderStageRepository(gormDB)
+}
+
+// CreateTeamRepository creates a team repository
+func (f *PostgreSQLFactory) CreateTeamRepository() appointmentDomain.TeamRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewTeamRepository(gormDB)
+}
+
+// CreateAppointmentRepository creates an appointment repository
+func (f *PostgreSQLFactory) CreateAppointmentRepository() appointmentDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewAppointmentRepository(gormDB)
+}
+
+// CreateStockMovementRepository creates a stock movement repository
+func (f *PostgreSQLFactory) CreateStockMovementRepository() stockDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewStockMovementRepository(gormDB)
+}
+
+// CreateSlabRepository creates a slab repository
+func (f *PostgreSQLFactory) CreateSlabRepository() slabDomain.Repository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewSlabRepository(gormDB)
+}
+
+// CreateSlabPhotoRepository creates a slab photo repository
+func (f *PostgreSQLFactory) CreateSlabPhotoRepository() slabDomain.PhotoRepository {
+	gormDB, err := f.getGormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to get GORM DB: %v", err))
+	}
+	return repository.NewSlabPhotoRepository(gormDB)
+}
+
 // CreateSettingsRepository creates a settings repository
 func (f *PostgreSQLFactory) CreateSettingsRepository() settingsDomain.Repository {
 	gormDB, err := f.getGormDB()
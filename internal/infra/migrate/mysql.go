@@ -6,11 +6,19 @@ import (
 	"strconv"
 	"strings"
 
+	appointmentDomain "erp-api/internal/domain/appointment"
 	auditDomain "erp-api/internal/domain/audit"
 	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
 	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	serviceDomain "erp-api/internal/domain/service"
 	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	tenantDomain "erp-api/internal/domain/tenant"
 	userDomain "erp-api/internal/domain/user"
 
@@ -30,14 +38,32 @@ func (m *MySQLMigrator) Run(db *gorm.DB) error {
 		return fmt.Errorf("failed to ensure tenants.company_name: %w", err)
 	}
 
+	prepareQuoteNumbersMySQL(db)
+
 	if err := db.AutoMigrate(
 		&auditDomain.Audit{},
 		&tenantDomain.Tenant{},
 		&userDomain.User{},
 		&clientDomain.Client{},
 		&productDomain.Product{},
+		&serviceDomain.Service{},
+		&zoneDomain.DeliveryZone{},
 		&quoteDomain.Quote{},
+		&quoteDomain.QuoteOption{},
 		&quoteDomain.QuoteItem{},
+		&quoteDomain.QuoteStatusHistory{},
+		&quoteDomain.QuoteSequence{},
+		&templateDomain.QuoteTemplate{},
+		&templateDomain.QuoteTemplateItem{},
+		&orderDomain.Order{},
+		&orderDomain.OrderItem{},
+		&productionDomain.WorkOrder{},
+		&productionDomain.WorkOrderStage{},
+		&appointmentDomain.Team{},
+		&appointmentDomain.Appointment{},
+		&stockDomain.Movement{},
+		&slabDomain.Slab{},
+		&slabDomain.Photo{},
 		&settingsDomain.Settings{},
 	); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
@@ -45,6 +71,9 @@ func (m *MySQLMigrator) Run(db *gorm.DB) error {
 
 	createForeignKeysMySQL(db)
 	createGeneratedColumnsAndIndexesMySQL(db)
+	backfillQuoteDiscountsMySQL(db)
+	backfillStockLedgerMySQL(db)
+	backfillQuoteNumbersMySQL(db)
 
 	log.Println("Database migrations completed successfully (mysql)")
 	return nil
@@ -118,6 +147,58 @@ func createForeignKeysMySQL(db *gorm.DB) {
 	addFKIfMissing(db, "quote_items", "fk_quote_items_tenant", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
 	addFKIfMissing(db, "quote_items", "fk_quote_items_quote", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE")
 	addFKIfMissing(db, "quote_items", "fk_quote_items_product", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_product FOREIGN KEY (product_id) REFERENCES products(id)")
+	addFKIfMissing(db, "quote_items", "fk_quote_items_service", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_service FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE SET NULL")
+
+	addFKIfMissing(db, "services", "fk_services_tenant", "ALTER TABLE services ADD CONSTRAINT fk_services_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "delivery_zones", "fk_delivery_zones_tenant", "ALTER TABLE delivery_zones ADD CONSTRAINT fk_delivery_zones_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+
+	addFKIfMissing(db, "quote_status_history", "fk_quote_status_history_tenant", "ALTER TABLE quote_status_history ADD CONSTRAINT fk_quote_status_history_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "quote_status_history", "fk_quote_status_history_quote", "ALTER TABLE quote_status_history ADD CONSTRAINT fk_quote_status_history_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE")
+
+	addFKIfMissing(db, "quote_options", "fk_quote_options_tenant", "ALTER TABLE quote_options ADD CONSTRAINT fk_quote_options_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "quote_options", "fk_quote_options_quote", "ALTER TABLE quote_options ADD CONSTRAINT fk_quote_options_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "quote_items", "fk_quote_items_option", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_option FOREIGN KEY (option_id) REFERENCES quote_options(id) ON DELETE CASCADE")
+
+	addFKIfMissing(db, "quote_sequences", "fk_quote_sequences_tenant", "ALTER TABLE quote_sequences ADD CONSTRAINT fk_quote_sequences_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+
+	addFKIfMissing(db, "quote_templates", "fk_quote_templates_tenant", "ALTER TABLE quote_templates ADD CONSTRAINT fk_quote_templates_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+
+	addFKIfMissing(db, "quote_template_items", "fk_quote_template_items_tenant", "ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "quote_template_items", "fk_quote_template_items_template", "ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_template FOREIGN KEY (template_id) REFERENCES quote_templates(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "quote_template_items", "fk_quote_template_items_product", "ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_product FOREIGN KEY (product_id) REFERENCES products(id)")
+
+	addFKIfMissing(db, "orders", "fk_orders_tenant", "ALTER TABLE orders ADD CONSTRAINT fk_orders_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "orders", "fk_orders_quote", "ALTER TABLE orders ADD CONSTRAINT fk_orders_quote FOREIGN KEY (quote_id) REFERENCES quotes(id)")
+	addFKIfMissing(db, "orders", "fk_orders_client", "ALTER TABLE orders ADD CONSTRAINT fk_orders_client FOREIGN KEY (client_id) REFERENCES clients(id)")
+	addFKIfMissing(db, "orders", "fk_orders_user", "ALTER TABLE orders ADD CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users(id)")
+
+	addFKIfMissing(db, "order_items", "fk_order_items_tenant", "ALTER TABLE order_items ADD CONSTRAINT fk_order_items_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "order_items", "fk_order_items_order", "ALTER TABLE order_items ADD CONSTRAINT fk_order_items_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "order_items", "fk_order_items_product", "ALTER TABLE order_items ADD CONSTRAINT fk_order_items_product FOREIGN KEY (product_id) REFERENCES products(id)")
+
+	addFKIfMissing(db, "work_orders", "fk_work_orders_tenant", "ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "work_orders", "fk_work_orders_quote", "ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "work_orders", "fk_work_orders_quote_item", "ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_quote_item FOREIGN KEY (quote_item_id) REFERENCES quote_items(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "work_order_stages", "fk_work_order_stages_tenant", "ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "work_order_stages", "fk_work_order_stages_work_order", "ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_work_order FOREIGN KEY (work_order_id) REFERENCES work_orders(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "work_order_stages", "fk_work_order_stages_assignee", "ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "teams", "fk_teams_tenant", "ALTER TABLE teams ADD CONSTRAINT fk_teams_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "appointments", "fk_appointments_tenant", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "appointments", "fk_appointments_team", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_team FOREIGN KEY (team_id) REFERENCES teams(id)")
+	addFKIfMissing(db, "appointments", "fk_appointments_client", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_client FOREIGN KEY (client_id) REFERENCES clients(id)")
+	addFKIfMissing(db, "appointments", "fk_appointments_quote", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "appointments", "fk_appointments_order", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "appointments", "fk_appointments_created_by", "ALTER TABLE appointments ADD CONSTRAINT fk_appointments_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "stock_movements", "fk_stock_movements_tenant", "ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "stock_movements", "fk_stock_movements_product", "ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT")
+	addFKIfMissing(db, "stock_movements", "fk_stock_movements_user", "ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "slabs", "fk_slabs_tenant", "ALTER TABLE slabs ADD CONSTRAINT fk_slabs_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "slabs", "fk_slabs_product", "ALTER TABLE slabs ADD CONSTRAINT fk_slabs_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "slabs", "fk_slabs_parent", "ALTER TABLE slabs ADD CONSTRAINT fk_slabs_parent FOREIGN KEY (parent_id) REFERENCES slabs(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "slabs", "fk_slabs_quote", "ALTER TABLE slabs ADD CONSTRAINT fk_slabs_quote FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "quote_items", "fk_quote_items_slab", "ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_slab FOREIGN KEY (slab_id) REFERENCES slabs(id) ON DELETE SET NULL")
+	addFKIfMissing(db, "slab_photos", "fk_slab_photos_tenant", "ALTER TABLE slab_photos ADD CONSTRAINT fk_slab_photos_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
+	addFKIfMissing(db, "slab_photos", "fk_slab_photos_slab", "ALTER TABLE slab_photos ADD CONSTRAINT fk_slab_photos_slab FOREIGN KEY (slab_id) REFERENCES slabs(id) ON DELETE CASCADE")
 
 	addFKIfMissing(db, "settings", "fk_settings_tenant", "ALTER TABLE settings ADD CONSTRAINT fk_settings_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE")
 }
@@ -254,3 +335,104 @@ func parseMySQLVersion(v string) (major, minor, patch int) {
 	}
 	return major, minor, patch
 }
+
+// backfillQuoteDiscountsMySQL preenche o desconto em R$ e o percentual efetivo dos
+// orçamentos anteriores aos tipos de desconto, quando o desconto era sempre absoluto.
+func backfillQuoteDiscountsMySQL(db *gorm.DB) {
+	if err := db.Exec(`
+		UPDATE quotes SET discount_amount = discount
+		WHERE discount_amount = 0 AND discount > 0 AND (discount_type IS NULL OR discount_type = 'amount')
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill quotes.discount_amount: %v", err)
+	}
+
+	if err := db.Exec(`
+		UPDATE quotes SET discount_percent = ROUND(discount_amount * 100 / subtotal, 2)
+		WHERE discount_percent = 0 AND discount_amount > 0 AND subtotal > 0
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill quotes.discount_percent: %v", err)
+	}
+}
+
+// backfillStockLedgerMySQL lança o saldo dos produtos anteriores ao livro de
+// estoque como saldo inicial, para o livro bater com products.stock.
+func backfillStockLedgerMySQL(db *gorm.DB) {
+	if err := db.Exec(`
+		INSERT INTO stock_movements (id, tenant_id, product_id, type, quantity, balance, reason, created_at)
+		SELECT UUID(), p.tenant_id, p.id, 'adjustment', p.stock, p.stock, 'Saldo inicial', NOW()
+		FROM products p
+		WHERE p.stock <> 0
+		AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id)
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill stock_movements: %v", err)
+	}
+}
+
+// prepareQuoteNumbersMySQL deixa os números dos orçamentos prontos para o índice
+// único (tenant_id, number): número vazio vira NULL, que o índice aceita repetido, e
+// o índice antigo, não único e com o mesmo nome, é removido para o AutoMigrate recriá-lo.
+func prepareQuoteNumbersMySQL(db *gorm.DB) {
+	exists, err := mysqlColumnExists(db, "quotes", "number")
+	if err != nil || !exists {
+		return
+	}
+
+	if err := db.Exec(`UPDATE quotes SET number = NULL WHERE number = ''`).Error; err != nil {
+		log.Printf("Warning: could not clear empty quotes.number: %v", err)
+	}
+
+	var nonUnique int
+	if err := db.Raw(`
+		SELECT COUNT(*) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = 'quotes'
+		AND index_name = 'idx_quotes_tenant_number' AND non_unique = 1
+	`).Scan(&nonUnique).Error; err != nil {
+		log.Printf("Warning: could not inspect idx_quotes_tenant_number: %v", err)
+		return
+	}
+	if nonUnique > 0 {
+		if err := db.Exec("DROP INDEX idx_quotes_tenant_number ON quotes").Error; err != nil {
+			log.Printf("Warning: could not drop idx_quotes_tenant_number: %v", err)
+		}
+	}
+}
+
+// backfillQuoteNumbersMySQL numera os orçamentos anteriores à numeração no formato
+// padrão (ORC-2026-000123), pela ordem de criação em cada tenant e ano, continuando a
+// sequência do ano. Depois avança as sequências, para a próxima emissão não repetir
+// um número gerado aqui.
+func backfillQuoteNumbersMySQL(db *gorm.DB) {
+	result := db.Exec(`
+		UPDATE quotes q
+		JOIN (
+			SELECT q2.id, YEAR(q2.created_at) AS yr,
+				COALESCE(s.last_value, 0) + ROW_NUMBER() OVER (
+					PARTITION BY q2.tenant_id, YEAR(q2.created_at)
+					ORDER BY q2.created_at, q2.id
+				) AS seq
+			FROM quotes q2
+			LEFT JOIN quote_sequences s
+				ON s.tenant_id = q2.tenant_id AND s.scope = CAST(YEAR(q2.created_at) AS CHAR)
+			WHERE q2.number IS NULL
+		) n ON n.id = q.id
+		SET q.number = CONCAT('ORC-', n.yr, '-', LPAD(n.seq, 6, '0'))
+	`)
+	if result.Error != nil {
+		log.Printf("Warning: could not backfill quotes.number: %v", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		return
+	}
+
+	if err := db.Exec(`
+		INSERT INTO quote_sequences (tenant_id, scope, last_value, updated_at)
+		SELECT tenant_id, SUBSTRING(number, 5, 4), MAX(CAST(SUBSTRING(number, 10) AS UNSIGNED)), NOW()
+		FROM quotes
+		WHERE number REGEXP '^ORC-[0-9]{4}-[0-9]{6}$'
+		GROUP BY tenant_id, SUBSTRING(number, 5, 4)
+		ON DUPLICATE KEY UPDATE last_value = GREATEST(last_value, VALUES(last_value)), updated_at = VALUES(updated_at)
+	`).Error; err != nil {
+		log.Printf("Warning: could not advance quote_sequences after the number backfill: %v", err)
+	}
+}
@@ -4,11 +4,19 @@ import (
 	"fmt"
 	"log"
 
+	appointmentDomain "erp-api/internal/domain/appointment"
 	auditDomain "erp-api/internal/domain/audit"
 	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
 	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	serviceDomain "erp-api/internal/domain/service"
 	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
 	tenantDomain "erp-api/internal/domain/tenant"
 	userDomain "erp-api/internal/domain/user"
 
@@ -35,14 +43,32 @@ func (m *PostgresMigrator) Run(db *gorm.DB) error {
 		return fmt.Errorf("failed to ensure tenants.company_name: %w", err)
 	}
 
+	prepareQuoteNumbersPostgres(db)
+
 	if err := db.AutoMigrate(
 		&auditDomain.Audit{},
 		&tenantDomain.Tenant{},
 		&userDomain.User{},
 		&clientDomain.Client{},
 		&productDomain.Product{},
+		&serviceDomain.Service{},
+		&zoneDomain.DeliveryZone{},
 		&quoteDomain.Quote{},
+		&quoteDomain.QuoteOption{},
 		&quoteDomain.QuoteItem{},
+		&quoteDomain.QuoteStatusHistory{},
+		&quoteDomain.QuoteSequence{},
+		&templateDomain.QuoteTemplate{},
+		&templateDomain.QuoteTemplateItem{},
+		&orderDomain.Order{},
+		&orderDomain.OrderItem{},
+		&productionDomain.WorkOrder{},
+		&productionDomain.WorkOrderStage{},
+		&appointmentDomain.Team{},
+		&appointmentDomain.Appointment{},
+		&stockDomain.Movement{},
+		&slabDomain.Slab{},
+		&slabDomain.Photo{},
 		&settingsDomain.Settings{},
 	); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
@@ -50,6 +76,9 @@ func (m *PostgresMigrator) Run(db *gorm.DB) error {
 
 	createForeignKeysPostgres(db)
 	createGeneratedColumnsAndIndexesPostgres(db)
+	backfillQuoteDiscountsPostgres(db)
+	backfillStockLedgerPostgres(db)
+	backfillQuoteNumbersPostgres(db)
 
 	log.Println("Database migrations completed successfully (postgres)")
 	return nil
@@ -210,6 +239,360 @@ func createForeignKeysPostgres(db *gorm.DB) {
 				ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_product 
 				FOREIGN KEY (product_id) REFERENCES products(id);
 			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_items_service'
+			) THEN
+				ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_service 
+				FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE SET NULL;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_services_tenant'
+			) THEN
+				ALTER TABLE services ADD CONSTRAINT fk_services_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_delivery_zones_tenant'
+			) THEN
+				ALTER TABLE delivery_zones ADD CONSTRAINT fk_delivery_zones_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_status_history_tenant'
+			) THEN
+				ALTER TABLE quote_status_history ADD CONSTRAINT fk_quote_status_history_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_status_history_quote'
+			) THEN
+				ALTER TABLE quote_status_history ADD CONSTRAINT fk_quote_status_history_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_options_tenant'
+			) THEN
+				ALTER TABLE quote_options ADD CONSTRAINT fk_quote_options_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_options_quote'
+			) THEN
+				ALTER TABLE quote_options ADD CONSTRAINT fk_quote_options_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_items_option'
+			) THEN
+				ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_option 
+				FOREIGN KEY (option_id) REFERENCES quote_options(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_sequences_tenant'
+			) THEN
+				ALTER TABLE quote_sequences ADD CONSTRAINT fk_quote_sequences_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_templates_tenant'
+			) THEN
+				ALTER TABLE quote_templates ADD CONSTRAINT fk_quote_templates_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_template_items_tenant'
+			) THEN
+				ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_template_items_template'
+			) THEN
+				ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_template 
+				FOREIGN KEY (template_id) REFERENCES quote_templates(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_template_items_product'
+			) THEN
+				ALTER TABLE quote_template_items ADD CONSTRAINT fk_quote_template_items_product 
+				FOREIGN KEY (product_id) REFERENCES products(id);
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_orders_tenant'
+			) THEN
+				ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_orders_quote'
+			) THEN
+				ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_orders_quote_item'
+			) THEN
+				ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_quote_item 
+				FOREIGN KEY (quote_item_id) REFERENCES quote_items(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_order_stages_tenant'
+			) THEN
+				ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_order_stages_work_order'
+			) THEN
+				ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_work_order 
+				FOREIGN KEY (work_order_id) REFERENCES work_orders(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_work_order_stages_assignee'
+			) THEN
+				ALTER TABLE work_order_stages ADD CONSTRAINT fk_work_order_stages_assignee 
+				FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_teams_tenant'
+			) THEN
+				ALTER TABLE teams ADD CONSTRAINT fk_teams_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_tenant'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_team'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_team 
+				FOREIGN KEY (team_id) REFERENCES teams(id);
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_client'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_client 
+				FOREIGN KEY (client_id) REFERENCES clients(id);
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_quote'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE SET NULL;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_order'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_order 
+				FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_appointments_created_by'
+			) THEN
+				ALTER TABLE appointments ADD CONSTRAINT fk_appointments_created_by 
+				FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_stock_movements_tenant'
+			) THEN
+				ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_stock_movements_product'
+			) THEN
+				ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_product 
+				FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_stock_movements_user'
+			) THEN
+				ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_user 
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slabs_tenant'
+			) THEN
+				ALTER TABLE slabs ADD CONSTRAINT fk_slabs_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slabs_product'
+			) THEN
+				ALTER TABLE slabs ADD CONSTRAINT fk_slabs_product 
+				FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slabs_parent'
+			) THEN
+				ALTER TABLE slabs ADD CONSTRAINT fk_slabs_parent 
+				FOREIGN KEY (parent_id) REFERENCES slabs(id) ON DELETE SET NULL;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slabs_quote'
+			) THEN
+				ALTER TABLE slabs ADD CONSTRAINT fk_slabs_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id) ON DELETE SET NULL;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_quote_items_slab'
+			) THEN
+				ALTER TABLE quote_items ADD CONSTRAINT fk_quote_items_slab 
+				FOREIGN KEY (slab_id) REFERENCES slabs(id) ON DELETE SET NULL;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slab_photos_tenant'
+			) THEN
+				ALTER TABLE slab_photos ADD CONSTRAINT fk_slab_photos_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_slab_photos_slab'
+			) THEN
+				ALTER TABLE slab_photos ADD CONSTRAINT fk_slab_photos_slab 
+				FOREIGN KEY (slab_id) REFERENCES slabs(id) ON DELETE CASCADE;
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_orders_tenant'
+			) THEN
+				ALTER TABLE orders ADD CONSTRAINT fk_orders_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_orders_quote'
+			) THEN
+				ALTER TABLE orders ADD CONSTRAINT fk_orders_quote 
+				FOREIGN KEY (quote_id) REFERENCES quotes(id);
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_orders_client'
+			) THEN
+				ALTER TABLE orders ADD CONSTRAINT fk_orders_client 
+				FOREIGN KEY (client_id) REFERENCES clients(id);
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_orders_user'
+			) THEN
+				ALTER TABLE orders ADD CONSTRAINT fk_orders_user 
+				FOREIGN KEY (user_id) REFERENCES users(id);
+			END IF;
+		END $$;
+	`)
+
+	db.Exec(`
+		DO $$ 
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_order_items_tenant'
+			) THEN
+				ALTER TABLE order_items ADD CONSTRAINT fk_order_items_tenant 
+				FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_order_items_order'
+			) THEN
+				ALTER TABLE order_items ADD CONSTRAINT fk_order_items_order 
+				FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conname = 'fk_order_items_product'
+			) THEN
+				ALTER TABLE order_items ADD CONSTRAINT fk_order_items_product 
+				FOREIGN KEY (product_id) REFERENCES products(id);
+			END IF;
 		END $$;
 	`)
 
@@ -274,3 +657,102 @@ func createGeneratedColumnsAndIndexesPostgres(db *gorm.DB) {
 		END $$;
 	`)
 }
+
+// backfillQuoteDiscountsPostgres preenche o desconto em R$ e o percentual efetivo dos
+// orçamentos anteriores aos tipos de desconto, quando o desconto era sempre absoluto.
+func backfillQuoteDiscountsPostgres(db *gorm.DB) {
+	if err := db.Exec(`
+		UPDATE quotes SET discount_amount = discount
+		WHERE discount_amount = 0 AND discount > 0 AND (discount_type IS NULL OR discount_type = 'amount')
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill quotes.discount_amount: %v", err)
+	}
+
+	if err := db.Exec(`
+		UPDATE quotes SET discount_percent = ROUND(discount_amount * 100 / subtotal, 2)
+		WHERE discount_percent = 0 AND discount_amount > 0 AND subtotal > 0
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill quotes.discount_percent: %v", err)
+	}
+}
+
+// backfillStockLedgerPostgres lança o saldo dos produtos anteriores ao livro de
+// estoque como saldo inicial, para o livro bater com products.stock.
+func backfillStockLedgerPostgres(db *gorm.DB) {
+	if err := db.Exec(`
+		INSERT INTO stock_movements (id, tenant_id, product_id, type, quantity, balance, reason, created_at)
+		SELECT gen_random_uuid(), p.tenant_id, p.id, 'adjustment', p.stock, p.stock, 'Saldo inicial', NOW()
+		FROM products p
+		WHERE p.stock <> 0
+		AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id)
+	`).Error; err != nil {
+		log.Printf("Warning: could not backfill stock_movements: %v", err)
+	}
+}
+
+// prepareQuoteNumbersPostgres deixa os números dos orçamentos prontos para o índice
+// único (tenant_id, number): número vazio vira NULL, que o índice aceita repetido, e
+// o índice antigo, não único e com o mesmo nome, é removido para o AutoMigrate recriá-lo.
+func prepareQuoteNumbersPostgres(db *gorm.DB) {
+	if err := db.Exec(`
+		DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = 'public' AND table_name = 'quotes' AND column_name = 'number'
+			) THEN
+				UPDATE quotes SET number = NULL WHERE number = '';
+			END IF;
+			IF EXISTS (
+				SELECT 1 FROM pg_indexes
+				WHERE schemaname = 'public' AND indexname = 'idx_quotes_tenant_number'
+				AND indexdef NOT LIKE 'CREATE UNIQUE%'
+			) THEN
+				DROP INDEX idx_quotes_tenant_number;
+			END IF;
+		END $$;
+	`).Error; err != nil {
+		log.Printf("Warning: could not prepare quotes.number for the unique index: %v", err)
+	}
+}
+
+// backfillQuoteNumbersPostgres numera os orçamentos anteriores à numeração no formato
+// padrão (ORC-2026-000123), pela ordem de criação em cada tenant e ano, continuando a
+// sequência do ano. Depois avança as sequências, para a próxima emissão não repetir
+// um número gerado aqui.
+func backfillQuoteNumbersPostgres(db *gorm.DB) {
+	result := db.Exec(`
+		UPDATE quotes SET number = 'ORC-' || n.year || '-' || LPAD(n.seq::text, 6, '0')
+		FROM (
+			SELECT q.id, EXTRACT(YEAR FROM q.created_at)::int AS year,
+				COALESCE(s.last_value, 0) + ROW_NUMBER() OVER (
+					PARTITION BY q.tenant_id, EXTRACT(YEAR FROM q.created_at)
+					ORDER BY q.created_at, q.id
+				) AS seq
+			FROM quotes q
+			LEFT JOIN quote_sequences s
+				ON s.tenant_id = q.tenant_id AND s.scope = EXTRACT(YEAR FROM q.created_at)::int::text
+			WHERE q.number IS NULL
+		) n
+		WHERE quotes.id = n.id
+	`)
+	if result.Error != nil {
+		log.Printf("Warning: could not backfill quotes.number: %v", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		return
+	}
+
+	if err := db.Exec(`
+		INSERT INTO quote_sequences (tenant_id, scope, last_value, updated_at)
+		SELECT tenant_id, SUBSTRING(number FROM 5 FOR 4), MAX(CAST(SUBSTRING(number FROM 10) AS bigint)), NOW()
+		FROM quotes
+		WHERE number ~ '^ORC-[0-9]{4}-[0-9]{6}$'
+		GROUP BY tenant_id, SUBSTRING(number FROM 5 FOR 4)
+		ON CONFLICT (tenant_id, scope) DO UPDATE
+		SET last_value = GREATEST(quote_sequences.last_value, EXCLUDED.last_value), updated_at = EXCLUDED.updated_at
+	`).Error; err != nil {
+		log.Printf("Warning: could not advance quote_sequences after the number backfill: %v", err)
+	}
+}
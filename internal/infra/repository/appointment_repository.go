@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AppointmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAppointmentRepository(db *gorm.DB) appointmentDomain.Repository {
+	return &AppointmentRepository{db: db}
+}
+
+func (r *AppointmentRepository) Create(ctx context.Context, appointment *appointmentDomain.Appointment) error {
+	result := r.db.WithContext(ctx).Create(appointment)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *AppointmentRepository) GetByID(ctx context.Context, tenantID, id string) (*appointmentDomain.Appointment, error) {
+	var appointment appointmentDomain.Appointment
+
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&appointment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, appointmentDomain.ErrAppointmentNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &appointment, nil
+}
+
+func (r *AppointmentRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*appointmentDomain.Appointment, error) {
+	var appointment appointmentDomain.Appointment
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&appointment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, appointmentDomain.ErrAppointmentNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &appointment, nil
+}
+
+func (r *AppointmentRepository) Update(ctx context.Context, appointment *appointmentDomain.Appointment) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", appointment.ID, appointment.TenantID).
+		Save(appointment)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return appointmentDomain.ErrAppointmentNotFound
+	}
+
+	return nil
+}
+
+func (r *AppointmentRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&appointmentDomain.Appointment{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return appointmentDomain.ErrAppointmentNotFound
+	}
+
+	return nil
+}
+
+func (r *AppointmentRepository) List(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter, limit, offset int) ([]*appointmentDomain.Appointment, error) {
+	var appointments []*appointmentDomain.Appointment
+
+	result := r.filtered(ctx, tenantID, filter).
+		Order("starts_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&appointments)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return appointments, nil
+}
+
+func (r *AppointmentRepository) Count(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter) (int, error) {
+	var count int64
+
+	result := r.filtered(ctx, tenantID, filter).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *AppointmentRepository) ListOverlapping(ctx context.Context, tenantID, teamID string, startsAt, endsAt time.Time, excludeID string) ([]*appointmentDomain.Appointment, error) {
+	var appointments []*appointmentDomain.Appointment
+
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND team_id = ? AND status <> ?", tenantID, teamID, appointmentDomain.StatusCancelled).
+		Where("starts_at < ? AND ends_at > ?", endsAt, startsAt)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	result := query.Order("starts_at ASC").Find(&appointments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return appointments, nil
+}
+
+func (r *AppointmentRepository) ListForFeed(ctx context.Context, tenantID, teamID string, since time.Time) ([]*appointmentDomain.Appointment, error) {
+	var appointments []*appointmentDomain.Appointment
+
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND team_id = ? AND status <> ?", tenantID, teamID, appointmentDomain.StatusCancelled).
+		Where("ends_at > ?", since).
+		Order("starts_at ASC").
+		Find(&appointments)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return appointments, nil
+}
+
+func (r *AppointmentRepository) filtered(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&appointmentDomain.Appointment{}).Where("tenant_id = ?", tenantID)
+
+	if filter.TeamID != "" {
+		query = query.Where("team_id = ?", filter.TeamID)
+	}
+	if filter.ClientID != "" {
+		query = query.Where("client_id = ?", filter.ClientID)
+	}
+	if filter.QuoteID != "" {
+		query = query.Where("quote_id = ?", filter.QuoteID)
+	}
+	if filter.OrderID != "" {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	// Período: visitas que se sobrepõem a [From, To]
+	if filter.From != nil {
+		query = query.Where("ends_at > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("starts_at < ?", *filter.To)
+	}
+
+	return query
+}
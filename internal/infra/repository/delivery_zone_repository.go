@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+
+	"gorm.io/gorm"
+)
+
+type DeliveryZoneRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryZoneRepository(db *gorm.DB) zoneDomain.Repository {
+	return &DeliveryZoneRepository{db: db}
+}
+
+func (r *DeliveryZoneRepository) Create(ctx context.Context, zone *zoneDomain.DeliveryZone) error {
+	result := r.db.WithContext(ctx).Create(zone)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return zoneDomain.ErrZoneAlreadyExists
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *DeliveryZoneRepository) GetByID(ctx context.Context, tenantID, id string) (*zoneDomain.DeliveryZone, error) {
+	return r.first(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+func (r *DeliveryZoneRepository) GetByName(ctx context.Context, tenantID, name string) (*zoneDomain.DeliveryZone, error) {
+	return r.first(ctx, "name = ? AND tenant_id = ?", name, tenantID)
+}
+
+func (r *DeliveryZoneRepository) first(ctx context.Context, query string, args ...any) (*zoneDomain.DeliveryZone, error) {
+	var zone zoneDomain.DeliveryZone
+
+	result := r.db.WithContext(ctx).Where(query, args...).First(&zone)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, zoneDomain.ErrZoneNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &zone, nil
+}
+
+func (r *DeliveryZoneRepository) Update(ctx context.Context, zone *zoneDomain.DeliveryZone) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", zone.ID, zone.TenantID).
+		Save(zone)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return zoneDomain.ErrZoneAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return zoneDomain.ErrZoneNotFound
+	}
+
+	return nil
+}
+
+func (r *DeliveryZoneRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&zoneDomain.DeliveryZone{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return zoneDomain.ErrZoneNotFound
+	}
+
+	return nil
+}
+
+func (r *DeliveryZoneRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]*zoneDomain.DeliveryZone, error) {
+	var zones []*zoneDomain.DeliveryZone
+
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&zones)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return zones, nil
+}
+
+func (r *DeliveryZoneRepository) Count(ctx context.Context, tenantID string) (int, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).Model(&zoneDomain.DeliveryZone{}).Where("tenant_id = ?", tenantID).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *DeliveryZoneRepository) ListActive(ctx context.Context, tenantID string) ([]*zoneDomain.DeliveryZone, error) {
+	var zones []*zoneDomain.DeliveryZone
+
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_active = ?", tenantID, true).
+		Find(&zones)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return zones, nil
+}
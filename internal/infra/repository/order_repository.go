@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	orderDomain "erp-api/internal/domain/order"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) orderDomain.Repository {
+	return &OrderRepository{db: db}
+}
+
+func (r *OrderRepository) Create(ctx context.Context, order *orderDomain.Order) error {
+	result := r.db.WithContext(ctx).Create(order)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, tenantID, id string) (*orderDomain.Order, error) {
+	return r.first(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+func (r *OrderRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*orderDomain.Order, error) {
+	var order orderDomain.Order
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&order)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, orderDomain.ErrOrderNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &order, nil
+}
+
+func (r *OrderRepository) GetByQuoteID(ctx context.Context, tenantID, quoteID string) (*orderDomain.Order, error) {
+	return r.first(ctx, "quote_id = ? AND tenant_id = ?", quoteID, tenantID)
+}
+
+func (r *OrderRepository) first(ctx context.Context, query string, args ...any) (*orderDomain.Order, error) {
+	var order orderDomain.Order
+
+	result := r.db.WithContext(ctx).Where(query, args...).First(&order)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, orderDomain.ErrOrderNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &order, nil
+}
+
+func (r *OrderRepository) Update(ctx context.Context, order *orderDomain.Order) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", order.ID, order.TenantID).
+		Save(order)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return orderDomain.ErrOrderNotFound
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) List(ctx context.Context, tenantID string, filter orderDomain.ListFilter, limit, offset int) ([]*orderDomain.Order, error) {
+	var orders []*orderDomain.Order
+
+	result := r.filtered(ctx, tenantID, filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&orders)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepository) Count(ctx context.Context, tenantID string, filter orderDomain.ListFilter) (int, error) {
+	var count int64
+
+	result := r.filtered(ctx, tenantID, filter).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *OrderRepository) filtered(ctx context.Context, tenantID string, filter orderDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&orderDomain.Order{}).Where("tenant_id = ?", tenantID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ClientID != "" {
+		query = query.Where("client_id = ?", filter.ClientID)
+	}
+
+	return query
+}
+
+type OrderItemRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderItemRepository(db *gorm.DB) orderDomain.ItemRepository {
+	return &OrderItemRepository{db: db}
+}
+
+func (r *OrderItemRepository) Create(ctx context.Context, item *orderDomain.OrderItem) error {
+	result := r.db.WithContext(ctx).Create(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *OrderItemRepository) GetByOrderID(ctx context.Context, orderID string) ([]*orderDomain.OrderItem, error) {
+	var items []*orderDomain.OrderItem
+
+	result := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&items)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return items, nil
+}
@@ -7,6 +7,7 @@ import (
 	productDomain "erp-api/internal/domain/product"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository struct {
@@ -39,10 +40,29 @@ func (r *ProductRepository) GetByID(ctx context.Context, tenantID, id string) (*
 	return &product, nil
 }
 
+func (r *ProductRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*productDomain.Product, error) {
+	var product productDomain.Product
+	
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&product)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, productDomain.ErrProductNotFound
+		}
+		return nil, result.Error
+	}
+	
+	return &product, nil
+}
+
 func (r *ProductRepository) Update(ctx context.Context, product *productDomain.Product) error {
-	// Garantir que o update só funciona se o tenant_id corresponder
+	// Garantir que o update só funciona se o tenant_id corresponder. O saldo só
+	// muda por AddStock/SetStock, junto com o livro de estoque
 	result := r.db.WithContext(ctx).
 		Where("id = ? AND tenant_id = ?", product.ID, product.TenantID).
+		Omit("stock").
 		Save(product)
 	if result.Error != nil {
 		return result.Error
@@ -94,4 +114,39 @@ func (r *ProductRepository) Count(ctx context.Context, tenantID string) (int, er
 	}
 	
 	return int(count), nil
-} 
\ No newline at end of file
+} 
+
+// AddStock soma delta ao saldo no próprio banco, sem ler e regravar o produto,
+// para lançamentos concorrentes não se perderem.
+func (r *ProductRepository) AddStock(ctx context.Context, tenantID, id string, delta int) error {
+	result := r.db.WithContext(ctx).
+		Model(&productDomain.Product{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		UpdateColumn("stock", gorm.Expr("stock + ?", delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	
+	if result.RowsAffected == 0 {
+		return productDomain.ErrProductNotFound
+	}
+	
+	return nil
+}
+
+// SetStock grava o saldo calculado na conciliação com o livro de estoque.
+func (r *ProductRepository) SetStock(ctx context.Context, tenantID, id string, stock int) error {
+	result := r.db.WithContext(ctx).
+		Model(&productDomain.Product{}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		UpdateColumn("stock", stock)
+	if result.Error != nil {
+		return result.Error
+	}
+	
+	if result.RowsAffected == 0 {
+		return productDomain.ErrProductNotFound
+	}
+	
+	return nil
+}
@@ -3,10 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type QuoteRepository struct {
@@ -29,7 +33,24 @@ func (r *QuoteRepository) GetByID(ctx context.Context, tenantID, id string) (*qu
 	var quote quoteDomain.Quote
 	
 	result := r.db.WithContext(ctx).
-		Preload("Client").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&quote)
+	
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, quoteDomain.ErrQuoteNotFound
+		}
+		return nil, result.Error
+	}
+	
+	return &quote, nil
+}
+
+func (r *QuoteRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*quoteDomain.Quote, error) {
+	var quote quoteDomain.Quote
+	
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("id = ? AND tenant_id = ?", id, tenantID).
 		First(&quote)
 	
@@ -44,8 +65,11 @@ func (r *QuoteRepository) GetByID(ctx context.Context, tenantID, id string) (*qu
 }
 
 func (r *QuoteRepository) Update(ctx context.Context, quote *quoteDomain.Quote) error {
+	// O status só muda por UpdateStatus, que confere o status de origem; gravar a
+	// linha inteira poderia desfazer uma transição concorrente
 	result := r.db.WithContext(ctx).
 		Where("id = ? AND tenant_id = ?", quote.ID, quote.TenantID).
+		Omit("status", "approved_at").
 		Save(quote)
 	if result.Error != nil {
 		return result.Error
@@ -71,40 +95,150 @@ func (r *QuoteRepository) Delete(ctx context.Context, tenantID, id string) error
 	return nil
 }
 
-func (r *QuoteRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]*quoteDomain.Quote, error) {
+func (r *QuoteRepository) List(ctx context.Context, tenantID string, filter quoteDomain.ListFilter, limit, offset int) ([]*quoteDomain.Quote, error) {
 	var quotes []*quoteDomain.Quote
-	
-	result := r.db.WithContext(ctx).
-		Preload("Client").
-		Where("tenant_id = ?", tenantID).
-		Order("created_at DESC").
+
+	direction := " DESC"
+	if filter.SortAsc {
+		direction = " ASC"
+	}
+
+	query := r.filtered(ctx, tenantID, filter).Select("quotes.*")
+	switch filter.SortBy {
+	case quoteDomain.SortByTotalValue:
+		query = query.Order("quotes.total_value" + direction)
+	case quoteDomain.SortByClientName:
+		query = query.Joins("LEFT JOIN clients ON clients.id = quotes.client_id").
+			Order("clients.name" + direction)
+	}
+
+	result := query.
+		Order("quotes.created_at" + direction).
 		Limit(limit).
 		Offset(offset).
 		Find(&quotes)
-	
+
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	
+
 	return quotes, nil
 }
 
-func (r *QuoteRepository) Count(ctx context.Context, tenantID string) (int, error) {
+func (r *QuoteRepository) Count(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (int, error) {
 	var count int64
-	
-	result := r.db.WithContext(ctx).Model(&quoteDomain.Quote{}).Where("tenant_id = ?", tenantID).Count(&count)
+
+	result := r.filtered(ctx, tenantID, filter).Count(&count)
 	if result.Error != nil {
 		return 0, result.Error
 	}
-	
+
 	return int(count), nil
 }
 
-func (r *QuoteRepository) UpdateStatus(ctx context.Context, tenantID, id string, status quoteDomain.QuoteStatus) error {
+func (r *QuoteRepository) ListForAnalytics(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) ([]*quoteDomain.Quote, error) {
+	var quotes []*quoteDomain.Quote
+
+	result := r.filtered(ctx, tenantID, filter).
+		Select("quotes.id, quotes.client_id, quotes.user_id, quotes.status, quotes.total_value, quotes.cost_total, quotes.margin, quotes.margin_percent, quotes.created_at, quotes.approved_at").
+		Find(&quotes)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return quotes, nil
+}
+
+// filtered aplica os filtros de listagem; List e Count compartilham a mesma consulta.
+// As colunas são qualificadas porque a ordenação por cliente faz join com clients.
+func (r *QuoteRepository) filtered(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&quoteDomain.Quote{}).Where("quotes.tenant_id = ?", tenantID)
+
+	if filter.Status != "" {
+		query = query.Where("quotes.status = ?", filter.Status)
+	}
+	if filter.ClientID != "" {
+		query = query.Where("quotes.client_id = ?", filter.ClientID)
+	}
+	if filter.UserID != "" {
+		query = query.Where("quotes.user_id = ?", filter.UserID)
+	}
+	if filter.Number != "" {
+		query = query.Where("quotes.number LIKE ?", "%"+strings.ToUpper(strings.TrimSpace(filter.Number))+"%")
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("quotes.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("quotes.created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.ApprovedFrom != nil {
+		query = query.Where("quotes.approved_at >= ?", *filter.ApprovedFrom)
+	}
+	if filter.ApprovedTo != nil {
+		query = query.Where("quotes.approved_at <= ?", *filter.ApprovedTo)
+	}
+	if filter.MinTotal != nil {
+		query = query.Where("quotes.total_value >= ?", *filter.MinTotal)
+	}
+	if filter.MaxTotal != nil {
+		query = query.Where("quotes.total_value <= ?", *filter.MaxTotal)
+	}
+
+	return query
+}
+
+func (r *QuoteRepository) ListOverdue(ctx context.Context, now time.Time, limit int) ([]*quoteDomain.Quote, error) {
+	var quotes []*quoteDomain.Quote
+
+	result := r.db.WithContext(ctx).
+		Where("status IN ? AND valid_until IS NOT NULL AND valid_until < ?",
+			[]quoteDomain.QuoteStatus{quoteDomain.QuoteStatusPending, quoteDomain.QuoteStatusAwaitingApproval}, now).
+		Order("valid_until ASC").
+		Limit(limit).
+		Find(&quotes)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return quotes, nil
+}
+
+func (r *QuoteRepository) UpdateStatus(ctx context.Context, tenantID, id string, from, status quoteDomain.QuoteStatus, approvedAt *time.Time) error {
+	updates := map[string]any{"status": status}
+	if approvedAt != nil {
+		updates["approved_at"] = approvedAt
+	}
+
 	result := r.db.WithContext(ctx).
 		Model(&quoteDomain.Quote{}).
-		Where("id = ? AND tenant_id = ?", id, tenantID).
-		Update("status", status)
+		Where("id = ? AND tenant_id = ? AND status = ?", id, tenantID, from).
+		Updates(updates)
+	
+	if result.Error != nil {
+		return result.Error
+	}
+	
+	// O orçamento foi lido antes; sem linha alterada, outra transição já o tirou de from
+	if result.RowsAffected == 0 {
+		return quoteDomain.ErrInvalidStatusTransition
+	}
+	
+	return nil
+}
+
+func (r *QuoteRepository) ApproveDiscount(ctx context.Context, quote *quoteDomain.Quote) error {
+	result := r.db.WithContext(ctx).
+		Model(&quoteDomain.Quote{}).
+		Where("id = ? AND tenant_id = ?", quote.ID, quote.TenantID).
+		Updates(map[string]any{
+			"discount_approved_percent": quote.DiscountApprovedPercent,
+			"discount_approved_by":      quote.DiscountApprovedBy,
+			"discount_approved_at":      quote.DiscountApprovedAt,
+			"updated_at":                quote.UpdatedAt,
+		})
 	
 	if result.Error != nil {
 		return result.Error
@@ -133,12 +267,29 @@ func (r *QuoteItemRepository) Create(ctx context.Context, item *quoteDomain.Quot
 	return nil
 }
 
+func (r *QuoteItemRepository) GetByID(ctx context.Context, quoteID, id string) (*quoteDomain.QuoteItem, error) {
+	var item quoteDomain.QuoteItem
+
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", id, quoteID).
+		First(&item)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, quoteDomain.ErrQuoteItemNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &item, nil
+}
+
 func (r *QuoteItemRepository) GetByQuoteID(ctx context.Context, quoteID string) ([]*quoteDomain.QuoteItem, error) {
 	var items []*quoteDomain.QuoteItem
 	
 	result := r.db.WithContext(ctx).
-		Preload("Product").
 		Where("quote_id = ?", quoteID).
+		Order("created_at ASC").
 		Find(&items)
 	
 	if result.Error != nil {
@@ -148,6 +299,36 @@ func (r *QuoteItemRepository) GetByQuoteID(ctx context.Context, quoteID string)
 	return items, nil
 }
 
+func (r *QuoteItemRepository) Update(ctx context.Context, item *quoteDomain.QuoteItem) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", item.ID, item.QuoteID).
+		Save(item)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return quoteDomain.ErrQuoteItemNotFound
+	}
+
+	return nil
+}
+
+func (r *QuoteItemRepository) Delete(ctx context.Context, quoteID, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", id, quoteID).
+		Delete(&quoteDomain.QuoteItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return quoteDomain.ErrQuoteItemNotFound
+	}
+
+	return nil
+}
+
 func (r *QuoteItemRepository) DeleteByQuoteID(ctx context.Context, quoteID string) error {
 	result := r.db.WithContext(ctx).
 		Where("quote_id = ?", quoteID).
@@ -158,4 +339,156 @@ func (r *QuoteItemRepository) DeleteByQuoteID(ctx context.Context, quoteID strin
 	}
 	
 	return nil
-} 
\ No newline at end of file
+}
+
+func (r *QuoteItemRepository) DeleteByOptionID(ctx context.Context, quoteID, optionID string) error {
+	result := r.db.WithContext(ctx).
+		Where("quote_id = ? AND option_id = ?", quoteID, optionID).
+		Delete(&quoteDomain.QuoteItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+type QuoteOptionRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteOptionRepository(db *gorm.DB) quoteDomain.OptionRepository {
+	return &QuoteOptionRepository{db: db}
+}
+
+func (r *QuoteOptionRepository) Create(ctx context.Context, option *quoteDomain.QuoteOption) error {
+	result := r.db.WithContext(ctx).Create(option)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *QuoteOptionRepository) GetByID(ctx context.Context, quoteID, id string) (*quoteDomain.QuoteOption, error) {
+	var option quoteDomain.QuoteOption
+
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", id, quoteID).
+		First(&option)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, quoteDomain.ErrQuoteOptionNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &option, nil
+}
+
+func (r *QuoteOptionRepository) ListByQuoteID(ctx context.Context, quoteID string) ([]*quoteDomain.QuoteOption, error) {
+	var options []*quoteDomain.QuoteOption
+
+	result := r.db.WithContext(ctx).
+		Where("quote_id = ?", quoteID).
+		Order("position ASC").
+		Find(&options)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return options, nil
+}
+
+func (r *QuoteOptionRepository) Update(ctx context.Context, option *quoteDomain.QuoteOption) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", option.ID, option.QuoteID).
+		Save(option)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return quoteDomain.ErrQuoteOptionNotFound
+	}
+
+	return nil
+}
+
+func (r *QuoteOptionRepository) Delete(ctx context.Context, quoteID, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND quote_id = ?", id, quoteID).
+		Delete(&quoteDomain.QuoteOption{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return quoteDomain.ErrQuoteOptionNotFound
+	}
+
+	return nil
+}
+
+type QuoteStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteStatusHistoryRepository(db *gorm.DB) quoteDomain.StatusHistoryRepository {
+	return &QuoteStatusHistoryRepository{db: db}
+}
+
+func (r *QuoteStatusHistoryRepository) Create(ctx context.Context, history *quoteDomain.QuoteStatusHistory) error {
+	result := r.db.WithContext(ctx).Create(history)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *QuoteStatusHistoryRepository) ListByQuoteID(ctx context.Context, tenantID, quoteID string) ([]*quoteDomain.QuoteStatusHistory, error) {
+	var history []*quoteDomain.QuoteStatusHistory
+
+	result := r.db.WithContext(ctx).
+		Where("quote_id = ? AND tenant_id = ?", quoteID, tenantID).
+		Order("created_at ASC").
+		Find(&history)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return history, nil
+}
+
+type QuoteSequenceRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteSequenceRepository(db *gorm.DB) quoteDomain.SequenceRepository {
+	return &QuoteSequenceRepository{db: db}
+}
+
+func (r *QuoteSequenceRepository) Next(ctx context.Context, tenantID, scope string) (int64, error) {
+	db := r.db.WithContext(ctx)
+
+	// Cria a sequência na primeira emissão do escopo; se outra transação
+	// criou antes, o conflito é ignorado
+	seq := quoteDomain.QuoteSequence{TenantID: dbtypes.UUID(tenantID), Scope: scope}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&seq).Error; err != nil {
+		return 0, err
+	}
+
+	// O UPDATE bloqueia a linha até o fim da transação, serializando emissões concorrentes
+	result := db.Model(&quoteDomain.QuoteSequence{}).
+		Where("tenant_id = ? AND scope = ?", tenantID, scope).
+		Update("last_value", gorm.Expr("last_value + ?", 1))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if err := db.Where("tenant_id = ? AND scope = ?", tenantID, scope).First(&seq).Error; err != nil {
+		return 0, err
+	}
+
+	return seq.LastValue, nil
+}
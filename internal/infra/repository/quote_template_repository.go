@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	templateDomain "erp-api/internal/domain/quotetemplate"
+
+	"gorm.io/gorm"
+)
+
+type QuoteTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteTemplateRepository(db *gorm.DB) templateDomain.Repository {
+	return &QuoteTemplateRepository{db: db}
+}
+
+func (r *QuoteTemplateRepository) Create(ctx context.Context, template *templateDomain.QuoteTemplate) error {
+	result := r.db.WithContext(ctx).Create(template)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return templateDomain.ErrTemplateAlreadyExists
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *QuoteTemplateRepository) GetByID(ctx context.Context, tenantID, id string) (*templateDomain.QuoteTemplate, error) {
+	return r.first(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+func (r *QuoteTemplateRepository) GetByName(ctx context.Context, tenantID, name string) (*templateDomain.QuoteTemplate, error) {
+	return r.first(ctx, "name = ? AND tenant_id = ?", name, tenantID)
+}
+
+func (r *QuoteTemplateRepository) first(ctx context.Context, query string, args ...any) (*templateDomain.QuoteTemplate, error) {
+	var template templateDomain.QuoteTemplate
+
+	result := r.db.WithContext(ctx).Where(query, args...).First(&template)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, templateDomain.ErrTemplateNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &template, nil
+}
+
+func (r *QuoteTemplateRepository) Update(ctx context.Context, template *templateDomain.QuoteTemplate) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", template.ID, template.TenantID).
+		Save(template)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return templateDomain.ErrTemplateAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return templateDomain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *QuoteTemplateRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&templateDomain.QuoteTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return templateDomain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *QuoteTemplateRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]*templateDomain.QuoteTemplate, error) {
+	var templates []*templateDomain.QuoteTemplate
+
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&templates)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return templates, nil
+}
+
+func (r *QuoteTemplateRepository) Count(ctx context.Context, tenantID string) (int, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).Model(&templateDomain.QuoteTemplate{}).Where("tenant_id = ?", tenantID).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+type QuoteTemplateItemRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteTemplateItemRepository(db *gorm.DB) templateDomain.ItemRepository {
+	return &QuoteTemplateItemRepository{db: db}
+}
+
+func (r *QuoteTemplateItemRepository) Create(ctx context.Context, item *templateDomain.QuoteTemplateItem) error {
+	result := r.db.WithContext(ctx).Create(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *QuoteTemplateItemRepository) GetByTemplateID(ctx context.Context, templateID string) ([]*templateDomain.QuoteTemplateItem, error) {
+	var items []*templateDomain.QuoteTemplateItem
+
+	result := r.db.WithContext(ctx).
+		Where("template_id = ?", templateID).
+		Order("position ASC").
+		Find(&items)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return items, nil
+}
+
+func (r *QuoteTemplateItemRepository) DeleteByTemplateID(ctx context.Context, templateID string) error {
+	result := r.db.WithContext(ctx).Where("template_id = ?", templateID).Delete(&templateDomain.QuoteTemplateItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
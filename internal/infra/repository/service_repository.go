@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	serviceDomain "erp-api/internal/domain/service"
+
+	"gorm.io/gorm"
+)
+
+type ServiceRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceRepository(db *gorm.DB) serviceDomain.Repository {
+	return &ServiceRepository{db: db}
+}
+
+func (r *ServiceRepository) Create(ctx context.Context, service *serviceDomain.Service) error {
+	result := r.db.WithContext(ctx).Create(service)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return serviceDomain.ErrServiceAlreadyExists
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *ServiceRepository) GetByID(ctx context.Context, tenantID, id string) (*serviceDomain.Service, error) {
+	return r.first(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+func (r *ServiceRepository) GetByName(ctx context.Context, tenantID, name string) (*serviceDomain.Service, error) {
+	return r.first(ctx, "name = ? AND tenant_id = ?", name, tenantID)
+}
+
+func (r *ServiceRepository) first(ctx context.Context, query string, args ...any) (*serviceDomain.Service, error) {
+	var service serviceDomain.Service
+
+	result := r.db.WithContext(ctx).Where(query, args...).First(&service)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, serviceDomain.ErrServiceNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &service, nil
+}
+
+func (r *ServiceRepository) Update(ctx context.Context, service *serviceDomain.Service) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", service.ID, service.TenantID).
+		Save(service)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return serviceDomain.ErrServiceAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return serviceDomain.ErrServiceNotFound
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&serviceDomain.Service{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return serviceDomain.ErrServiceNotFound
+	}
+
+	return nil
+}
+
+func (r *ServiceRepository) List(ctx context.Context, tenantID string, activeOnly bool, limit, offset int) ([]*serviceDomain.Service, error) {
+	var services []*serviceDomain.Service
+
+	result := r.scope(ctx, tenantID, activeOnly).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&services)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return services, nil
+}
+
+func (r *ServiceRepository) Count(ctx context.Context, tenantID string, activeOnly bool) (int, error) {
+	var count int64
+
+	result := r.scope(ctx, tenantID, activeOnly).Model(&serviceDomain.Service{}).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *ServiceRepository) scope(ctx context.Context, tenantID string, activeOnly bool) *gorm.DB {
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	return query
+}
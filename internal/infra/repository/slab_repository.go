@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	slabDomain "erp-api/internal/domain/slab"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SlabRepository struct {
+	db *gorm.DB
+}
+
+func NewSlabRepository(db *gorm.DB) slabDomain.Repository {
+	return &SlabRepository{db: db}
+}
+
+func (r *SlabRepository) Create(ctx context.Context, slab *slabDomain.Slab) error {
+	result := r.db.WithContext(ctx).Create(slab)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *SlabRepository) GetByID(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error) {
+	var slab slabDomain.Slab
+
+	result := r.db.WithContext(ctx).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&slab)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, slabDomain.ErrSlabNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &slab, nil
+}
+
+func (r *SlabRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error) {
+	var slab slabDomain.Slab
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&slab)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, slabDomain.ErrSlabNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &slab, nil
+}
+
+// Update grava só a chapa; fotos têm repositório próprio.
+func (r *SlabRepository) Update(ctx context.Context, slab *slabDomain.Slab) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", slab.ID, slab.TenantID).
+		Omit("Photos").
+		Save(slab)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return slabDomain.ErrSlabNotFound
+	}
+
+	return nil
+}
+
+func (r *SlabRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&slabDomain.Slab{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return slabDomain.ErrSlabNotFound
+	}
+
+	return nil
+}
+
+func (r *SlabRepository) List(ctx context.Context, tenantID string, filter slabDomain.ListFilter, limit, offset int) ([]*slabDomain.Slab, error) {
+	var slabs []*slabDomain.Slab
+
+	result := r.filtered(ctx, tenantID, filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&slabs)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return slabs, nil
+}
+
+func (r *SlabRepository) Count(ctx context.Context, tenantID string, filter slabDomain.ListFilter) (int, error) {
+	var count int64
+
+	result := r.filtered(ctx, tenantID, filter).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *SlabRepository) ListAll(ctx context.Context, tenantID string, filter slabDomain.ListFilter) ([]*slabDomain.Slab, error) {
+	var slabs []*slabDomain.Slab
+
+	result := r.filtered(ctx, tenantID, filter).
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Order("created_at ASC").
+		Find(&slabs)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return slabs, nil
+}
+
+func (r *SlabRepository) filtered(ctx context.Context, tenantID string, filter slabDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&slabDomain.Slab{}).Where("tenant_id = ?", tenantID)
+
+	if filter.ProductID != "" {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.Lot != "" {
+		query = query.Where("lot = ?", filter.Lot)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RemnantsOnly {
+		query = query.Where("is_remnant = ?", true)
+	}
+	if filter.QuoteID != "" {
+		query = query.Where("quote_id = ?", filter.QuoteID)
+	}
+
+	return query
+}
+
+type SlabPhotoRepository struct {
+	db *gorm.DB
+}
+
+func NewSlabPhotoRepository(db *gorm.DB) slabDomain.PhotoRepository {
+	return &SlabPhotoRepository{db: db}
+}
+
+func (r *SlabPhotoRepository) Create(ctx context.Context, photo *slabDomain.Photo) error {
+	result := r.db.WithContext(ctx).Create(photo)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *SlabPhotoRepository) Delete(ctx context.Context, tenantID, slabID, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND slab_id = ? AND tenant_id = ?", id, slabID, tenantID).
+		Delete(&slabDomain.Photo{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return slabDomain.ErrPhotoNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+
+	stockDomain "erp-api/internal/domain/stock"
+
+	"gorm.io/gorm"
+)
+
+type StockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) stockDomain.Repository {
+	return &StockMovementRepository{db: db}
+}
+
+func (r *StockMovementRepository) Create(ctx context.Context, movement *stockDomain.Movement) error {
+	result := r.db.WithContext(ctx).Create(movement)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// List traz os lançamentos mais recentes primeiro.
+func (r *StockMovementRepository) List(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter, limit, offset int) ([]*stockDomain.Movement, error) {
+	var movements []*stockDomain.Movement
+
+	result := r.filtered(ctx, tenantID, productID, filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return movements, nil
+}
+
+func (r *StockMovementRepository) Count(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter) (int, error) {
+	var count int64
+
+	result := r.filtered(ctx, tenantID, productID, filter).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *StockMovementRepository) Totals(ctx context.Context, tenantID, productID string) (int, int, error) {
+	var totals struct {
+		OnHand   int
+		Reserved int
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&stockDomain.Movement{}).
+		Select(
+			"COALESCE(SUM(CASE WHEN type <> ? THEN quantity ELSE 0 END), 0) AS on_hand, "+
+				"COALESCE(SUM(CASE WHEN type = ? THEN quantity ELSE 0 END), 0) AS reserved",
+			stockDomain.TypeReservation, stockDomain.TypeReservation,
+		).
+		Where("tenant_id = ? AND product_id = ?", tenantID, productID).
+		Scan(&totals)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+
+	return totals.OnHand, totals.Reserved, nil
+}
+
+func (r *StockMovementRepository) ReservedByProduct(ctx context.Context, tenantID string, productIDs []string) (map[string]int, error) {
+	reserved := make(map[string]int, len(productIDs))
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+
+	var rows []struct {
+		ProductID string
+		Quantity  int
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&stockDomain.Movement{}).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS quantity").
+		Where("tenant_id = ? AND type = ? AND product_id IN ?", tenantID, stockDomain.TypeReservation, productIDs).
+		Group("product_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		reserved[row.ProductID] = row.Quantity
+	}
+	return reserved, nil
+}
+
+func (r *StockMovementRepository) ReservedByReference(ctx context.Context, tenantID, referenceType, referenceID string) (map[string]int, error) {
+	var rows []struct {
+		ProductID string
+		Quantity  int
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&stockDomain.Movement{}).
+		Select("product_id, COALESCE(SUM(quantity), 0) AS quantity").
+		Where("tenant_id = ? AND type = ? AND reference_type = ? AND reference_id = ?",
+			tenantID, stockDomain.TypeReservation, referenceType, referenceID).
+		Group("product_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	reserved := make(map[string]int, len(rows))
+	for _, row := range rows {
+		if row.Quantity != 0 {
+			reserved[row.ProductID] = row.Quantity
+		}
+	}
+	return reserved, nil
+}
+
+func (r *StockMovementRepository) filtered(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&stockDomain.Movement{}).
+		Where("tenant_id = ? AND product_id = ?", tenantID, productID)
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	return query
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) appointmentDomain.TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *appointmentDomain.Team) error {
+	result := r.db.WithContext(ctx).Create(team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return appointmentDomain.ErrTeamAlreadyExists
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *TeamRepository) GetByID(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error) {
+	return r.first(ctx, "id = ? AND tenant_id = ?", id, tenantID)
+}
+
+func (r *TeamRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error) {
+	var team appointmentDomain.Team
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, appointmentDomain.ErrTeamNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &team, nil
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, tenantID, name string) (*appointmentDomain.Team, error) {
+	return r.first(ctx, "name = ? AND tenant_id = ?", name, tenantID)
+}
+
+// GetByFeedToken não filtra por tenant: o token é a única credencial do calendário público.
+func (r *TeamRepository) GetByFeedToken(ctx context.Context, token string) (*appointmentDomain.Team, error) {
+	if token == "" {
+		return nil, appointmentDomain.ErrTeamNotFound
+	}
+	return r.first(ctx, "feed_token = ?", token)
+}
+
+func (r *TeamRepository) first(ctx context.Context, query string, args ...any) (*appointmentDomain.Team, error) {
+	var team appointmentDomain.Team
+
+	result := r.db.WithContext(ctx).Where(query, args...).First(&team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, appointmentDomain.ErrTeamNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &team, nil
+}
+
+func (r *TeamRepository) Update(ctx context.Context, team *appointmentDomain.Team) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", team.ID, team.TenantID).
+		Save(team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return appointmentDomain.ErrTeamAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return appointmentDomain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, tenantID, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&appointmentDomain.Team{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return appointmentDomain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]*appointmentDomain.Team, error) {
+	var teams []*appointmentDomain.Team
+
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&teams)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) Count(ctx context.Context, tenantID string) (int, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).Model(&appointmentDomain.Team{}).Where("tenant_id = ?", tenantID).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	productionDomain "erp-api/internal/domain/production"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkOrderRepository(db *gorm.DB) productionDomain.Repository {
+	return &WorkOrderRepository{db: db}
+}
+
+func (r *WorkOrderRepository) Create(ctx context.Context, workOrder *productionDomain.WorkOrder) error {
+	result := r.db.WithContext(ctx).Create(workOrder)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *WorkOrderRepository) GetByID(ctx context.Context, tenantID, id string) (*productionDomain.WorkOrder, error) {
+	var workOrder productionDomain.WorkOrder
+
+	result := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&workOrder)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, productionDomain.ErrWorkOrderNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &workOrder, nil
+}
+
+func (r *WorkOrderRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*productionDomain.WorkOrder, error) {
+	var workOrder productionDomain.WorkOrder
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		First(&workOrder)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, productionDomain.ErrWorkOrderNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &workOrder, nil
+}
+
+func (r *WorkOrderRepository) Update(ctx context.Context, workOrder *productionDomain.WorkOrder) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", workOrder.ID, workOrder.TenantID).
+		Save(workOrder)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return productionDomain.ErrWorkOrderNotFound
+	}
+
+	return nil
+}
+
+func (r *WorkOrderRepository) List(ctx context.Context, tenantID string, filter productionDomain.ListFilter, limit, offset int) ([]*productionDomain.WorkOrder, error) {
+	var workOrders []*productionDomain.WorkOrder
+
+	result := r.filtered(ctx, tenantID, filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&workOrders)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return workOrders, nil
+}
+
+func (r *WorkOrderRepository) Count(ctx context.Context, tenantID string, filter productionDomain.ListFilter) (int, error) {
+	var count int64
+
+	result := r.filtered(ctx, tenantID, filter).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+// ListOpen ordena pelas mais antigas primeiro, que é a fila do chão de fábrica.
+func (r *WorkOrderRepository) ListOpen(ctx context.Context, tenantID string) ([]*productionDomain.WorkOrder, error) {
+	var workOrders []*productionDomain.WorkOrder
+
+	result := r.filtered(ctx, tenantID, productionDomain.ListFilter{Open: true}).
+		Order("created_at ASC").
+		Find(&workOrders)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return workOrders, nil
+}
+
+func (r *WorkOrderRepository) filtered(ctx context.Context, tenantID string, filter productionDomain.ListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&productionDomain.WorkOrder{}).Where("tenant_id = ?", tenantID)
+
+	if filter.QuoteID != "" {
+		query = query.Where("quote_id = ?", filter.QuoteID)
+	}
+	if filter.Stage != "" {
+		query = query.Where("current_stage = ?", filter.Stage)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Open {
+		query = query.Where("status <> ?", productionDomain.WorkOrderStatusCompleted)
+	}
+
+	return query
+}
+
+type WorkOrderStageRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkOrderStageRepository(db *gorm.DB) productionDomain.StageRepository {
+	return &WorkOrderStageRepository{db: db}
+}
+
+func (r *WorkOrderStageRepository) Create(ctx context.Context, stage *productionDomain.WorkOrderStage) error {
+	result := r.db.WithContext(ctx).Create(stage)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *WorkOrderStageRepository) Update(ctx context.Context, stage *productionDomain.WorkOrderStage) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ?", stage.ID, stage.TenantID).
+		Save(stage)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (r *WorkOrderStageRepository) ListByWorkOrderID(ctx context.Context, workOrderID string) ([]*productionDomain.WorkOrderStage, error) {
+	return r.ListByWorkOrderIDs(ctx, []string{workOrderID})
+}
+
+func (r *WorkOrderStageRepository) ListByWorkOrderIDs(ctx context.Context, workOrderIDs []string) ([]*productionDomain.WorkOrderStage, error) {
+	var stages []*productionDomain.WorkOrderStage
+	if len(workOrderIDs) == 0 {
+		return stages, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("work_order_id IN ?", workOrderIDs).
+		Order("work_order_id, position ASC").
+		Find(&stages)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return stages, nil
+}
@@ -0,0 +1,178 @@
+package appointment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// CreateTeam cadastra uma equipe já com o token do calendário público.
+func (u *UseCase) CreateTeam(ctx context.Context, tenantID string, req *appointmentDomain.CreateTeamDTO) (*appointmentDomain.Team, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	token, err := appointmentDomain.NewFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	team := &appointmentDomain.Team{
+		TenantID:    dbtypes.UUID(tenantID),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		IsActive:    true,
+		FeedToken:   token,
+	}
+
+	if err := u.ensureUniqueTeamName(ctx, tenantID, team.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := u.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func (u *UseCase) GetTeam(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error) {
+	return u.teamRepo.GetByID(ctx, tenantID, id)
+}
+
+func (u *UseCase) UpdateTeam(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateTeamDTO) (*appointmentDomain.Team, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	team, err := u.teamRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := u.ensureUniqueTeamName(ctx, tenantID, name, id); err != nil {
+			return nil, err
+		}
+		team.Name = name
+	}
+	if req.Description != nil {
+		team.Description = *req.Description
+	}
+	if req.IsActive != nil {
+		team.IsActive = *req.IsActive
+	}
+
+	if err := u.teamRepo.Update(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// DeleteTeam só remove equipes sem visitas; as demais devem ser desativadas
+// para manter o histórico.
+func (u *UseCase) DeleteTeam(ctx context.Context, tenantID, id string) error {
+	if _, err := u.teamRepo.GetByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+
+	count, err := u.appointmentRepo.Count(ctx, tenantID, appointmentDomain.ListFilter{TeamID: id})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return appointmentDomain.ErrTeamInUse
+	}
+
+	return u.teamRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) ListTeams(ctx context.Context, tenantID string, limit, offset int) ([]*appointmentDomain.Team, error) {
+	return u.teamRepo.List(ctx, tenantID, limit, offset)
+}
+
+func (u *UseCase) CountTeams(ctx context.Context, tenantID string) (int, error) {
+	return u.teamRepo.Count(ctx, tenantID)
+}
+
+// RotateFeedToken troca o token do calendário; o endereço antigo deixa de funcionar.
+func (u *UseCase) RotateFeedToken(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error) {
+	team, err := u.teamRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := appointmentDomain.NewFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	team.FeedToken = token
+
+	if err := u.teamRepo.Update(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Calendar monta o .ics da equipe dona do token, com as visitas dos últimos
+// 30 dias em diante. Equipes inativas não têm calendário.
+func (u *UseCase) Calendar(ctx context.Context, token string) ([]byte, error) {
+	team, err := u.teamRepo.GetByFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !team.IsActive {
+		return nil, appointmentDomain.ErrTeamNotFound
+	}
+
+	now := time.Now()
+	appointments, err := u.appointmentRepo.ListForFeed(ctx, team.TenantID.String(), team.ID.String(), now.Add(-feedHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	// Nome do cliente no título; falhas na busca deixam só o tipo da visita
+	clients := make(map[string]string)
+	for _, appointment := range appointments {
+		id := appointment.ClientID.String()
+		if _, ok := clients[id]; ok {
+			continue
+		}
+		clients[id] = ""
+		if client, err := u.clientRepo.GetByID(ctx, team.TenantID.String(), id); err == nil {
+			clients[id] = client.Name
+		}
+	}
+
+	return appointmentDomain.BuildCalendar(team, appointments, clients, now), nil
+}
+
+// activeTeam busca uma equipe que ainda pode receber visitas.
+func (u *UseCase) activeTeam(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error) {
+	team, err := u.teamRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !team.IsActive {
+		return nil, appointmentDomain.ErrTeamInactive
+	}
+	return team, nil
+}
+
+// ensureUniqueTeamName garante que não existe outra equipe com o mesmo nome no tenant
+func (u *UseCase) ensureUniqueTeamName(ctx context.Context, tenantID, name, currentID string) error {
+	existing, err := u.teamRepo.GetByName(ctx, tenantID, name)
+	if err != nil && err != appointmentDomain.ErrTeamNotFound {
+		return err
+	}
+	if existing != nil && existing.ID.String() != currentID {
+		return appointmentDomain.ErrTeamAlreadyExists
+	}
+	return nil
+}
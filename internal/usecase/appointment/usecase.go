@@ -0,0 +1,286 @@
+package appointment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appointmentDomain "erp-api/internal/domain/appointment"
+	clientDomain "erp-api/internal/domain/client"
+	orderDomain "erp-api/internal/domain/order"
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/infra/database"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// feedHistory é quanto do passado o calendário público mostra.
+const feedHistory = 30 * 24 * time.Hour
+
+type UseCaseInterface interface {
+	CreateTeam(ctx context.Context, tenantID string, req *appointmentDomain.CreateTeamDTO) (*appointmentDomain.Team, error)
+	GetTeam(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error)
+	UpdateTeam(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateTeamDTO) (*appointmentDomain.Team, error)
+	DeleteTeam(ctx context.Context, tenantID, id string) error
+	ListTeams(ctx context.Context, tenantID string, limit, offset int) ([]*appointmentDomain.Team, error)
+	CountTeams(ctx context.Context, tenantID string) (int, error)
+	RotateFeedToken(ctx context.Context, tenantID, id string) (*appointmentDomain.Team, error)
+	Calendar(ctx context.Context, token string) ([]byte, error)
+
+	Create(ctx context.Context, tenantID, userID string, req *appointmentDomain.CreateAppointmentDTO) (*appointmentDomain.Appointment, error)
+	GetByID(ctx context.Context, tenantID, id string) (*appointmentDomain.Appointment, error)
+	Update(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateAppointmentDTO) (*appointmentDomain.Appointment, error)
+	UpdateStatus(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateAppointmentStatusDTO) (*appointmentDomain.Appointment, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter, limit, offset int) ([]*appointmentDomain.Appointment, error)
+	Count(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter) (int, error)
+}
+
+type UseCase struct {
+	teamRepo        appointmentDomain.TeamRepository
+	appointmentRepo appointmentDomain.Repository
+	clientRepo      clientDomain.Repository
+	quoteRepo       quoteDomain.Repository
+	orderRepo       orderDomain.Repository
+	uow             database.UnitOfWork
+}
+
+func NewUseCase(
+	teamRepo appointmentDomain.TeamRepository,
+	appointmentRepo appointmentDomain.Repository,
+	clientRepo clientDomain.Repository,
+	quoteRepo quoteDomain.Repository,
+	orderRepo orderDomain.Repository,
+	uow database.UnitOfWork,
+) UseCaseInterface {
+	return &UseCase{
+		teamRepo:        teamRepo,
+		appointmentRepo: appointmentRepo,
+		clientRepo:      clientRepo,
+		quoteRepo:       quoteRepo,
+		orderRepo:       orderRepo,
+		uow:             uow,
+	}
+}
+
+// Create agenda uma visita. O endereço vem do cliente quando não informado e a
+// equipe não pode ter outra visita na mesma janela.
+func (u *UseCase) Create(ctx context.Context, tenantID, userID string, req *appointmentDomain.CreateAppointmentDTO) (*appointmentDomain.Appointment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.activeTeam(ctx, tenantID, req.TeamID); err != nil {
+		return nil, err
+	}
+
+	client, err := u.clientRepo.GetByID(ctx, tenantID, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &appointmentDomain.Appointment{
+		TenantID: dbtypes.UUID(tenantID),
+		TeamID:   dbtypes.UUID(req.TeamID),
+		ClientID: client.ID,
+		Type:     req.Type,
+		Status:   appointmentDomain.StatusScheduled,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Address:  strings.TrimSpace(req.Address),
+		City:     strings.TrimSpace(req.City),
+		State:    strings.ToUpper(strings.TrimSpace(req.State)),
+		ZipCode:  strings.TrimSpace(req.ZipCode),
+		Notes:    req.Notes,
+	}
+	if appointment.Address == "" {
+		appointment.Address = client.Address
+		appointment.City = client.City
+		appointment.State = client.State
+		appointment.ZipCode = client.ZipCode
+	}
+	if userID != "" {
+		uid := dbtypes.UUID(userID)
+		appointment.CreatedBy = &uid
+	}
+
+	if err := u.linkDocuments(ctx, tenantID, appointment, req.QuoteID, req.OrderID); err != nil {
+		return nil, err
+	}
+
+	err = u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		if err := ensureNoConflict(ctx, tx, appointment); err != nil {
+			return err
+		}
+		return tx.CreateAppointmentRepository().Create(ctx, appointment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// linkDocuments vincula orçamento e pedido, que precisam ser do mesmo cliente.
+func (u *UseCase) linkDocuments(ctx context.Context, tenantID string, appointment *appointmentDomain.Appointment, quoteID, orderID string) error {
+	if quoteID != "" {
+		quote, err := u.quoteRepo.GetByID(ctx, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+		if quote.ClientID != appointment.ClientID {
+			return appointmentDomain.ErrClientMismatch
+		}
+		appointment.QuoteID = &quote.ID
+	}
+
+	if orderID != "" {
+		order, err := u.orderRepo.GetByID(ctx, tenantID, orderID)
+		if err != nil {
+			return err
+		}
+		if order.ClientID != appointment.ClientID {
+			return appointmentDomain.ErrClientMismatch
+		}
+		appointment.OrderID = &order.ID
+		// O pedido já aponta para o orçamento de origem
+		if appointment.QuoteID == nil {
+			appointment.QuoteID = &order.QuoteID
+		}
+	}
+
+	return nil
+}
+
+func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*appointmentDomain.Appointment, error) {
+	return u.appointmentRepo.GetByID(ctx, tenantID, id)
+}
+
+// Update remarca ou corrige uma visita agendada; troca de equipe ou horário
+// passa de novo pela verificação de conflitos. A visita é lida com a linha
+// travada, então um cancelamento simultâneo não é desfeito pela remarcação.
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateAppointmentDTO) (*appointmentDomain.Appointment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var appointment *appointmentDomain.Appointment
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		repo := tx.CreateAppointmentRepository()
+
+		var err error
+		appointment, err = repo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		if !appointment.IsOpen() {
+			return appointmentDomain.ErrAppointmentClosed
+		}
+
+		if req.TeamID != nil && *req.TeamID != appointment.TeamID.String() {
+			team, err := u.activeTeam(ctx, tenantID, *req.TeamID)
+			if err != nil {
+				return err
+			}
+			appointment.TeamID = team.ID
+		}
+		if req.Type != nil {
+			appointment.Type = *req.Type
+		}
+		if req.StartsAt != nil {
+			appointment.StartsAt = *req.StartsAt
+		}
+		if req.EndsAt != nil {
+			appointment.EndsAt = *req.EndsAt
+		}
+		if req.Address != nil {
+			appointment.Address = strings.TrimSpace(*req.Address)
+		}
+		if req.City != nil {
+			appointment.City = strings.TrimSpace(*req.City)
+		}
+		if req.State != nil {
+			appointment.State = strings.ToUpper(strings.TrimSpace(*req.State))
+		}
+		if req.ZipCode != nil {
+			appointment.ZipCode = strings.TrimSpace(*req.ZipCode)
+		}
+		if req.Notes != nil {
+			appointment.Notes = *req.Notes
+		}
+
+		if err := appointmentDomain.ValidateWindow(appointment.StartsAt, appointment.EndsAt); err != nil {
+			return err
+		}
+
+		if err := ensureNoConflict(ctx, tx, appointment); err != nil {
+			return err
+		}
+		return repo.Update(ctx, appointment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// UpdateStatus conclui ou cancela a visita. Visitas canceladas liberam a janela
+// da equipe. A visita é lida com a linha travada, como em Update.
+func (u *UseCase) UpdateStatus(ctx context.Context, tenantID, id string, req *appointmentDomain.UpdateAppointmentStatusDTO) (*appointmentDomain.Appointment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var appointment *appointmentDomain.Appointment
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		repo := tx.CreateAppointmentRepository()
+
+		var err error
+		appointment, err = repo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := appointment.ChangeStatus(req.Status); err != nil {
+			return err
+		}
+
+		return repo.Update(ctx, appointment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
+	return u.appointmentRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter, limit, offset int) ([]*appointmentDomain.Appointment, error) {
+	return u.appointmentRepo.List(ctx, tenantID, filter, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string, filter appointmentDomain.ListFilter) (int, error) {
+	return u.appointmentRepo.Count(ctx, tenantID, filter)
+}
+
+// ensureNoConflict recusa a visita quando a equipe já tem outra na mesma janela.
+// A linha da equipe fica travada até o fim da transação, então dois agendamentos
+// simultâneos para a mesma equipe são verificados um depois do outro.
+func ensureNoConflict(ctx context.Context, tx database.RepositoryFactory, appointment *appointmentDomain.Appointment) error {
+	tenantID, teamID := appointment.TenantID.String(), appointment.TeamID.String()
+	if _, err := tx.CreateTeamRepository().GetForUpdate(ctx, tenantID, teamID); err != nil {
+		return err
+	}
+
+	conflicts, err := tx.CreateAppointmentRepository().ListOverlapping(ctx, tenantID, teamID, appointment.StartsAt, appointment.EndsAt, appointment.ID.String())
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return appointmentDomain.ErrScheduleConflict
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package deliveryzone
+
+import (
+	"context"
+
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	Create(ctx context.Context, tenantID string, req *zoneDomain.CreateZoneDTO) (*zoneDomain.DeliveryZone, error)
+	GetByID(ctx context.Context, tenantID, id string) (*zoneDomain.DeliveryZone, error)
+	Update(ctx context.Context, tenantID, id string, req *zoneDomain.UpdateZoneDTO) (*zoneDomain.DeliveryZone, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*zoneDomain.DeliveryZone, error)
+	Count(ctx context.Context, tenantID string) (int, error)
+}
+
+type UseCase struct {
+	zoneRepo zoneDomain.Repository
+}
+
+func NewUseCase(zoneRepo zoneDomain.Repository) UseCaseInterface {
+	return &UseCase{
+		zoneRepo: zoneRepo,
+	}
+}
+
+func (u *UseCase) Create(ctx context.Context, tenantID string, req *zoneDomain.CreateZoneDTO) (*zoneDomain.DeliveryZone, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	zone := &zoneDomain.DeliveryZone{
+		TenantID:   dbtypes.UUID(tenantID),
+		Name:       req.Name,
+		ZipStart:   req.ZipStart,
+		ZipEnd:     req.ZipEnd,
+		City:       req.City,
+		State:      req.State,
+		BaseFee:    req.BaseFee,
+		RateBasis:  req.RateBasis,
+		Rate:       req.Rate,
+		MinimumFee: req.MinimumFee,
+		IsActive:   true,
+	}
+	zone.Normalize()
+	if err := zone.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.ensureUniqueName(ctx, tenantID, zone.Name, ""); err != nil {
+		return nil, err
+	}
+
+	if err := u.zoneRepo.Create(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*zoneDomain.DeliveryZone, error) {
+	return u.zoneRepo.GetByID(ctx, tenantID, id)
+}
+
+// Update altera a zona; fretes já calculados só mudam quando o orçamento é recalculado
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *zoneDomain.UpdateZoneDTO) (*zoneDomain.DeliveryZone, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	zone, err := u.zoneRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		zone.Name = *req.Name
+	}
+	if req.ZipStart != nil {
+		zone.ZipStart = *req.ZipStart
+	}
+	if req.ZipEnd != nil {
+		zone.ZipEnd = *req.ZipEnd
+	}
+	if req.City != nil {
+		zone.City = *req.City
+	}
+	if req.State != nil {
+		zone.State = *req.State
+	}
+	if req.BaseFee != nil {
+		zone.BaseFee = *req.BaseFee
+	}
+	if req.RateBasis != nil {
+		zone.RateBasis = *req.RateBasis
+	}
+	if req.Rate != nil {
+		zone.Rate = *req.Rate
+	}
+	if req.MinimumFee != nil {
+		zone.MinimumFee = *req.MinimumFee
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+
+	zone.Normalize()
+	if err := zone.Validate(); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if err := u.ensureUniqueName(ctx, tenantID, zone.Name, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.zoneRepo.Update(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
+	return u.zoneRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]*zoneDomain.DeliveryZone, error) {
+	return u.zoneRepo.List(ctx, tenantID, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string) (int, error) {
+	return u.zoneRepo.Count(ctx, tenantID)
+}
+
+// ensureUniqueName garante que não existe outra zona com o mesmo nome no tenant
+func (u *UseCase) ensureUniqueName(ctx context.Context, tenantID, name, currentID string) error {
+	existing, err := u.zoneRepo.GetByName(ctx, tenantID, name)
+	if err != nil && err != zoneDomain.ErrZoneNotFound {
+		return err
+	}
+	if existing != nil && existing.ID.String() != currentID {
+		return zoneDomain.ErrZoneAlreadyExists
+	}
+	return nil
+}
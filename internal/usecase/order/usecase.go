@@ -0,0 +1,262 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	orderDomain "erp-api/internal/domain/order"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+	slabUseCase "erp-api/internal/usecase/slab"
+	stockUseCase "erp-api/internal/usecase/stock"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	CreateFromQuote(ctx context.Context, tenantID, userID string, req *orderDomain.CreateOrderDTO) (*orderDomain.OrderDetailDTO, error)
+	GetDetail(ctx context.Context, tenantID, id string) (*orderDomain.OrderDetailDTO, error)
+	List(ctx context.Context, tenantID string, filter orderDomain.ListFilter, limit, offset int) ([]*orderDomain.Order, error)
+	Count(ctx context.Context, tenantID string, filter orderDomain.ListFilter) (int, error)
+	UpdateStatus(ctx context.Context, tenantID, id, userID string, req *orderDomain.UpdateOrderStatusDTO) (*orderDomain.Order, error)
+}
+
+type UseCase struct {
+	orderRepo orderDomain.Repository
+	itemRepo  orderDomain.ItemRepository
+	uow       database.UnitOfWork
+}
+
+func NewUseCase(
+	orderRepo orderDomain.Repository,
+	itemRepo orderDomain.ItemRepository,
+	uow database.UnitOfWork,
+) UseCaseInterface {
+	return &UseCase{
+		orderRepo: orderRepo,
+		itemRepo:  itemRepo,
+		uow:       uow,
+	}
+}
+
+// CreateFromQuote gera o pedido de um orçamento aprovado, copiando valores e itens.
+// O orçamento é lido com a linha travada, então duas conversões simultâneas não
+// geram dois pedidos e um cancelamento concorrente não passa despercebido.
+func (u *UseCase) CreateFromQuote(ctx context.Context, tenantID, userID string, req *orderDomain.CreateOrderDTO) (*orderDomain.OrderDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var detail *orderDomain.OrderDetailDTO
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		quote, err := tx.CreateQuoteRepository().GetForUpdate(ctx, tenantID, req.QuoteID)
+		if err != nil {
+			return err
+		}
+		if quote.Status != quoteDomain.QuoteStatusApproved {
+			return orderDomain.ErrQuoteNotApproved
+		}
+
+		// Um orçamento gera no máximo um pedido
+		orderRepo := tx.CreateOrderRepository()
+		if _, err := orderRepo.GetByQuoteID(ctx, tenantID, req.QuoteID); err == nil {
+			return orderDomain.ErrOrderAlreadyExists
+		} else if err != orderDomain.ErrOrderNotFound {
+			return err
+		}
+
+		quoteItems, err := tx.CreateQuoteItemRepository().GetByQuoteID(ctx, req.QuoteID)
+		if err != nil {
+			return err
+		}
+		// Em orçamentos com opções, o pedido leva apenas os itens da opção aprovada
+		quoteItems = quoteDomain.ActiveItems(quote, quoteItems)
+
+		createdBy := userID
+		if createdBy == "" {
+			createdBy = quote.UserID.String()
+		}
+		notes := req.Notes
+		if notes == "" {
+			notes = quote.Notes
+		}
+
+		order := &orderDomain.Order{
+			TenantID:    quote.TenantID,
+			QuoteID:     quote.ID,
+			ClientID:    quote.ClientID,
+			UserID:      dbtypes.UUID(createdBy),
+			Subtotal:    quote.Subtotal,
+			Discount:    quote.DiscountAmount,
+			TotalValue:  quote.TotalValue,
+			Status:      orderDomain.OrderStatusConfirmed,
+			Notes:       notes,
+			ConfirmedAt: time.Now(),
+		}
+		if err := orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+
+		productRepo := tx.CreateProductRepository()
+		itemRepo := tx.CreateOrderItemRepository()
+		items := make([]*orderDomain.OrderItem, 0, len(quoteItems))
+		for _, quoteItem := range quoteItems {
+			item, err := freezeItem(ctx, productRepo, tenantID, quoteItem)
+			if err != nil {
+				return err
+			}
+			item.OrderID = order.ID
+			if err := itemRepo.Create(ctx, item); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+
+		detail = &orderDomain.OrderDetailDTO{Order: order, Items: items}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// freezeItem copia o item do orçamento, guardando o nome atual do produto.
+func freezeItem(ctx context.Context, productRepo productDomain.Repository, tenantID string, quoteItem *quoteDomain.QuoteItem) (*orderDomain.OrderItem, error) {
+	item := &orderDomain.OrderItem{
+		TenantID:        quoteItem.TenantID,
+		QuoteItemID:     quoteItem.ID,
+		ProductID:       quoteItem.ProductID,
+		SlabID:          quoteItem.SlabID,
+		Kind:            quoteItem.Kind,
+		ServiceID:       quoteItem.ServiceID,
+		WidthCM:         quoteItem.WidthCM,
+		HeightCM:        quoteItem.HeightCM,
+		Thickness:       quoteItem.Thickness,
+		AreaM2:          quoteItem.AreaM2,
+		PriceType:       quoteItem.PriceType,
+		UnitPrice:       quoteItem.UnitPrice,
+		Quantity:        quoteItem.Quantity,
+		EdgeSurcharge:   quoteItem.EdgeSurcharge,
+		CutoutSurcharge: quoteItem.CutoutSurcharge,
+		DiscountType:    quoteItem.DiscountType,
+		DiscountAmount:  quoteItem.DiscountAmount,
+		Total:           quoteItem.Total,
+		UnitCost:        quoteItem.UnitCost,
+		Cost:            quoteItem.Cost,
+		EdgeType:        quoteItem.EdgeType,
+		EdgeSides:       quoteItem.EdgeSides,
+		HasCutout:       quoteItem.HasCutout,
+		ReferenceImage:  quoteItem.ReferenceImage,
+		Notes:           quoteItem.Notes,
+	}
+
+	// Serviços não têm produto; a descrição da linha faz as vezes do nome
+	if quoteItem.IsService() {
+		item.ProductName = quoteItem.Label()
+		return item, nil
+	}
+
+	product, err := productRepo.GetByID(ctx, tenantID, quoteItem.ProductIDString())
+	if err != nil && err != productDomain.ErrProductNotFound {
+		return nil, err
+	}
+	if product != nil {
+		item.ProductName = product.Name
+	}
+
+	return item, nil
+}
+
+func (u *UseCase) GetDetail(ctx context.Context, tenantID, id string) (*orderDomain.OrderDetailDTO, error) {
+	order, err := u.orderRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.GetByOrderID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderDomain.OrderDetailDTO{Order: order, Items: items}, nil
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, filter orderDomain.ListFilter, limit, offset int) ([]*orderDomain.Order, error) {
+	return u.orderRepo.List(ctx, tenantID, filter, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string, filter orderDomain.ListFilter) (int, error) {
+	return u.orderRepo.Count(ctx, tenantID, filter)
+}
+
+// UpdateStatus avança o pedido no fluxo. O pedido é lido com a linha travada,
+// então duas mudanças simultâneas não se sobrescrevem: a segunda parte do status
+// gravado pela primeira. O estoque acompanha a nova etapa na mesma transação.
+func (u *UseCase) UpdateStatus(ctx context.Context, tenantID, id, userID string, req *orderDomain.UpdateOrderStatusDTO) (*orderDomain.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var order *orderDomain.Order
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		orderRepo := tx.CreateOrderRepository()
+
+		var err error
+		order, err = orderRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := order.Advance(req.Status, time.Now()); err != nil {
+			return err
+		}
+
+		if err := syncStock(ctx, tx, order, userID); err != nil {
+			return err
+		}
+
+		return orderRepo.Update(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// syncStock acompanha a etapa do pedido no estoque. Ao entrar em produção, as
+// chapas reservadas pelo orçamento são usadas e as reservas do produto viram
+// saídas; no encerramento, o que ainda estiver reservado para o orçamento é
+// liberado, para que pedidos que já estavam em produção não prendam saldo.
+func syncStock(ctx context.Context, tx database.RepositoryFactory, order *orderDomain.Order, userID string) error {
+	if order.Status != orderDomain.OrderStatusInProduction && order.Status != orderDomain.OrderStatusClosed {
+		return nil
+	}
+
+	tenantID, quoteID := order.TenantID.String(), order.QuoteID.String()
+	quote, err := tx.CreateQuoteRepository().GetByID(ctx, tenantID, quoteID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status == orderDomain.OrderStatusClosed {
+		return stockUseCase.Release(ctx, tx, tenantID, userID, stockDomain.ReferenceQuote, quoteID,
+			fmt.Sprintf("Liberação do orçamento %s (pedido encerrado)", quote.Number))
+	}
+
+	if err := slabUseCase.Consume(ctx, tx, tenantID, quoteID); err != nil {
+		return err
+	}
+
+	// A mesma trava da aprovação: sem ela, a produção começa mesmo sem saldo físico
+	settings, err := tx.CreateSettingsRepository().Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return stockUseCase.Consume(ctx, tx, tenantID, userID, stockDomain.ReferenceQuote, quoteID,
+		fmt.Sprintf("Saída para produção do orçamento %s", quote.Number), quoteDomain.BlockOversellFromSettings(settings))
+}
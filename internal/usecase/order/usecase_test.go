@@ -0,0 +1,244 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	orderDomain "erp-api/internal/domain/order"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/usecase/usecasetest"
+	"erp-api/internal/utils/dbtypes"
+)
+
+func newTestUseCase(store *usecasetest.Store) UseCaseInterface {
+	factory := store.Factory()
+	return NewUseCase(factory.CreateOrderRepository(), factory.CreateOrderItemRepository(), store)
+}
+
+// seedApprovedQuote grava um orçamento aprovado com duas opções; a opção A foi
+// a escolhida e tem uma peça com chapa, desconto e bordas, e uma instalação.
+func seedApprovedQuote(store *usecasetest.Store) *quoteDomain.Quote {
+	productID := dbtypes.UUID("prod-1")
+	slabID := dbtypes.UUID("slab-1")
+	serviceID := dbtypes.UUID("svc-1")
+	optA := dbtypes.UUID("opt-a")
+	optB := dbtypes.UUID("opt-b")
+
+	store.Products["prod-1"] = &productDomain.Product{ID: productID, TenantID: "tenant-1", Name: "Granito São Gabriel"}
+
+	quote := &quoteDomain.Quote{
+		ID:               "quote-1",
+		TenantID:         "tenant-1",
+		ClientID:         "client-1",
+		UserID:           "seller-1",
+		Status:           quoteDomain.QuoteStatusApproved,
+		SelectedOptionID: &optA,
+		Subtotal:         1150,
+		DiscountAmount:   50,
+		TotalValue:       1100,
+		Notes:            "Entregar pela manhã",
+	}
+	store.Quotes["quote-1"] = quote
+
+	items := []*quoteDomain.QuoteItem{
+		{
+			ID: "item-1", TenantID: "tenant-1", QuoteID: "quote-1", OptionID: &optA,
+			Kind: quoteDomain.ItemKindProduct, ProductID: &productID, SlabID: &slabID,
+			WidthCM: 200, HeightCM: 60, AreaM2: 1.2, PriceType: productDomain.PriceTypeSquareMeter,
+			UnitPrice: 800, Quantity: 1, EdgeSurcharge: 40, Total: 950,
+			UnitCost: 400, Cost: 480,
+			Discount: 10, DiscountType: quoteDomain.DiscountTypePercent, DiscountAmount: 50,
+			EdgeType: "reta", EdgeSides: "front,left",
+		},
+		{
+			ID: "item-2", TenantID: "tenant-1", QuoteID: "quote-1", OptionID: &optA,
+			Kind: quoteDomain.ItemKindService, ServiceID: &serviceID, Description: "Instalação",
+			PriceType: productDomain.PriceTypeUnit, UnitPrice: 200, Quantity: 1, Total: 200,
+			UnitCost: 120, Cost: 120,
+		},
+		{
+			ID: "item-3", TenantID: "tenant-1", QuoteID: "quote-1", OptionID: &optB,
+			Kind: quoteDomain.ItemKindProduct, ProductID: &productID,
+			UnitPrice: 500, Quantity: 1, Total: 500,
+		},
+	}
+	for _, item := range items {
+		store.Items[item.ID.String()] = item
+	}
+	return quote
+}
+
+func TestUseCase_CreateFromQuote(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedApprovedQuote(store)
+	useCase := newTestUseCase(store)
+
+	detail, err := useCase.CreateFromQuote(context.Background(), "tenant-1", "", &orderDomain.CreateOrderDTO{QuoteID: "quote-1"})
+	if err != nil {
+		t.Fatalf("CreateFromQuote() error = %v", err)
+	}
+	if store.Locks["quotes"] != 1 {
+		t.Errorf("quote locked %d times, want 1", store.Locks["quotes"])
+	}
+
+	order := detail.Order
+	if order.Status != orderDomain.OrderStatusConfirmed || order.ConfirmedAt.IsZero() {
+		t.Errorf("order = %s confirmed at %v, want confirmed now", order.Status, order.ConfirmedAt)
+	}
+	if order.UserID != "seller-1" || order.ClientID != "client-1" || order.Notes != "Entregar pela manhã" {
+		t.Errorf("order = user %s, client %s, notes %q; want copied from the quote", order.UserID, order.ClientID, order.Notes)
+	}
+	if order.Subtotal != 1150 || order.Discount != 50 || order.TotalValue != 1100 {
+		t.Errorf("order values = %.2f - %.2f = %.2f, want 1150 - 50 = 1100", order.Subtotal, order.Discount, order.TotalValue)
+	}
+
+	// Só os itens da opção aprovada entram no pedido
+	if len(detail.Items) != 2 || len(store.OrderItems) != 2 {
+		t.Fatalf("items = %d (stored %d), want 2", len(detail.Items), len(store.OrderItems))
+	}
+
+	piece := detail.Items[0]
+	if piece.OrderID != order.ID || piece.QuoteItemID != "item-1" || piece.ProductName != "Granito São Gabriel" {
+		t.Errorf("piece = order %s, quote item %s, name %q", piece.OrderID, piece.QuoteItemID, piece.ProductName)
+	}
+	if piece.SlabID == nil || *piece.SlabID != "slab-1" {
+		t.Errorf("piece slab = %v, want slab-1", piece.SlabID)
+	}
+	if piece.DiscountType != quoteDomain.DiscountTypePercent || piece.DiscountAmount != 50 || piece.Total != 950 {
+		t.Errorf("piece discount = %s %.2f, total %.2f; want percent 50, total 950", piece.DiscountType, piece.DiscountAmount, piece.Total)
+	}
+	if piece.UnitCost != 400 || piece.Cost != 480 {
+		t.Errorf("piece cost = %.2f/%.2f, want 400/480", piece.UnitCost, piece.Cost)
+	}
+	if piece.EdgeType != "reta" || piece.EdgeSides != "front,left" || piece.EdgeSurcharge != 40 {
+		t.Errorf("piece edge = %q %q %.2f, want reta front,left 40", piece.EdgeType, piece.EdgeSides, piece.EdgeSurcharge)
+	}
+
+	service := detail.Items[1]
+	if service.ProductName != "Instalação" || service.ProductID != nil || service.Cost != 120 {
+		t.Errorf("service = %q product %v cost %.2f, want Instalação without product, cost 120", service.ProductName, service.ProductID, service.Cost)
+	}
+}
+
+func TestUseCase_CreateFromQuote_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(store *usecasetest.Store)
+		want  error
+	}{
+		{"quote not found", func(store *usecasetest.Store) { delete(store.Quotes, "quote-1") }, quoteDomain.ErrQuoteNotFound},
+		{"quote not approved", func(store *usecasetest.Store) { store.Quotes["quote-1"].Status = quoteDomain.QuoteStatusCancelled }, orderDomain.ErrQuoteNotApproved},
+		{"order already exists", func(store *usecasetest.Store) {
+			store.Orders["order-0"] = &orderDomain.Order{ID: "order-0", TenantID: "tenant-1", QuoteID: "quote-1"}
+		}, orderDomain.ErrOrderAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := usecasetest.NewStore()
+			seedApprovedQuote(store)
+			tt.setup(store)
+			before := len(store.Orders)
+
+			_, err := newTestUseCase(store).CreateFromQuote(context.Background(), "tenant-1", "user-1", &orderDomain.CreateOrderDTO{QuoteID: "quote-1"})
+			if err != tt.want {
+				t.Fatalf("CreateFromQuote() error = %v, want %v", err, tt.want)
+			}
+			if len(store.Orders) != before || len(store.OrderItems) != 0 {
+				t.Errorf("orders = %d, items = %d; want nothing created", len(store.Orders)-before, len(store.OrderItems))
+			}
+		})
+	}
+}
+
+func TestUseCase_CreateFromQuote_RollsBack(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedApprovedQuote(store)
+	store.FailOn = "orderItems.Create"
+
+	_, err := newTestUseCase(store).CreateFromQuote(context.Background(), "tenant-1", "user-1", &orderDomain.CreateOrderDTO{QuoteID: "quote-1"})
+	if err != usecasetest.ErrInjected {
+		t.Fatalf("CreateFromQuote() error = %v, want %v", err, usecasetest.ErrInjected)
+	}
+	if len(store.Orders) != 0 || len(store.OrderItems) != 0 {
+		t.Errorf("orders = %d, items = %d; want the order rolled back", len(store.Orders), len(store.OrderItems))
+	}
+}
+
+func TestUseCase_UpdateStatus(t *testing.T) {
+	store := usecasetest.NewStore()
+	store.Orders["order-1"] = &orderDomain.Order{ID: "order-1", TenantID: "tenant-1", QuoteID: "quote-1", Status: orderDomain.OrderStatusConfirmed}
+	store.Quotes["quote-1"] = &quoteDomain.Quote{ID: "quote-1", TenantID: "tenant-1", Status: quoteDomain.QuoteStatusApproved}
+	quote1, quote2 := dbtypes.UUID("quote-1"), dbtypes.UUID("quote-2")
+	store.Slabs["slab-1"] = &slabDomain.Slab{ID: "slab-1", TenantID: "tenant-1", Status: slabDomain.StatusReserved, QuoteID: &quote1}
+	store.Slabs["slab-2"] = &slabDomain.Slab{ID: "slab-2", TenantID: "tenant-1", Status: slabDomain.StatusReserved, QuoteID: &quote2}
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	order, err := useCase.UpdateStatus(ctx, "tenant-1", "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusInProduction})
+	if err != nil {
+		t.Fatalf("UpdateStatus(in_production) error = %v", err)
+	}
+	if order.Status != orderDomain.OrderStatusInProduction || store.Orders["order-1"].ProductionStartedAt == nil {
+		t.Errorf("order = %s started at %v, want in_production with start time", order.Status, store.Orders["order-1"].ProductionStartedAt)
+	}
+	if store.Locks["orders"] != 1 {
+		t.Errorf("order locked %d times, want 1", store.Locks["orders"])
+	}
+	// Só a chapa do orçamento do pedido recebe baixa
+	if slab := store.Slabs["slab-1"]; slab.Status != slabDomain.StatusUsed {
+		t.Errorf("slab-1 = %s, want used", slab.Status)
+	}
+	if slab := store.Slabs["slab-2"]; slab.Status != slabDomain.StatusReserved {
+		t.Errorf("slab-2 = %s, want still reserved", slab.Status)
+	}
+
+	if _, err := useCase.UpdateStatus(ctx, "tenant-1", "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusConfirmed}); err != orderDomain.ErrInvalidStatusTransition {
+		t.Errorf("UpdateStatus(confirmed) error = %v, want %v", err, orderDomain.ErrInvalidStatusTransition)
+	}
+	if _, err := useCase.UpdateStatus(ctx, "tenant-1", "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: "shipped"}); err != orderDomain.ErrInvalidOrderStatus {
+		t.Errorf("UpdateStatus(shipped) error = %v, want %v", err, orderDomain.ErrInvalidOrderStatus)
+	}
+	if _, err := useCase.UpdateStatus(ctx, "tenant-2", "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusReady}); err != orderDomain.ErrOrderNotFound {
+		t.Errorf("UpdateStatus() from another tenant error = %v, want %v", err, orderDomain.ErrOrderNotFound)
+	}
+}
+
+func TestUseCase_UpdateStatus_Oversell(t *testing.T) {
+	tests := []struct {
+		name      string
+		block     string
+		wantErr   error
+		wantStock int
+	}{
+		{name: "blocked", block: "true", wantErr: stockDomain.ErrInsufficientStock, wantStock: 2},
+		{name: "allowed", block: "false", wantStock: -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := usecasetest.NewStore()
+			store.Settings[quoteDomain.SettingBlockOversell] = tt.block
+			store.Orders["order-1"] = &orderDomain.Order{ID: "order-1", TenantID: "tenant-1", QuoteID: "quote-1", Status: orderDomain.OrderStatusConfirmed}
+			store.Quotes["quote-1"] = &quoteDomain.Quote{ID: "quote-1", TenantID: "tenant-1", Status: quoteDomain.QuoteStatusApproved}
+			store.Products["prod-1"] = &productDomain.Product{ID: "prod-1", TenantID: "tenant-1", Stock: 2}
+			// O orçamento foi aprovado sem a trava, reservando mais do que o saldo físico
+			store.Movements = []*stockDomain.Movement{
+				stockDomain.NewMovement("tenant-1", "prod-1", stockDomain.TypeAdjustment, 2, "Saldo inicial"),
+				stockDomain.NewMovement("tenant-1", "prod-1", stockDomain.TypeReservation, 5, "Reserva").
+					WithReference(stockDomain.ReferenceQuote, "quote-1"),
+			}
+			useCase := newTestUseCase(store)
+
+			_, err := useCase.UpdateStatus(context.Background(), "tenant-1", "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusInProduction})
+			if err != tt.wantErr {
+				t.Fatalf("UpdateStatus(in_production) error = %v, want %v", err, tt.wantErr)
+			}
+			if stock := store.Products["prod-1"].Stock; stock != tt.wantStock {
+				t.Errorf("stock = %d, want %d", stock, tt.wantStock)
+			}
+		})
+	}
+}
@@ -5,47 +5,77 @@ import (
 	"time"
 
 	productDomain "erp-api/internal/domain/product"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+	stockUseCase "erp-api/internal/usecase/stock"
 	"erp-api/internal/utils/dbtypes"
 )
 
 type UseCaseInterface interface {
-	Create(ctx context.Context, req *productDomain.CreateProductDTO) (*productDomain.Product, error)
+	Create(ctx context.Context, userID string, req *productDomain.CreateProductDTO) (*productDomain.Product, error)
 	GetByID(ctx context.Context, tenantID, id string) (*productDomain.Product, error)
-	Update(ctx context.Context, tenantID, id string, req *productDomain.UpdateProductDTO) (*productDomain.Product, error)
+	Update(ctx context.Context, tenantID, userID, id string, req *productDomain.UpdateProductDTO) (*productDomain.Product, error)
 	Delete(ctx context.Context, tenantID, id string) error
 	List(ctx context.Context, tenantID string, limit, offset int) ([]*productDomain.Product, error)
 	Count(ctx context.Context, tenantID string) (int, error)
+	Reserved(ctx context.Context, tenantID string, productIDs []string) (map[string]int, error)
 }
 
 type UseCase struct {
 	productRepo productDomain.Repository
+	stockRepo   stockDomain.Repository
+	uow         database.UnitOfWork
 }
 
-func NewUseCase(productRepo productDomain.Repository) UseCaseInterface {
+func NewUseCase(productRepo productDomain.Repository, stockRepo stockDomain.Repository, uow database.UnitOfWork) UseCaseInterface {
 	return &UseCase{
 		productRepo: productRepo,
+		stockRepo:   stockRepo,
+		uow:         uow,
 	}
 }
 
-func (u *UseCase) Create(ctx context.Context, req *productDomain.CreateProductDTO) (*productDomain.Product, error) {
+// Create cadastra o produto; o estoque informado entra no livro como saldo inicial.
+func (u *UseCase) Create(ctx context.Context, userID string, req *productDomain.CreateProductDTO) (*productDomain.Product, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	priceType := req.PriceType
+	if priceType == "" {
+		priceType = productDomain.PriceTypeUnit
+	}
+
 	// Criar produto
 	newProduct := &productDomain.Product{
 		TenantID:    dbtypes.UUID(req.TenantID),
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
-		Stock:       req.Stock,
+		CostPrice:   req.CostPrice,
+		PriceType:   priceType,
 		SKU:         req.SKU,
 		Category:    req.Category,
 		ImageURL:    req.ImageURL,
 		IsActive:    true,
 	}
 
-	err := u.productRepo.Create(ctx, newProduct)
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		if err := tx.CreateProductRepository().Create(ctx, newProduct); err != nil {
+			return err
+		}
+		if req.Stock == 0 {
+			return nil
+		}
+
+		movement := stockDomain.NewMovement(req.TenantID, newProduct.ID.String(), stockDomain.TypeEntry, req.Stock, stockDomain.ReasonOpeningBalance).
+			WithUser(userID)
+		if err := stockUseCase.Post(ctx, tx, movement); err != nil {
+			return err
+		}
+		newProduct.Stock = movement.Balance
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -57,42 +87,90 @@ func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*productDom
 	return u.productRepo.GetByID(ctx, tenantID, id)
 }
 
-func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *productDomain.UpdateProductDTO) (*productDomain.Product, error) {
-	product, err := u.productRepo.GetByID(ctx, tenantID, id)
-	if err != nil {
-		return nil, err
-	}
-
-	//TODO: Do WithName, WithDescription, WithPrice, WithStock, WithSKU, WithCategory, WithImageURL, WithIsActive
-	// Atualizar campos
-	if req.Name != "" {
-		product.Name = req.Name
-	}
-	if req.Description != "" {
-		product.Description = req.Description
+// Update altera o cadastro. Um novo estoque vira um lançamento de ajuste com a
+// diferença, para a alteração ficar registrada no livro. O produto é relido com
+// a linha travada, então a diferença parte do saldo atual.
+func (u *UseCase) Update(ctx context.Context, tenantID, userID, id string, req *productDomain.UpdateProductDTO) (*productDomain.Product, error) {
+	if req.CostPrice != nil && *req.CostPrice < 0 {
+		return nil, productDomain.ErrInvalidCostPrice
 	}
-	if req.Price != nil {
-		product.Price = *req.Price
+	if req.PriceType != "" && !req.PriceType.IsValid() {
+		return nil, productDomain.ErrInvalidPriceType
 	}
-	if req.Stock != nil {
-		product.Stock = *req.Stock
-	}
-	if req.SKU != "" {
-		product.SKU = req.SKU
-	}
-	if req.Category != "" {
-		product.Category = req.Category
-	}
-	if req.ImageURL != "" {
-		product.ImageURL = req.ImageURL
-	}
-	if req.IsActive != nil {
-		product.IsActive = *req.IsActive
+	if req.Stock != nil && *req.Stock < 0 {
+		return nil, productDomain.ErrInvalidStock
 	}
 
-	product.UpdatedAt = time.Now()
-
-	err = u.productRepo.Update(ctx, product)
+	var product *productDomain.Product
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		productRepo := tx.CreateProductRepository()
+
+		var err error
+		product, err = productRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		//TODO: Do WithName, WithDescription, WithPrice, WithStock, WithSKU, WithCategory, WithImageURL, WithIsActive
+		// Atualizar campos
+		if req.Name != "" {
+			product.Name = req.Name
+		}
+		if req.Description != "" {
+			product.Description = req.Description
+		}
+		if req.Price != nil {
+			product.Price = *req.Price
+		}
+		if req.CostPrice != nil {
+			product.CostPrice = *req.CostPrice
+		}
+		if req.PriceType != "" && req.PriceType != product.PriceType {
+			// O livro de estoque guarda as quantidades na unidade do tipo de preço
+			movements, err := tx.CreateStockMovementRepository().Count(ctx, tenantID, id, stockDomain.ListFilter{})
+			if err != nil {
+				return err
+			}
+			if movements > 0 {
+				return productDomain.ErrPriceTypeInUse
+			}
+			product.PriceType = req.PriceType
+		}
+		var adjustment *stockDomain.Movement
+		if req.Stock != nil {
+			if delta := *req.Stock - product.Stock; delta != 0 {
+				adjustment = stockDomain.NewMovement(tenantID, id, stockDomain.TypeAdjustment, delta, stockDomain.ReasonProductEdit).
+					WithUser(userID)
+			}
+		}
+		if req.SKU != "" {
+			product.SKU = req.SKU
+		}
+		if req.Category != "" {
+			product.Category = req.Category
+		}
+		if req.ImageURL != "" {
+			product.ImageURL = req.ImageURL
+		}
+		if req.IsActive != nil {
+			product.IsActive = *req.IsActive
+		}
+
+		product.UpdatedAt = time.Now()
+
+		if err := productRepo.Update(ctx, product); err != nil {
+			return err
+		}
+		if adjustment == nil {
+			return nil
+		}
+
+		if err := stockUseCase.Post(ctx, tx, adjustment); err != nil {
+			return err
+		}
+		product.Stock = adjustment.Balance
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +178,33 @@ func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *productD
 	return product, nil
 }
 
+// Delete remove o produto. Produto com lançamentos no livro de estoque não é
+// removido, para o histórico continuar apontando para ele: fica só desativado.
+// A linha é travada, então um lançamento concorrente não escapa da contagem.
 func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
-	return u.productRepo.Delete(ctx, tenantID, id)
+	return u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		productRepo := tx.CreateProductRepository()
+
+		product, err := productRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		movements, err := tx.CreateStockMovementRepository().Count(ctx, tenantID, id, stockDomain.ListFilter{})
+		if err != nil {
+			return err
+		}
+		if movements == 0 {
+			return productRepo.Delete(ctx, tenantID, id)
+		}
+
+		if !product.IsActive {
+			return nil
+		}
+		product.IsActive = false
+		product.UpdatedAt = time.Now()
+		return productRepo.Update(ctx, product)
+	})
 }
 
 func (u *UseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]*productDomain.Product, error) {
@@ -111,3 +214,8 @@ func (u *UseCase) List(ctx context.Context, tenantID string, limit, offset int)
 func (u *UseCase) Count(ctx context.Context, tenantID string) (int, error) {
 	return u.productRepo.Count(ctx, tenantID)
 }
+
+// Reserved retorna quanto de cada produto está reservado por orçamentos aprovados.
+func (u *UseCase) Reserved(ctx context.Context, tenantID string, productIDs []string) (map[string]int, error) {
+	return u.stockRepo.ReservedByProduct(ctx, tenantID, productIDs)
+}
@@ -0,0 +1,68 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	productDomain "erp-api/internal/domain/product"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/usecase/usecasetest"
+)
+
+func TestUseCase_Delete(t *testing.T) {
+	store := usecasetest.NewStore()
+	store.Products["granito"] = &productDomain.Product{ID: "granito", TenantID: "tenant-1", Stock: 10, IsActive: true}
+	store.Products["quartzo"] = &productDomain.Product{ID: "quartzo", TenantID: "tenant-1", IsActive: true}
+	store.Movements = append(store.Movements, stockDomain.NewMovement("tenant-1", "granito", stockDomain.TypeEntry, 10, stockDomain.ReasonOpeningBalance))
+	factory := store.Factory()
+	useCase := NewUseCase(factory.CreateProductRepository(), factory.CreateStockMovementRepository(), store)
+	ctx := context.Background()
+
+	// Com lançamentos no livro, o produto só é desativado
+	if err := useCase.Delete(ctx, "tenant-1", "granito"); err != nil {
+		t.Fatalf("Delete(granito) error = %v", err)
+	}
+	if granito, ok := store.Products["granito"]; !ok || granito.IsActive || granito.Stock != 10 {
+		t.Errorf("granito = %+v, want kept inactive with its stock", granito)
+	}
+
+	if err := useCase.Delete(ctx, "tenant-1", "quartzo"); err != nil {
+		t.Fatalf("Delete(quartzo) error = %v", err)
+	}
+	if _, ok := store.Products["quartzo"]; ok || len(store.Deleted) != 1 {
+		t.Errorf("deleted = %v, want quartzo removed", store.Deleted)
+	}
+
+	if err := useCase.Delete(ctx, "tenant-2", "granito"); err != productDomain.ErrProductNotFound {
+		t.Errorf("Delete() from another tenant error = %v, want %v", err, productDomain.ErrProductNotFound)
+	}
+}
+
+func TestUseCase_Update_PriceType(t *testing.T) {
+	store := usecasetest.NewStore()
+	store.Products["granito"] = &productDomain.Product{ID: "granito", TenantID: "tenant-1", PriceType: productDomain.PriceTypeSquareMeter}
+	store.Products["quartzo"] = &productDomain.Product{ID: "quartzo", TenantID: "tenant-1", PriceType: productDomain.PriceTypeSquareMeter}
+	store.Movements = append(store.Movements,
+		stockDomain.NewMovement("tenant-1", "granito", stockDomain.TypeReservation, 3, "Reserva do orçamento ORC-2026-000001"))
+	factory := store.Factory()
+	useCase := NewUseCase(factory.CreateProductRepository(), factory.CreateStockMovementRepository(), store)
+	ctx := context.Background()
+
+	// A reserva foi lançada em m²; trocar a unidade desalinharia o livro
+	req := &productDomain.UpdateProductDTO{PriceType: productDomain.PriceTypeUnit}
+	if _, err := useCase.Update(ctx, "tenant-1", "user-1", "granito", req); err != productDomain.ErrPriceTypeInUse {
+		t.Fatalf("Update(granito) error = %v, want %v", err, productDomain.ErrPriceTypeInUse)
+	}
+	if granito := store.Products["granito"]; granito.PriceType != productDomain.PriceTypeSquareMeter {
+		t.Errorf("granito price type = %s, want it kept", granito.PriceType)
+	}
+
+	// Sem lançamentos, a troca é livre
+	quartzo, err := useCase.Update(ctx, "tenant-1", "user-1", "quartzo", req)
+	if err != nil {
+		t.Fatalf("Update(quartzo) error = %v", err)
+	}
+	if quartzo.PriceType != productDomain.PriceTypeUnit {
+		t.Errorf("quartzo price type = %s, want %s", quartzo.PriceType, productDomain.PriceTypeUnit)
+	}
+}
@@ -0,0 +1,334 @@
+package production
+
+import (
+	"context"
+	"time"
+
+	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
+	quoteDomain "erp-api/internal/domain/quote"
+	settingsDomain "erp-api/internal/domain/settings"
+	userDomain "erp-api/internal/domain/user"
+	"erp-api/internal/infra/database"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	CreateFromQuote(ctx context.Context, tenantID string, req *productionDomain.CreateWorkOrdersDTO) ([]*productionDomain.WorkOrderDetailDTO, error)
+	GetDetail(ctx context.Context, tenantID, id string) (*productionDomain.WorkOrderDetailDTO, error)
+	List(ctx context.Context, tenantID string, filter productionDomain.ListFilter, limit, offset int) ([]*productionDomain.WorkOrder, error)
+	Count(ctx context.Context, tenantID string, filter productionDomain.ListFilter) (int, error)
+	StartStage(ctx context.Context, tenantID, id, userID string, req *productionDomain.StageActionDTO) (*productionDomain.WorkOrderDetailDTO, error)
+	CompleteStage(ctx context.Context, tenantID, id, userID string, req *productionDomain.StageActionDTO) (*productionDomain.WorkOrderDetailDTO, error)
+	AssignStage(ctx context.Context, tenantID, id string, req *productionDomain.AssignStageDTO) (*productionDomain.WorkOrderDetailDTO, error)
+	Board(ctx context.Context, tenantID string) (*productionDomain.Board, error)
+}
+
+type UseCase struct {
+	workOrderRepo productionDomain.Repository
+	stageRepo     productionDomain.StageRepository
+	quoteRepo     quoteDomain.Repository
+	quoteItemRepo quoteDomain.ItemRepository
+	productRepo   productDomain.Repository
+	userRepo      userDomain.Repository
+	settingsRepo  settingsDomain.Repository
+	uow           database.UnitOfWork
+}
+
+func NewUseCase(
+	workOrderRepo productionDomain.Repository,
+	stageRepo productionDomain.StageRepository,
+	quoteRepo quoteDomain.Repository,
+	quoteItemRepo quoteDomain.ItemRepository,
+	productRepo productDomain.Repository,
+	userRepo userDomain.Repository,
+	settingsRepo settingsDomain.Repository,
+	uow database.UnitOfWork,
+) UseCaseInterface {
+	return &UseCase{
+		workOrderRepo: workOrderRepo,
+		stageRepo:     stageRepo,
+		quoteRepo:     quoteRepo,
+		quoteItemRepo: quoteItemRepo,
+		productRepo:   productRepo,
+		userRepo:      userRepo,
+		settingsRepo:  settingsRepo,
+		uow:           uow,
+	}
+}
+
+// CreateFromQuote abre uma ordem de produção para cada peça do orçamento aprovado.
+// Serviços e frete não passam pela produção. O orçamento é lido com a linha
+// travada, como na geração do pedido, então duas chamadas simultâneas não abrem
+// as ordens duas vezes e um cancelamento concorrente não passa despercebido.
+func (u *UseCase) CreateFromQuote(ctx context.Context, tenantID string, req *productionDomain.CreateWorkOrdersDTO) ([]*productionDomain.WorkOrderDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	stages := productionDomain.StagesFromSettings(settings)
+
+	var details []*productionDomain.WorkOrderDetailDTO
+	err = u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		quote, err := tx.CreateQuoteRepository().GetForUpdate(ctx, tenantID, req.QuoteID)
+		if err != nil {
+			return err
+		}
+		if quote.Status != quoteDomain.QuoteStatusApproved {
+			return productionDomain.ErrQuoteNotApproved
+		}
+
+		workOrderRepo := tx.CreateWorkOrderRepository()
+		existing, err := workOrderRepo.Count(ctx, tenantID, productionDomain.ListFilter{QuoteID: req.QuoteID})
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return productionDomain.ErrWorkOrdersAlreadyExist
+		}
+
+		quoteItems, err := tx.CreateQuoteItemRepository().GetByQuoteID(ctx, req.QuoteID)
+		if err != nil {
+			return err
+		}
+		// Em orçamentos com opções, só os itens da opção aprovada são produzidos
+		quoteItems = quoteDomain.ProductItems(quoteDomain.ActiveItems(quote, quoteItems))
+		if len(quoteItems) == 0 {
+			return productionDomain.ErrNothingToProduce
+		}
+
+		stageRepo := tx.CreateWorkOrderStageRepository()
+		details = make([]*productionDomain.WorkOrderDetailDTO, 0, len(quoteItems))
+		for _, item := range quoteItems {
+			workOrder, err := u.workOrderFor(ctx, tenantID, item)
+			if err != nil {
+				return err
+			}
+			detail := &productionDomain.WorkOrderDetailDTO{
+				WorkOrder: workOrder,
+				Stages:    productionDomain.PlanStages(workOrder, stages),
+			}
+			if err := workOrderRepo.Create(ctx, workOrder); err != nil {
+				return err
+			}
+			for _, stage := range detail.Stages {
+				stage.WorkOrderID = workOrder.ID
+				if err := stageRepo.Create(ctx, stage); err != nil {
+					return err
+				}
+			}
+			details = append(details, detail)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// workOrderFor copia a peça do item do orçamento, com o nome atual do produto.
+func (u *UseCase) workOrderFor(ctx context.Context, tenantID string, item *quoteDomain.QuoteItem) (*productionDomain.WorkOrder, error) {
+	workOrder := &productionDomain.WorkOrder{
+		TenantID:    item.TenantID,
+		QuoteID:     item.QuoteID,
+		QuoteItemID: item.ID,
+		ProductID:   item.ProductID,
+		WidthCM:     item.WidthCM,
+		HeightCM:    item.HeightCM,
+		Thickness:   item.Thickness,
+		Quantity:    item.Quantity,
+		EdgeType:    item.EdgeType,
+		HasCutout:   item.HasCutout,
+		Notes:       item.Notes,
+	}
+
+	product, err := u.productRepo.GetByID(ctx, tenantID, item.ProductIDString())
+	if err != nil && err != productDomain.ErrProductNotFound {
+		return nil, err
+	}
+	if product != nil {
+		workOrder.ProductName = product.Name
+	}
+
+	return workOrder, nil
+}
+
+func (u *UseCase) GetDetail(ctx context.Context, tenantID, id string) (*productionDomain.WorkOrderDetailDTO, error) {
+	workOrder, err := u.workOrderRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := u.stageRepo.ListByWorkOrderID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productionDomain.WorkOrderDetailDTO{WorkOrder: workOrder, Stages: stages}, nil
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, filter productionDomain.ListFilter, limit, offset int) ([]*productionDomain.WorkOrder, error) {
+	return u.workOrderRepo.List(ctx, tenantID, filter, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string, filter productionDomain.ListFilter) (int, error) {
+	return u.workOrderRepo.Count(ctx, tenantID, filter)
+}
+
+// StartStage inicia a etapa atual. Sem responsável informado, a etapa fica com
+// quem já estava atribuído ou, se ninguém, com quem a iniciou.
+func (u *UseCase) StartStage(ctx context.Context, tenantID, id, userID string, req *productionDomain.StageActionDTO) (*productionDomain.WorkOrderDetailDTO, error) {
+	assignee, err := u.assignee(ctx, tenantID, req.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.advance(ctx, tenantID, id, func(detail *productionDomain.WorkOrderDetailDTO) (*productionDomain.WorkOrderStage, error) {
+		current := productionDomain.CurrentStage(detail.WorkOrder, detail.Stages)
+		if assignee == nil && current != nil && current.AssigneeID == nil && userID != "" {
+			uid := dbtypes.UUID(userID)
+			assignee = &uid
+		}
+
+		stage, err := productionDomain.Start(detail.WorkOrder, detail.Stages, assignee, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if req.Notes != "" {
+			stage.Notes = req.Notes
+		}
+		return stage, nil
+	})
+}
+
+// CompleteStage conclui a etapa atual e move a ordem para a próxima.
+func (u *UseCase) CompleteStage(ctx context.Context, tenantID, id, userID string, req *productionDomain.StageActionDTO) (*productionDomain.WorkOrderDetailDTO, error) {
+	assignee, err := u.assignee(ctx, tenantID, req.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var completedBy *dbtypes.UUID
+	if userID != "" {
+		uid := dbtypes.UUID(userID)
+		completedBy = &uid
+	}
+
+	return u.advance(ctx, tenantID, id, func(detail *productionDomain.WorkOrderDetailDTO) (*productionDomain.WorkOrderStage, error) {
+		if assignee != nil {
+			if _, err := productionDomain.Assign(detail.WorkOrder, detail.Stages, *assignee); err != nil {
+				return nil, err
+			}
+		}
+
+		stage, err := productionDomain.Complete(detail.WorkOrder, detail.Stages, completedBy, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if req.Notes != "" {
+			stage.Notes = req.Notes
+		}
+		return stage, nil
+	})
+}
+
+// AssignStage troca o responsável pela etapa atual.
+func (u *UseCase) AssignStage(ctx context.Context, tenantID, id string, req *productionDomain.AssignStageDTO) (*productionDomain.WorkOrderDetailDTO, error) {
+	assignee, err := u.assignee(ctx, tenantID, req.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	if assignee == nil {
+		return nil, productionDomain.ErrInvalidAssignee
+	}
+
+	return u.advance(ctx, tenantID, id, func(detail *productionDomain.WorkOrderDetailDTO) (*productionDomain.WorkOrderStage, error) {
+		return productionDomain.Assign(detail.WorkOrder, detail.Stages, *assignee)
+	})
+}
+
+// advance aplica a mudança à ordem e grava a ordem e a etapa alterada juntas.
+// A ordem é lida com a linha travada, então dois apontamentos simultâneos na
+// mesma ordem são aplicados um depois do outro, cada um sobre as etapas atuais.
+func (u *UseCase) advance(ctx context.Context, tenantID, id string, change func(*productionDomain.WorkOrderDetailDTO) (*productionDomain.WorkOrderStage, error)) (*productionDomain.WorkOrderDetailDTO, error) {
+	var detail *productionDomain.WorkOrderDetailDTO
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		workOrderRepo := tx.CreateWorkOrderRepository()
+		stageRepo := tx.CreateWorkOrderStageRepository()
+
+		workOrder, err := workOrderRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		stages, err := stageRepo.ListByWorkOrderID(ctx, id)
+		if err != nil {
+			return err
+		}
+		productionDomain.SortStages(stages)
+		detail = &productionDomain.WorkOrderDetailDTO{WorkOrder: workOrder, Stages: stages}
+
+		stage, err := change(detail)
+		if err != nil {
+			return err
+		}
+
+		if err := stageRepo.Update(ctx, stage); err != nil {
+			return err
+		}
+		return workOrderRepo.Update(ctx, detail.WorkOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// assignee confere que o responsável informado é um usuário do tenant.
+func (u *UseCase) assignee(ctx context.Context, tenantID, id string) (*dbtypes.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == userDomain.ErrUserNotFound {
+			return nil, productionDomain.ErrInvalidAssignee
+		}
+		return nil, err
+	}
+	if user.TenantID.String() != tenantID {
+		return nil, productionDomain.ErrInvalidAssignee
+	}
+	return &user.ID, nil
+}
+
+// Board lista as ordens em produção agrupadas pela etapa atual.
+func (u *UseCase) Board(ctx context.Context, tenantID string) (*productionDomain.Board, error) {
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	workOrders, err := u.workOrderRepo.ListOpen(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(workOrders))
+	for _, workOrder := range workOrders {
+		ids = append(ids, workOrder.ID.String())
+	}
+	stages, err := u.stageRepo.ListByWorkOrderIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return productionDomain.BuildBoard(productionDomain.StagesFromSettings(settings), workOrders, stages), nil
+}
@@ -0,0 +1,87 @@
+package quote
+
+import (
+	"context"
+
+	"erp-api/internal/domain/cutting"
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+// CuttingPlan calcula o plano de corte das peças de um orçamento aprovado,
+// agrupando os itens por produto e distribuindo as peças nas chapas informadas.
+func (u *UseCase) CuttingPlan(ctx context.Context, tenantID, id string, req *cutting.PlanRequest) (*cutting.Plan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	quote, err := u.quoteRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != quoteDomain.QuoteStatusApproved {
+		return nil, quoteDomain.ErrQuoteNotApproved
+	}
+
+	items, err := u.itemRepo.GetByQuoteID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Só as peças da opção aprovada são cortadas
+	items = quoteDomain.ActiveItems(quote, items)
+
+	plan := &cutting.Plan{QuoteID: quote.ID.String()}
+
+	// Agrupa as peças por produto mantendo a ordem dos itens
+	var productIDs []string
+	pieces := make(map[string][]cutting.Piece)
+	total := 0
+	for _, item := range quoteDomain.ProductItems(items) {
+		if item.WidthCM <= 0 || item.HeightCM <= 0 {
+			plan.SkippedItems = append(plan.SkippedItems, item.ID.String())
+			continue
+		}
+
+		productID := item.ProductIDString()
+		if _, ok := pieces[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+
+		quantity := item.Quantity
+		if quantity < 1 {
+			quantity = 1
+		}
+		// O limite vale antes de expandir as cópias, que ocupam memória
+		if total += quantity; total > cutting.MaxPieces {
+			return nil, cutting.ErrTooManyPieces
+		}
+		for n := 1; n <= quantity; n++ {
+			pieces[productID] = append(pieces[productID], cutting.Piece{
+				ItemID:   item.ID.String(),
+				Copy:     n,
+				WidthCM:  item.WidthCM,
+				HeightCM: item.HeightCM,
+			})
+		}
+	}
+
+	opts := cutting.PackOptions{
+		AllowRotation: req.AllowRotation,
+		KerfCM:        req.KerfMM / 10,
+	}
+
+	for _, productID := range productIDs {
+		slab, ok := req.SlabFor(productID)
+		if !ok {
+			return nil, cutting.ErrMissingSlabSize
+		}
+
+		productPlan := cutting.NewProductPlan(productID, slab, pieces[productID], opts)
+		if product, err := u.productRepo.GetByID(ctx, tenantID, productID); err == nil {
+			productPlan.ProductName = product.Name
+		}
+		plan.Products = append(plan.Products, productPlan)
+	}
+
+	plan.Summarize()
+	return plan, nil
+}
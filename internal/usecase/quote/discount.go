@@ -0,0 +1,119 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// ApproveDiscount registra a aprovação, por um gerente, do desconto de um orçamento
+// aguardando aprovação. O orçamento volta a ficar pendente e o percentual aprovado
+// passa a ser o novo limite dele: só um desconto maior exige outra aprovação.
+func (u *UseCase) ApproveDiscount(ctx context.Context, tenantID, id, userID string, req *quoteDomain.ApproveDiscountDTO) (*quoteDomain.Quote, error) {
+	var quote *quoteDomain.Quote
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		// A linha travada garante que o percentual aprovado é o desconto atual, e não
+		// o de uma leitura anterior a uma edição concorrente
+		locked, err := r.quotes.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		if locked.Status != quoteDomain.QuoteStatusAwaitingApproval {
+			return quoteDomain.ErrDiscountApprovalNotPending
+		}
+		quote = locked
+
+		now := time.Now()
+		approver := dbtypes.UUID(userID)
+		from := quote.Status
+
+		quote.Status = quoteDomain.QuoteStatusPending
+		quote.DiscountApprovedPercent = quote.DiscountPercent
+		quote.DiscountApprovedBy = &approver
+		quote.DiscountApprovedAt = &now
+		quote.UpdatedAt = now
+
+		reason := req.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("Desconto de %s aprovado", formatPercent(quote.DiscountPercent))
+		}
+
+		if err := r.quotes.UpdateStatus(ctx, tenantID, id, from, quote.Status, nil); err != nil {
+			return err
+		}
+		if err := r.quotes.ApproveDiscount(ctx, quote); err != nil {
+			return err
+		}
+		return recordStatusChange(ctx, r, quote, from, quoteDomain.StatusChange{
+			Reason: reason,
+			UserID: userID,
+			Source: quoteDomain.StatusSourceUser,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// reprice atualiza o frete, recalcula os totais após uma edição e aplica a
+// política de descontos.
+func (u *UseCase) reprice(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) (*quoteDomain.QuoteDetailDTO, error) {
+	if err := u.refreshFreight(ctx, r, quote); err != nil {
+		return nil, err
+	}
+
+	detail, err := recalculate(ctx, r, quote)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.enforceDiscountPolicy(ctx, r, quote); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// enforceDiscountPolicy move o orçamento em edição para aguardando aprovação, ou de
+// volta para pendente, conforme o desconto efetivo e o limite do tenant.
+func (u *UseCase) enforceDiscountPolicy(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) error {
+	settings, err := u.settingsRepo.Get(ctx, quote.TenantID.String())
+	if err != nil {
+		return err
+	}
+	policy := quoteDomain.DiscountPolicyFromSettings(settings)
+
+	from := quote.Status
+	next := policy.StatusFor(quote)
+	if next == from {
+		return nil
+	}
+
+	if err := r.quotes.UpdateStatus(ctx, quote.TenantID.String(), quote.ID.String(), from, next, nil); err != nil {
+		return err
+	}
+	quote.Status = next
+
+	reason := "Desconto dentro do limite de aprovação"
+	if next == quoteDomain.QuoteStatusAwaitingApproval {
+		reason = discountApprovalReason(quote, policy)
+	}
+	return recordStatusChange(ctx, r, quote, from, quoteDomain.StatusChange{
+		Reason: reason,
+		Source: quoteDomain.StatusSourceSystem,
+	})
+}
+
+func discountApprovalReason(quote *quoteDomain.Quote, policy quoteDomain.DiscountPolicy) string {
+	return fmt.Sprintf("Desconto de %s acima do limite de aprovação de %s",
+		formatPercent(quote.DiscountPercent), formatPercent(policy.ApprovalPercent))
+}
+
+// formatPercent escreve o percentual no formato brasileiro, ex.: "12,50%".
+func formatPercent(value float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f%%", value), ".", ",", 1)
+}
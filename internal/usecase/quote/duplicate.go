@@ -0,0 +1,92 @@
+package quote
+
+import (
+	"context"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+// Duplicate cria um novo orçamento pendente para outro cliente com os mesmos
+// itens, opções, desconto, condições de pagamento e observações do original.
+// Os preços unitários do original são mantidos; número e validade são novos.
+func (u *UseCase) Duplicate(ctx context.Context, tenantID, id, userID string, req *quoteDomain.DuplicateQuoteDTO) (*quoteDomain.Quote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	original, err := u.quoteRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.GetByQuoteID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := u.optionRepo.ListByQuoteID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		userID = original.UserID.String()
+	}
+
+	create := &quoteDomain.CreateQuoteDTO{
+		TenantID:     tenantID,
+		ClientID:     req.ClientID,
+		UserID:       userID,
+		Discount:     original.Discount,
+		DiscountType: original.DiscountType,
+		PaymentTerms: &original.PaymentTerms,
+		Notes:        original.Notes,
+		ValidUntil:   req.ValidUntil,
+	}
+
+	// As opções são copiadas com os respectivos itens
+	if len(options) == 0 {
+		create.Items = copyItems(items)
+	}
+	for _, option := range options {
+		create.Options = append(create.Options, quoteDomain.QuoteOptionDTO{
+			Name:  option.Name,
+			Items: copyItems(quoteDomain.ItemsOf(option, items)),
+		})
+	}
+
+	return u.Create(ctx, create)
+}
+
+// copyItems converte itens existentes em DTOs mantendo o preço unitário e o desconto.
+// Linhas de frete ficam de fora: o novo orçamento calcula o próprio frete.
+func copyItems(items []*quoteDomain.QuoteItem) []quoteDomain.QuoteItemDTO {
+	dtos := make([]quoteDomain.QuoteItemDTO, 0, len(items))
+	for _, item := range items {
+		if item.IsFreight() {
+			continue
+		}
+		dto := quoteDomain.QuoteItemDTO{
+			ProductID:      item.ProductIDString(),
+			Quantity:       item.Quantity,
+			Price:          item.UnitPrice,
+			Discount:       item.Discount,
+			DiscountType:   item.DiscountType,
+			WidthCM:        item.WidthCM,
+			HeightCM:       item.HeightCM,
+			Thickness:      item.Thickness,
+			EdgeType:       item.EdgeType,
+			EdgeSides:      item.EdgeSides,
+			HasCutout:      item.HasCutout,
+			ReferenceImage: item.ReferenceImage,
+			Notes:          item.Notes,
+			Kind:           item.Kind,
+			Description:    item.Description,
+		}
+		if item.ServiceID != nil {
+			dto.ServiceID = item.ServiceID.String()
+		}
+		dtos = append(dtos, dto)
+	}
+	return dtos
+}
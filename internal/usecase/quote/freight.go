@@ -0,0 +1,199 @@
+package quote
+
+import (
+	"context"
+
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// CalculateFreight calcula o frete do orçamento pela zona de entrega do cliente e
+// grava uma linha de frete por grupo de itens (o orçamento ou cada opção).
+func (u *UseCase) CalculateFreight(ctx context.Context, tenantID, id string) (*quoteDomain.QuoteDetailDTO, error) {
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var detail *quoteDomain.QuoteDetailDTO
+	err = u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		zone, err := u.freightZone(ctx, tenantID, quote.ClientID.String())
+		if err != nil {
+			return err
+		}
+		if zone == nil {
+			return zoneDomain.ErrNoZoneForAddress
+		}
+
+		items, err := r.items.GetByQuoteID(ctx, id)
+		if err != nil {
+			return err
+		}
+		options, err := r.options.ListByQuoteID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, created, err := applyFreight(zone, settings, options, items)
+		if err != nil {
+			return err
+		}
+
+		if err := saveFreight(ctx, r, quote, updated, created); err != nil {
+			return err
+		}
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// refreshFreight atualiza o frete de orçamentos que já têm linhas de frete, depois
+// de mudanças nos itens ou no cliente. Grupos novos (ex.: uma opção adicionada)
+// recebem a sua linha; sem zona que atenda o cliente, as linhas de frete são
+// removidas, para não cobrar a tarifa de outro endereço.
+func (u *UseCase) refreshFreight(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) error {
+	items, err := r.items.GetByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return err
+	}
+	if !hasFreight(items) {
+		return nil
+	}
+
+	tenantID := quote.TenantID.String()
+	zone, err := u.freightZone(ctx, tenantID, quote.ClientID.String())
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return removeFreight(ctx, r, quote, items)
+	}
+
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	options, err := r.options.ListByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return err
+	}
+
+	updated, created, err := applyFreight(zone, settings, options, items)
+	if err != nil {
+		return err
+	}
+	return saveFreight(ctx, r, quote, updated, created)
+}
+
+// freightZone retorna a zona de entrega que atende o endereço do cliente, ou nil.
+func (u *UseCase) freightZone(ctx context.Context, tenantID, clientID string) (*zoneDomain.DeliveryZone, error) {
+	client, err := u.clientRepo.GetByID(ctx, tenantID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := u.zoneRepo.ListActive(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return zoneDomain.Match(zones, client.ZipCode, client.City, client.State), nil
+}
+
+// applyFreight calcula o frete de cada grupo de itens — o orçamento inteiro ou cada
+// opção — a partir da área e do peso das peças do grupo. As linhas de frete
+// existentes são atualizadas e grupos sem frete recebem uma linha nova.
+func applyFreight(zone *zoneDomain.DeliveryZone, settings map[string]string, options []*quoteDomain.QuoteOption, items []*quoteDomain.QuoteItem) (updated, created []*quoteDomain.QuoteItem, err error) {
+	pricing := quoteDomain.PricingConfigFromSettings(settings)
+	cfg := zoneDomain.FreightConfigFromSettings(settings)
+
+	type group struct {
+		optionID *dbtypes.UUID
+		items    []*quoteDomain.QuoteItem
+	}
+	groups := []group{{items: items}}
+	if len(options) > 0 {
+		groups = groups[:0]
+		for _, option := range options {
+			groups = append(groups, group{optionID: &option.ID, items: quoteDomain.ItemsOf(option, items)})
+		}
+	}
+
+	for _, g := range groups {
+		line := freightLine(g.items)
+		isNew := line == nil
+		if isNew {
+			line = &quoteDomain.QuoteItem{
+				TenantID: zone.TenantID,
+				Kind:     quoteDomain.ItemKindFreight,
+				OptionID: g.optionID,
+				Quantity: 1,
+			}
+		}
+		line.Description = "Frete - " + zone.Name
+		line.UnitPrice = zone.Fee(zoneDomain.LoadOf(g.items, cfg))
+		if err := quoteDomain.PriceItem(line, productDomain.PriceTypeUnit, pricing); err != nil {
+			return nil, nil, err
+		}
+
+		if isNew {
+			created = append(created, line)
+		} else {
+			updated = append(updated, line)
+		}
+	}
+
+	return updated, created, nil
+}
+
+func saveFreight(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, updated, created []*quoteDomain.QuoteItem) error {
+	for _, line := range updated {
+		if err := r.items.Update(ctx, line); err != nil {
+			return err
+		}
+	}
+	for _, line := range created {
+		line.QuoteID = quote.ID
+		if err := r.items.Create(ctx, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeFreight(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, items []*quoteDomain.QuoteItem) error {
+	for _, item := range items {
+		if !item.IsFreight() {
+			continue
+		}
+		if err := r.items.Delete(ctx, quote.ID.String(), item.ID.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func freightLine(items []*quoteDomain.QuoteItem) *quoteDomain.QuoteItem {
+	for _, item := range items {
+		if item.IsFreight() {
+			return item
+		}
+	}
+	return nil
+}
+
+func hasFreight(items []*quoteDomain.QuoteItem) bool {
+	return freightLine(items) != nil
+}
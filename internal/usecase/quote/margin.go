@@ -0,0 +1,36 @@
+package quote
+
+import (
+	"context"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+// checkMargin recusa a aprovação de orçamentos com margem abaixo do mínimo do tenant.
+func (u *UseCase) checkMargin(ctx context.Context, quote *quoteDomain.Quote) error {
+	settings, err := u.settingsRepo.Get(ctx, quote.TenantID.String())
+	if err != nil {
+		return err
+	}
+	return quoteDomain.MarginPolicyFromSettings(settings).Check(quote)
+}
+
+// ProfitabilityReport calcula receita, custo e margem dos orçamentos aprovados
+// que atendem ao filtro.
+func (u *UseCase) ProfitabilityReport(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (*quoteDomain.ProfitabilityReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := u.quoteRepo.ListForAnalytics(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return quoteDomain.BuildProfitabilityReport(quotes, quoteDomain.MarginPolicyFromSettings(settings)), nil
+}
@@ -0,0 +1,224 @@
+package quote
+
+import (
+	"context"
+	"strings"
+
+	quoteDomain "erp-api/internal/domain/quote"
+)
+
+// AddOption adiciona uma alternativa ao orçamento. Se o orçamento ainda não tem
+// opções, os itens atuais passam a formar a primeira opção.
+func (u *UseCase) AddOption(ctx context.Context, tenantID, quoteID string, req *quoteDomain.CreateOptionDTO) (*quoteDomain.QuoteDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var detail *quoteDomain.QuoteDetailDTO
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+
+		options, err := r.options.ListByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+		items, err := r.items.GetByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+
+		// Orçamento sem opções: os itens existentes formam a primeira opção
+		var first *quoteDomain.QuoteOption
+		if len(options) == 0 {
+			first = &quoteDomain.QuoteOption{
+				TenantID: quote.TenantID,
+				QuoteID:  quote.ID,
+				Name:     quoteDomain.DefaultOptionName,
+				Position: 1,
+			}
+			options = append(options, first)
+		}
+
+		name := strings.TrimSpace(req.Name)
+		if optionNameTaken(options, name, "") {
+			return quoteDomain.ErrDuplicateOptionName
+		}
+
+		option := &quoteDomain.QuoteOption{
+			TenantID: quote.TenantID,
+			QuoteID:  quote.ID,
+			Name:     name,
+			Position: options[len(options)-1].Position + 1,
+		}
+
+		newItems, err := u.optionItems(ctx, tenantID, req, options, items)
+		if err != nil {
+			return err
+		}
+
+		if first != nil {
+			if err := r.options.Create(ctx, first); err != nil {
+				return err
+			}
+			for _, item := range items {
+				item.OptionID = &first.ID
+				if err := r.items.Update(ctx, item); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := r.options.Create(ctx, option); err != nil {
+			return err
+		}
+		for _, item := range newItems {
+			item.QuoteID = quote.ID
+			item.OptionID = &option.ID
+			if err := r.items.Create(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// optionItems monta os itens da nova opção: os informados na requisição ou uma
+// cópia dos itens de outra opção, com o produto trocado quando indicado.
+func (u *UseCase) optionItems(ctx context.Context, tenantID string, req *quoteDomain.CreateOptionDTO, options []*quoteDomain.QuoteOption, items []*quoteDomain.QuoteItem) ([]*quoteDomain.QuoteItem, error) {
+	dtos := req.Items
+	if len(dtos) == 0 {
+		source := options[0]
+		if req.CopyFrom != "" {
+			var err error
+			if source, err = quoteDomain.FindOption(options, req.CopyFrom); err != nil {
+				return nil, err
+			}
+		}
+
+		// A primeira opção recém-criada ainda não tem os itens vinculados
+		sourceItems := items
+		if source.ID != "" {
+			sourceItems = quoteDomain.ItemsOf(source, items)
+		}
+
+		dtos = copyItems(sourceItems)
+		// Outro produto: usa o preço de cadastro dele; linhas de serviço não mudam
+		if req.ProductID != "" {
+			for i := range dtos {
+				if dtos[i].Kind == quoteDomain.ItemKindService {
+					continue
+				}
+				dtos[i].ProductID = req.ProductID
+				dtos[i].Price = 0
+			}
+		}
+	}
+	if len(dtos) == 0 {
+		return nil, quoteDomain.ErrInvalidItems
+	}
+
+	pricing, err := u.pricingConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*quoteDomain.QuoteItem, 0, len(dtos))
+	for _, dto := range dtos {
+		item, err := u.buildItem(ctx, tenantID, &dto, pricing)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// UpdateOption renomeia uma opção do orçamento.
+func (u *UseCase) UpdateOption(ctx context.Context, tenantID, quoteID, optionID string, req *quoteDomain.UpdateOptionDTO) (*quoteDomain.QuoteDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		if _, err := editableQuote(ctx, r, tenantID, quoteID); err != nil {
+			return err
+		}
+
+		options, err := r.options.ListByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+		option, err := quoteDomain.FindOption(options, optionID)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSpace(req.Name)
+		if optionNameTaken(options, name, optionID) {
+			return quoteDomain.ErrDuplicateOptionName
+		}
+
+		option.Name = name
+		return r.options.Update(ctx, option)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetDetail(ctx, tenantID, quoteID)
+}
+
+// DeleteOption remove uma opção e seus itens. O orçamento mantém ao menos uma opção.
+func (u *UseCase) DeleteOption(ctx context.Context, tenantID, quoteID, optionID string) (*quoteDomain.QuoteDetailDTO, error) {
+	var detail *quoteDomain.QuoteDetailDTO
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+
+		options, err := r.options.ListByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+		if _, err := quoteDomain.FindOption(options, optionID); err != nil {
+			return err
+		}
+		if len(options) == 1 {
+			return quoteDomain.ErrLastOption
+		}
+
+		if err := r.items.DeleteByOptionID(ctx, quoteID, optionID); err != nil {
+			return err
+		}
+		if err := r.options.Delete(ctx, quoteID, optionID); err != nil {
+			return err
+		}
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+func optionNameTaken(options []*quoteDomain.QuoteOption, name, exceptID string) bool {
+	for _, option := range options {
+		if option.ID.String() != exceptID && strings.EqualFold(option.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package quote
+
+import (
+	"context"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	serviceDomain "erp-api/internal/domain/service"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// buildServiceItem monta uma linha de serviço. Com um serviço do catálogo, a
+// descrição e o preço vêm do cadastro quando não informados e o custo é o do cadastro.
+func (u *UseCase) buildServiceItem(ctx context.Context, tenantID string, itemDTO *quoteDomain.QuoteItemDTO, pricing quoteDomain.PricingConfig) (*quoteDomain.QuoteItem, error) {
+	item := &quoteDomain.QuoteItem{
+		TenantID:       dbtypes.UUID(tenantID),
+		Kind:           quoteDomain.ItemKindService,
+		Description:    strings.TrimSpace(itemDTO.Description),
+		UnitPrice:      itemDTO.Price,
+		Quantity:       itemDTO.Quantity,
+		Discount:       itemDTO.Discount,
+		DiscountType:   itemDTO.DiscountType,
+		ReferenceImage: itemDTO.ReferenceImage,
+		Notes:          itemDTO.Notes,
+	}
+
+	if itemDTO.ServiceID != "" {
+		service, err := u.activeService(ctx, tenantID, itemDTO.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		item.ServiceID = &service.ID
+		item.UnitCost = service.CostPrice
+		if item.Description == "" {
+			item.Description = service.Name
+		}
+		if item.UnitPrice == 0 {
+			item.UnitPrice = service.Price
+		}
+	}
+
+	if err := quoteDomain.PriceItem(item, productDomain.PriceTypeUnit, pricing); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// applyServiceChanges aplica a uma linha de serviço os campos que ela aceita;
+// produto, medidas e acabamentos são recusados.
+func (u *UseCase) applyServiceChanges(ctx context.Context, tenantID string, item *quoteDomain.QuoteItem, req *quoteDomain.UpdateQuoteItemDTO) error {
+	if req.ProductID != "" || nonZero(req.WidthCM) || nonZero(req.HeightCM) || nonZero(req.Thickness) ||
+		(req.EdgeType != nil && *req.EdgeType != "") || (req.EdgeSides != nil && *req.EdgeSides != "") ||
+		(req.HasCutout != nil && *req.HasCutout) {
+		return quoteDomain.ErrInvalidServiceItem
+	}
+
+	if req.Description != nil {
+		item.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Price == nil {
+		return nil
+	}
+
+	item.UnitPrice = *req.Price
+	if item.UnitPrice == 0 && item.ServiceID != nil {
+		service, err := u.serviceRepo.GetByID(ctx, tenantID, item.ServiceID.String())
+		if err != nil {
+			return err
+		}
+		item.UnitPrice = service.Price
+	}
+	return nil
+}
+
+// activeService busca um serviço do catálogo que ainda pode ser orçado.
+func (u *UseCase) activeService(ctx context.Context, tenantID, id string) (*serviceDomain.Service, error) {
+	service, err := u.serviceRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsActive {
+		return nil, serviceDomain.ErrServiceInactive
+	}
+	return service, nil
+}
+
+func nonZero(value *float64) bool {
+	return value != nil && *value != 0
+}
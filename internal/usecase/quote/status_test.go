@@ -0,0 +1,531 @@
+package quote
+
+import (
+	"context"
+	"testing"
+
+	orderDomain "erp-api/internal/domain/order"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	orderUseCase "erp-api/internal/usecase/order"
+	slabUseCase "erp-api/internal/usecase/slab"
+	"erp-api/internal/usecase/usecasetest"
+)
+
+func updateStatus(useCase *UseCase, quoteID string, status quoteDomain.QuoteStatus) error {
+	return useCase.UpdateStatus(context.Background(), testTenant, quoteID, "user-1", &quoteDomain.UpdateQuoteStatusDTO{Status: status})
+}
+
+func TestUseCase_UpdateStatus_ApproveAndCancel(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase,
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2},
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1},
+		quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1},
+	)
+	id := quote.ID.String()
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+
+	stored := store.Quotes[id]
+	if stored.Status != quoteDomain.QuoteStatusApproved || stored.ApprovedAt == nil {
+		t.Errorf("status = %s, approved_at = %v, want approved with a date", stored.Status, stored.ApprovedAt)
+	}
+	history := store.HistoryOf(id)
+	if len(history) != 2 || history[1].FromStatus != quoteDomain.QuoteStatusPending || history[1].ToStatus != quoteDomain.QuoteStatusApproved {
+		t.Fatalf("history = %v, want pending -> approved", history)
+	}
+	if history[1].UserID == nil || history[1].UserID.String() != "user-1" {
+		t.Errorf("history user = %v, want user-1", history[1].UserID)
+	}
+	if reserved := store.ReservedFor(id); len(reserved) != 2 || reserved["granito"] != 3 || reserved["quartzo"] != 1 {
+		t.Errorf("reserved = %v, want granito:3 quartzo:1", reserved)
+	}
+	// Reservar não mexe no saldo físico
+	if store.Products["granito"].Stock != 10 {
+		t.Errorf("granito stock = %d, want 10", store.Products["granito"].Stock)
+	}
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusCancelled); err != nil {
+		t.Fatalf("UpdateStatus(cancelled) error = %v", err)
+	}
+	if reserved := store.ReservedFor(id); len(reserved) != 0 {
+		t.Errorf("reserved after cancel = %v, want nothing", reserved)
+	}
+	if history := store.HistoryOf(id); len(history) != 3 || history[2].ToStatus != quoteDomain.QuoteStatusCancelled {
+		t.Errorf("history = %v, want approved -> cancelled recorded", history)
+	}
+}
+
+func TestUseCase_UpdateStatus_BlocksOversell(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	// Só há 4 peças de quartzo no estoque
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 5})
+	id := quote.ID.String()
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != stockDomain.ErrInsufficientStock {
+		t.Fatalf("UpdateStatus(approved) error = %v, want %v", err, stockDomain.ErrInsufficientStock)
+	}
+	if stored := store.Quotes[id]; stored.Status != quoteDomain.QuoteStatusPending || len(store.ReservedFor(id)) != 0 {
+		t.Errorf("status = %s, reserved = %v, want pending with nothing reserved", stored.Status, store.ReservedFor(id))
+	}
+
+	// O tenant pode desligar a trava e aprovar mesmo sem saldo
+	store.Settings[quoteDomain.SettingBlockOversell] = "false"
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) with the block off error = %v", err)
+	}
+	if reserved := store.ReservedFor(id); reserved["quartzo"] != 5 {
+		t.Errorf("reserved = %v, want quartzo:5", reserved)
+	}
+}
+
+func TestUseCase_UpdateStatus_ReservesSlabs(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Slabs["slab-1"] = &slabDomain.Slab{ID: "slab-1", TenantID: testTenant, ProductID: "granito", WidthCM: 300, HeightCM: 180, Status: slabDomain.StatusAvailable}
+	useCase := newTestUseCase(store)
+
+	if _, err := useCase.Create(context.Background(), &quoteDomain.CreateQuoteDTO{
+		TenantID: testTenant,
+		ClientID: "client-1",
+		UserID:   "user-1",
+		Items:    []quoteDomain.QuoteItemDTO{{ProductID: "quartzo", Quantity: 1, SlabID: "slab-1"}},
+	}); err != slabDomain.ErrSlabProductMismatch {
+		t.Fatalf("Create() with a slab of another product error = %v, want %v", err, slabDomain.ErrSlabProductMismatch)
+	}
+
+	// Dois vendedores escolhem a mesma chapa; só o primeiro a aprovar fica com ela
+	first := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1, SlabID: "slab-1"})
+	second := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1, SlabID: "slab-1"})
+
+	if err := updateStatus(useCase, first.ID.String(), quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+	slab := store.Slabs["slab-1"]
+	if slab.Status != slabDomain.StatusReserved || slab.QuoteID == nil || *slab.QuoteID != first.ID {
+		t.Fatalf("slab = %s/%v, want reserved for %s", slab.Status, slab.QuoteID, first.ID)
+	}
+
+	if err := updateStatus(useCase, second.ID.String(), quoteDomain.QuoteStatusApproved); err != slabDomain.ErrSlabUnavailable {
+		t.Fatalf("UpdateStatus(approved) on the same slab error = %v, want %v", err, slabDomain.ErrSlabUnavailable)
+	}
+	if stored := store.Quotes[second.ID.String()]; stored.Status != quoteDomain.QuoteStatusPending || len(store.ReservedFor(second.ID.String())) != 0 {
+		t.Errorf("second quote = %s, reserved = %v, want pending with nothing reserved", stored.Status, store.ReservedFor(second.ID.String()))
+	}
+
+	if err := updateStatus(useCase, first.ID.String(), quoteDomain.QuoteStatusCancelled); err != nil {
+		t.Fatalf("UpdateStatus(cancelled) error = %v", err)
+	}
+	if slab := store.Slabs["slab-1"]; slab.Status != slabDomain.StatusAvailable || slab.QuoteID != nil {
+		t.Errorf("slab after cancel = %s/%v, want available", slab.Status, slab.QuoteID)
+	}
+}
+
+func TestUseCase_UpdateStatus_QuoteWithOrder(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	id := quote.ID.String()
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+	store.Orders["order-1"] = &orderDomain.Order{ID: "order-1", TenantID: testTenant, QuoteID: quote.ID, Status: orderDomain.OrderStatusInProduction}
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusCancelled); err != quoteDomain.ErrQuoteHasOrder {
+		t.Fatalf("UpdateStatus(cancelled) error = %v, want %v", err, quoteDomain.ErrQuoteHasOrder)
+	}
+	if err := useCase.Delete(context.Background(), testTenant, id); err != quoteDomain.ErrQuoteHasOrder {
+		t.Fatalf("Delete() error = %v, want %v", err, quoteDomain.ErrQuoteHasOrder)
+	}
+
+	// O material continua reservado para o pedido em produção
+	if stored := store.Quotes[id]; stored == nil || stored.Status != quoteDomain.QuoteStatusApproved {
+		t.Errorf("quote = %v, want it kept as approved", stored)
+	}
+	if reserved := store.ReservedFor(id); reserved["granito"] != 2 {
+		t.Errorf("reserved = %v, want granito:2", reserved)
+	}
+}
+
+func TestUseCase_UpdateStatus_QuoteInProduction(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+	// Ordens de produção abertas direto do orçamento, sem pedido
+	store.WorkOrders[id] = 1
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusCancelled); err != quoteDomain.ErrQuoteInProduction {
+		t.Fatalf("UpdateStatus(cancelled) error = %v, want %v", err, quoteDomain.ErrQuoteInProduction)
+	}
+	if err := useCase.Delete(context.Background(), testTenant, id); err != quoteDomain.ErrQuoteInProduction {
+		t.Fatalf("Delete() error = %v, want %v", err, quoteDomain.ErrQuoteInProduction)
+	}
+	if stored := store.Quotes[id]; stored == nil || stored.Status != quoteDomain.QuoteStatusApproved {
+		t.Errorf("quote = %v, want it kept as approved", stored)
+	}
+}
+
+// startProduction converte o orçamento aprovado em pedido e o coloca em produção.
+func startProduction(t *testing.T, store *usecasetest.Store, quoteID string) *orderDomain.Order {
+	t.Helper()
+
+	factory := store.Factory()
+	orders := orderUseCase.NewUseCase(factory.CreateOrderRepository(), factory.CreateOrderItemRepository(), store)
+	ctx := context.Background()
+
+	detail, err := orders.CreateFromQuote(ctx, testTenant, "user-1", &orderDomain.CreateOrderDTO{QuoteID: quoteID})
+	if err != nil {
+		t.Fatalf("CreateFromQuote() error = %v", err)
+	}
+	order, err := orders.UpdateStatus(ctx, testTenant, detail.Order.ID.String(), "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusInProduction})
+	if err != nil {
+		t.Fatalf("UpdateStatus(in_production) error = %v", err)
+	}
+	return order
+}
+
+func TestUseCase_UpdateStatus_OrderConsumesReservation(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 3})
+	id := quote.ID.String()
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+
+	order := startProduction(t, store, id)
+
+	// A reserva vira saída: o material sai do saldo uma única vez
+	onHand, reserved, _ := store.Factory().CreateStockMovementRepository().Totals(ctx, testTenant, "granito")
+	if onHand != 7 || reserved != 0 || store.Products["granito"].Stock != 7 {
+		t.Errorf("granito = %d on hand (product %d), %d reserved; want 7 and 0", onHand, store.Products["granito"].Stock, reserved)
+	}
+	last := store.Movements[len(store.Movements)-1]
+	if last.Type != stockDomain.TypeExit || last.Quantity != -3 || last.ReferenceID != id || last.UserID == nil {
+		t.Errorf("last movement = %s %d ref %s user %v, want an exit of 3 for the quote", last.Type, last.Quantity, last.ReferenceID, last.UserID)
+	}
+
+	factory := store.Factory()
+	orders := orderUseCase.NewUseCase(factory.CreateOrderRepository(), factory.CreateOrderItemRepository(), store)
+	for _, next := range []orderDomain.OrderStatus{orderDomain.OrderStatusReady, orderDomain.OrderStatusInstalled, orderDomain.OrderStatusClosed} {
+		if _, err := orders.UpdateStatus(ctx, testTenant, order.ID.String(), "user-1", &orderDomain.UpdateOrderStatusDTO{Status: next}); err != nil {
+			t.Fatalf("UpdateStatus(%s) error = %v", next, err)
+		}
+	}
+
+	onHand, reserved, _ = store.Factory().CreateStockMovementRepository().Totals(ctx, testTenant, "granito")
+	if onHand-reserved != 7 || reserved != 0 {
+		t.Errorf("granito after closing = %d available, %d reserved; want 7 and 0", onHand-reserved, reserved)
+	}
+}
+
+func TestUseCase_UpdateStatus_ClosedOrderReleasesReservation(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	id := quote.ID.String()
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+
+	// Pedido que já estava pronto antes da baixa automática: a saída foi lançada à mão
+	store.Orders["order-1"] = &orderDomain.Order{ID: "order-1", TenantID: testTenant, QuoteID: quote.ID, Status: orderDomain.OrderStatusInstalled}
+	store.Movements = append(store.Movements, stockDomain.NewMovement(testTenant, "granito", stockDomain.TypeExit, 2, "Saída manual"))
+
+	factory := store.Factory()
+	orders := orderUseCase.NewUseCase(factory.CreateOrderRepository(), factory.CreateOrderItemRepository(), store)
+	if _, err := orders.UpdateStatus(ctx, testTenant, "order-1", "user-1", &orderDomain.UpdateOrderStatusDTO{Status: orderDomain.OrderStatusClosed}); err != nil {
+		t.Fatalf("UpdateStatus(closed) error = %v", err)
+	}
+
+	onHand, reserved, _ := store.Factory().CreateStockMovementRepository().Totals(ctx, testTenant, "granito")
+	if onHand != 8 || reserved != 0 {
+		t.Errorf("granito = %d on hand, %d reserved; want 8 and 0", onHand, reserved)
+	}
+}
+
+func TestUseCase_UpdateStatus_SlabUsedByOrder(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Slabs["slab-1"] = &slabDomain.Slab{ID: "slab-1", TenantID: testTenant, ProductID: "granito", WidthCM: 300, HeightCM: 180, Status: slabDomain.StatusAvailable}
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1, SlabID: "slab-1"})
+	id := quote.ID.String()
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+
+	factory := store.Factory()
+	slabs := slabUseCase.NewUseCase(factory.CreateSlabRepository(), nil, factory.CreateProductRepository(),
+		factory.CreateQuoteRepository(), factory.CreateQuoteItemRepository(), store)
+	remnants := &slabDomain.RegisterRemnantsDTO{Remnants: []slabDomain.RemnantDTO{{WidthCM: 120, HeightCM: 60}}}
+
+	// Enquanto o pedido não entra em produção, a chapa segue com o orçamento
+	if _, err := slabs.RegisterRemnants(context.Background(), testTenant, "slab-1", remnants); err != slabDomain.ErrReservedByQuote {
+		t.Fatalf("RegisterRemnants() before production error = %v, want %v", err, slabDomain.ErrReservedByQuote)
+	}
+
+	startProduction(t, store, id)
+	slab := store.Slabs["slab-1"]
+	if slab.Status != slabDomain.StatusUsed || slab.QuoteID == nil || *slab.QuoteID != quote.ID {
+		t.Fatalf("slab = %s/%v, want used by %s", slab.Status, slab.QuoteID, quote.ID)
+	}
+
+	registered, err := slabs.RegisterRemnants(context.Background(), testTenant, "slab-1", remnants)
+	if err != nil {
+		t.Fatalf("RegisterRemnants() error = %v", err)
+	}
+	if len(registered) != 1 || registered[0].Status != slabDomain.StatusAvailable || registered[0].QuoteID != nil {
+		t.Errorf("remnants = %+v, want one available remnant free of the quote", registered)
+	}
+}
+
+func TestUseCase_UpdateStatus_InvalidTransition(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusApproved); err != nil {
+		t.Fatalf("UpdateStatus(approved) error = %v", err)
+	}
+	movements := len(store.Movements)
+
+	for _, status := range []quoteDomain.QuoteStatus{quoteDomain.QuoteStatusPending, quoteDomain.QuoteStatusApproved, quoteDomain.QuoteStatusRejected} {
+		if err := updateStatus(useCase, id, status); err != quoteDomain.ErrInvalidStatusTransition {
+			t.Errorf("UpdateStatus(%s) error = %v, want %v", status, err, quoteDomain.ErrInvalidStatusTransition)
+		}
+	}
+	if len(store.HistoryOf(id)) != 2 || len(store.Movements) != movements {
+		t.Errorf("history/movements = %d/%d, want no side effects", len(store.HistoryOf(id)), len(store.Movements))
+	}
+}
+
+func TestUseCase_RespondAsClient_Reject(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+	movements := len(store.Movements)
+
+	err := useCase.RespondAsClient(context.Background(), testTenant, id, &quoteDomain.ClientDecisionDTO{
+		Decision: quoteDomain.QuoteStatusRejected,
+		Comment:  "Preço acima do esperado",
+	}, "203.0.113.7", "test")
+	if err != nil {
+		t.Fatalf("RespondAsClient() error = %v", err)
+	}
+
+	history := store.HistoryOf(id)
+	last := history[len(history)-1]
+	if last.ToStatus != quoteDomain.QuoteStatusRejected || last.Source != quoteDomain.StatusSourcePublicLink || last.Reason != "Preço acima do esperado" {
+		t.Errorf("history = %+v, want rejection from the public link", last)
+	}
+	// Orçamento pendente não tem reserva a liberar
+	if len(store.Movements) != movements {
+		t.Errorf("movements = %d, want %d", len(store.Movements), movements)
+	}
+}
+
+func TestUseCase_Renew_Expired(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+	store.Quotes[id].Status = quoteDomain.QuoteStatusExpired
+	store.Products["granito"].Price = 120
+
+	// A troca direta de status não reabre o orçamento vencido; só a renovação
+	if err := updateStatus(useCase, id, quoteDomain.QuoteStatusPending); err != quoteDomain.ErrInvalidStatusTransition {
+		t.Fatalf("UpdateStatus(pending) on expired quote error = %v, want %v", err, quoteDomain.ErrInvalidStatusTransition)
+	}
+
+	detail, err := useCase.Renew(context.Background(), testTenant, id, "user-1", &quoteDomain.RenewQuoteDTO{})
+	if err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	if detail.Quote.Subtotal != 120 {
+		t.Errorf("subtotal = %.2f, want 120", detail.Quote.Subtotal)
+	}
+	if stored := store.Quotes[id]; stored.Status != quoteDomain.QuoteStatusPending {
+		t.Errorf("status = %s, want %s", stored.Status, quoteDomain.QuoteStatusPending)
+	}
+	history := store.HistoryOf(id)
+	if last := history[len(history)-1]; last.FromStatus != quoteDomain.QuoteStatusExpired || last.Reason != "Orçamento renovado" {
+		t.Errorf("last history = %s -> %s (%q), want the renewal recorded", last.FromStatus, last.ToStatus, last.Reason)
+	}
+}
+
+func TestUseCase_Renew_DeletedProduct(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase,
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1},
+		quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1},
+	)
+	id := quote.ID.String()
+	store.Products["granito"].Price = 120
+	delete(store.Products, "quartzo")
+
+	// O produto removido do catálogo mantém o preço gravado no orçamento
+	detail, err := useCase.Renew(context.Background(), testTenant, id, "user-1", &quoteDomain.RenewQuoteDTO{})
+	if err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	if detail.Quote.Subtotal != 370 {
+		t.Errorf("subtotal = %.2f, want 370", detail.Quote.Subtotal)
+	}
+	if store.Locks["quotes"] == 0 {
+		t.Error("Renew() did not lock the quote")
+	}
+}
+
+func TestUseCase_Renew_CancelledMeanwhile(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+	store.Products["granito"].Price = 120
+
+	store.BeforeTx = func() { store.Quotes[id].Status = quoteDomain.QuoteStatusCancelled }
+
+	if _, err := useCase.Renew(context.Background(), testTenant, id, "user-1", &quoteDomain.RenewQuoteDTO{}); err != quoteDomain.ErrQuoteNotRenewable {
+		t.Fatalf("Renew() error = %v, want %v", err, quoteDomain.ErrQuoteNotRenewable)
+	}
+	if stored := store.Quotes[id]; stored.Status != quoteDomain.QuoteStatusCancelled || stored.Subtotal != 100 {
+		t.Errorf("status = %s, subtotal = %.2f, want it kept cancelled at 100", stored.Status, stored.Subtotal)
+	}
+}
+
+func TestUseCase_ApproveDiscount(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Settings[quoteDomain.SettingDiscountApprovalPercent] = "10"
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	id := quote.ID.String()
+
+	discount := 50.0
+	if _, err := useCase.Update(ctx, testTenant, id, &quoteDomain.UpdateQuoteDTO{Discount: &discount}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if stored := store.Quotes[id]; stored.Status != quoteDomain.QuoteStatusAwaitingApproval {
+		t.Fatalf("status = %s, want %s", stored.Status, quoteDomain.QuoteStatusAwaitingApproval)
+	}
+	history := store.HistoryOf(id)
+	if reason := history[len(history)-1].Reason; reason != "Desconto de 25,00% acima do limite de aprovação de 10,00%" {
+		t.Errorf("reason = %q, want the approval limit explained", reason)
+	}
+
+	if _, err := useCase.ApproveDiscount(ctx, testTenant, id, "manager-1", &quoteDomain.ApproveDiscountDTO{}); err != nil {
+		t.Fatalf("ApproveDiscount() error = %v", err)
+	}
+	stored := store.Quotes[id]
+	if stored.Status != quoteDomain.QuoteStatusPending || stored.DiscountApprovedPercent != 25 {
+		t.Errorf("status = %s, approved = %.2f, want pending with 25%% approved", stored.Status, stored.DiscountApprovedPercent)
+	}
+	history = store.HistoryOf(id)
+	if reason := history[len(history)-1].Reason; reason != "Desconto de 25,00% aprovado" {
+		t.Errorf("reason = %q, want the approval recorded", reason)
+	}
+}
+
+func TestUseCase_ApproveDiscount_ConcurrentCancel(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Settings[quoteDomain.SettingDiscountApprovalPercent] = "10"
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	id := quote.ID.String()
+	discount := 50.0
+	if _, err := useCase.Update(ctx, testTenant, id, &quoteDomain.UpdateQuoteDTO{Discount: &discount}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// O orçamento é cancelado antes de a aprovação travar a linha
+	store.BeforeTx = func() { store.Quotes[id].Status = quoteDomain.QuoteStatusCancelled }
+
+	if _, err := useCase.ApproveDiscount(ctx, testTenant, id, "manager-1", &quoteDomain.ApproveDiscountDTO{}); err != quoteDomain.ErrDiscountApprovalNotPending {
+		t.Fatalf("ApproveDiscount() error = %v, want %v", err, quoteDomain.ErrDiscountApprovalNotPending)
+	}
+	if stored := store.Quotes[id]; stored.Status != quoteDomain.QuoteStatusCancelled || stored.DiscountApprovedBy != nil {
+		t.Errorf("status = %s, approved by = %v, want it kept cancelled", stored.Status, stored.DiscountApprovedBy)
+	}
+}
+
+func TestUseCase_ApproveDiscount_ConcurrentDiscountEdit(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Settings[quoteDomain.SettingDiscountApprovalPercent] = "10"
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	id := quote.ID.String()
+	discount := 50.0
+	if _, err := useCase.Update(ctx, testTenant, id, &quoteDomain.UpdateQuoteDTO{Discount: &discount}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// O vendedor aumenta o desconto enquanto o gerente abre a aprovação
+	store.BeforeTx = func() {
+		stored := store.Quotes[id]
+		stored.Discount, stored.DiscountAmount, stored.DiscountPercent = 60, 60, 30
+	}
+
+	approved, err := useCase.ApproveDiscount(ctx, testTenant, id, "manager-1", &quoteDomain.ApproveDiscountDTO{})
+	if err != nil {
+		t.Fatalf("ApproveDiscount() error = %v", err)
+	}
+	if approved.DiscountApprovedPercent != 30 {
+		t.Errorf("approved percent = %.2f, want the locked 30%%", approved.DiscountApprovedPercent)
+	}
+	stored := store.Quotes[id]
+	if stored.Discount != 60 || stored.DiscountApprovedPercent != 30 {
+		t.Errorf("discount = %.2f, approved = %.2f, want the concurrent edit kept and approved", stored.Discount, stored.DiscountApprovedPercent)
+	}
+	if store.Locks["quotes"] == 0 {
+		t.Error("ApproveDiscount() did not lock the quote")
+	}
+}
@@ -0,0 +1,65 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	stockDomain "erp-api/internal/domain/stock"
+	slabUseCase "erp-api/internal/usecase/slab"
+	stockUseCase "erp-api/internal/usecase/stock"
+)
+
+// reserveStock reserva o material dos itens ativos do orçamento aprovado, para
+// que a mesma chapa não seja vendida duas vezes. As chapas escolhidas nos itens
+// são separadas no pátio; para as demais peças o saldo do produto é reservado na
+// unidade de estoque (peças, m² ou metros lineares).
+func (u *UseCase) reserveStock(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, items []*quoteDomain.QuoteItem, userID string) error {
+	if slabs := quoteDomain.SlabNeeds(quote, items); len(slabs) > 0 {
+		if err := slabUseCase.Reserve(ctx, r.tx, quote.TenantID.String(), quote.ID.String(), slabs); err != nil {
+			return err
+		}
+	}
+
+	needs := quoteDomain.MaterialNeeds(quote, items)
+	if len(needs) == 0 {
+		return nil
+	}
+
+	settings, err := u.settingsRepo.Get(ctx, quote.TenantID.String())
+	if err != nil {
+		return err
+	}
+
+	return stockUseCase.Reserve(ctx, r.tx, quote.TenantID.String(), userID,
+		stockDomain.ReferenceQuote, quote.ID.String(),
+		fmt.Sprintf("Reserva do orçamento %s", quote.Number),
+		needs, quoteDomain.BlockOversellFromSettings(settings))
+}
+
+// releaseStock devolve ao disponível o que foi reservado para o orçamento,
+// inclusive as chapas separadas no pátio.
+func releaseStock(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, userID, reason string) error {
+	if err := slabUseCase.Release(ctx, r.tx, quote.TenantID.String(), quote.ID.String()); err != nil {
+		return err
+	}
+	return stockUseCase.Release(ctx, r.tx, quote.TenantID.String(), userID,
+		stockDomain.ReferenceQuote, quote.ID.String(), reason)
+}
+
+// syncStock acompanha a transição de status: a aprovação reserva o material e
+// cancelamento, recusa ou vencimento o liberam.
+func (u *UseCase) syncStock(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, userID string) error {
+	switch {
+	case quote.Status == quoteDomain.QuoteStatusApproved:
+		items, err := r.items.GetByQuoteID(ctx, quote.ID.String())
+		if err != nil {
+			return err
+		}
+		return u.reserveStock(ctx, r, quote, items, userID)
+	case quote.Status.ReleasesStock():
+		return releaseStock(ctx, r, quote, userID,
+			fmt.Sprintf("Liberação do orçamento %s (%s)", quote.Number, quote.Status))
+	}
+	return nil
+}
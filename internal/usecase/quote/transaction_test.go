@@ -0,0 +1,90 @@
+package quote
+
+import (
+	"context"
+	"testing"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/usecase/usecasetest"
+)
+
+func TestUseCase_Create_RollsBack(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	store.FailOn = "items.Create"
+
+	_, err := useCase.Create(context.Background(), &quoteDomain.CreateQuoteDTO{
+		TenantID: testTenant,
+		ClientID: "client-1",
+		UserID:   "user-1",
+		Items:    []quoteDomain.QuoteItemDTO{{ProductID: "granito", Quantity: 1}},
+	})
+	if err != usecasetest.ErrInjected {
+		t.Fatalf("Create() error = %v, want %v", err, usecasetest.ErrInjected)
+	}
+
+	if len(store.Quotes) != 0 || len(store.Items) != 0 || len(store.History) != 0 {
+		t.Errorf("quotes/items/history = %d/%d/%d, want nothing written", len(store.Quotes), len(store.Items), len(store.History))
+	}
+	// O número reservado volta para a sequência
+	if len(store.Sequences) != 0 {
+		t.Errorf("sequences = %v, want the number returned on rollback", store.Sequences)
+	}
+
+	store.FailOn = ""
+	createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	for scope, seq := range store.Sequences {
+		if seq != 1 {
+			t.Errorf("sequence %s = %d, want 1", scope, seq)
+		}
+	}
+}
+
+func TestUseCase_AddItem_RollsBack(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+
+	// O item é gravado, mas o recálculo do orçamento falha na mesma transação
+	store.FailOn = "quotes.Update"
+	if _, err := useCase.AddItem(context.Background(), testTenant, quote.ID.String(), &quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1}); err != usecasetest.ErrInjected {
+		t.Fatalf("AddItem() error = %v, want %v", err, usecasetest.ErrInjected)
+	}
+
+	if got := len(store.ItemsOf(quote.ID.String())); got != 1 {
+		t.Errorf("items = %d, want the new item rolled back", got)
+	}
+	if stored := store.Quotes[quote.ID.String()]; stored.TotalValue != 200 {
+		t.Errorf("total = %.2f, want 200", stored.TotalValue)
+	}
+}
+
+func TestUseCase_UpdateStatus_RollsBack(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	movements := len(store.Movements)
+
+	// Status e histórico são gravados antes da reserva, que falha
+	store.FailOn = "movements.Create"
+	err := useCase.UpdateStatus(context.Background(), testTenant, quote.ID.String(), "user-1", &quoteDomain.UpdateQuoteStatusDTO{Status: quoteDomain.QuoteStatusApproved})
+	if err != usecasetest.ErrInjected {
+		t.Fatalf("UpdateStatus() error = %v, want %v", err, usecasetest.ErrInjected)
+	}
+
+	stored := store.Quotes[quote.ID.String()]
+	if stored.Status != quoteDomain.QuoteStatusPending || stored.ApprovedAt != nil {
+		t.Errorf("status = %s, approved_at = %v, want pending without approval", stored.Status, stored.ApprovedAt)
+	}
+	if len(store.Movements) != movements {
+		t.Errorf("movements = %d, want %d", len(store.Movements), movements)
+	}
+	if len(store.HistoryOf(quote.ID.String())) != 1 {
+		t.Errorf("history = %d entries, want 1", len(store.HistoryOf(quote.ID.String())))
+	}
+}
@@ -2,116 +2,661 @@ package quote
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	clientDomain "erp-api/internal/domain/client"
+	"erp-api/internal/domain/cutting"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
+	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
 	quoteDomain "erp-api/internal/domain/quote"
+	serviceDomain "erp-api/internal/domain/service"
+	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	"erp-api/internal/infra/database"
 	"erp-api/internal/utils/dbtypes"
 )
 
 type UseCaseInterface interface {
 	Create(ctx context.Context, req *quoteDomain.CreateQuoteDTO) (*quoteDomain.Quote, error)
 	GetByID(ctx context.Context, tenantID, id string) (*quoteDomain.Quote, error)
+	GetDetail(ctx context.Context, tenantID, id string) (*quoteDomain.QuoteDetailDTO, error)
+	GetItems(ctx context.Context, tenantID, id string) ([]*quoteDomain.QuoteItem, error)
+	AddItem(ctx context.Context, tenantID, quoteID string, req *quoteDomain.QuoteItemDTO) (*quoteDomain.QuoteDetailDTO, error)
+	UpdateItem(ctx context.Context, tenantID, quoteID, itemID string, req *quoteDomain.UpdateQuoteItemDTO) (*quoteDomain.QuoteDetailDTO, error)
+	DeleteItem(ctx context.Context, tenantID, quoteID, itemID string) (*quoteDomain.QuoteDetailDTO, error)
 	Update(ctx context.Context, tenantID, id string, req *quoteDomain.UpdateQuoteDTO) (*quoteDomain.Quote, error)
 	Delete(ctx context.Context, tenantID, id string) error
-	List(ctx context.Context, tenantID string, limit, offset int) ([]*quoteDomain.Quote, error)
-	Count(ctx context.Context, tenantID string) (int, error)
-	UpdateStatus(ctx context.Context, tenantID, id string, req *quoteDomain.UpdateQuoteStatusDTO) error
+	List(ctx context.Context, tenantID string, filter quoteDomain.ListFilter, limit, offset int) ([]*quoteDomain.Quote, error)
+	Count(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (int, error)
+	ConversionReport(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (*quoteDomain.ConversionReport, error)
+	ProfitabilityReport(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (*quoteDomain.ProfitabilityReport, error)
+	UpdateStatus(ctx context.Context, tenantID, id, userID string, req *quoteDomain.UpdateQuoteStatusDTO) error
+	ApproveDiscount(ctx context.Context, tenantID, id, userID string, req *quoteDomain.ApproveDiscountDTO) (*quoteDomain.Quote, error)
+	RespondAsClient(ctx context.Context, tenantID, id string, req *quoteDomain.ClientDecisionDTO, ipAddress, userAgent string) error
+	GetHistory(ctx context.Context, tenantID, id string) ([]*quoteDomain.QuoteStatusHistory, error)
+	AddOption(ctx context.Context, tenantID, quoteID string, req *quoteDomain.CreateOptionDTO) (*quoteDomain.QuoteDetailDTO, error)
+	UpdateOption(ctx context.Context, tenantID, quoteID, optionID string, req *quoteDomain.UpdateOptionDTO) (*quoteDomain.QuoteDetailDTO, error)
+	DeleteOption(ctx context.Context, tenantID, quoteID, optionID string) (*quoteDomain.QuoteDetailDTO, error)
+	Duplicate(ctx context.Context, tenantID, id, userID string, req *quoteDomain.DuplicateQuoteDTO) (*quoteDomain.Quote, error)
+	Renew(ctx context.Context, tenantID, id, userID string, req *quoteDomain.RenewQuoteDTO) (*quoteDomain.QuoteDetailDTO, error)
+	ExpireOverdue(ctx context.Context, now time.Time) (int, error)
+	CuttingPlan(ctx context.Context, tenantID, id string, req *cutting.PlanRequest) (*cutting.Plan, error)
+	CalculateFreight(ctx context.Context, tenantID, id string) (*quoteDomain.QuoteDetailDTO, error)
 }
 
 type UseCase struct {
-	quoteRepo quoteDomain.Repository
-	itemRepo  quoteDomain.ItemRepository
+	quoteRepo    quoteDomain.Repository
+	itemRepo     quoteDomain.ItemRepository
+	optionRepo   quoteDomain.OptionRepository
+	historyRepo  quoteDomain.StatusHistoryRepository
+	productRepo  productDomain.Repository
+	slabRepo     slabDomain.Repository
+	serviceRepo  serviceDomain.Repository
+	clientRepo   clientDomain.Repository
+	zoneRepo     zoneDomain.Repository
+	settingsRepo settingsDomain.Repository
+	uow          database.UnitOfWork
 }
 
-func NewUseCase(quoteRepo quoteDomain.Repository, itemRepo quoteDomain.ItemRepository) UseCaseInterface {
+// txRepos são os repositórios de escrita do orçamento vinculados a uma transação
+type txRepos struct {
+	quotes    quoteDomain.Repository
+	items     quoteDomain.ItemRepository
+	options   quoteDomain.OptionRepository
+	history   quoteDomain.StatusHistoryRepository
+	sequences quoteDomain.SequenceRepository
+	// tx dá acesso aos demais repositórios da transação, como o livro de estoque
+	tx database.RepositoryFactory
+}
+
+func NewUseCase(
+	quoteRepo quoteDomain.Repository,
+	itemRepo quoteDomain.ItemRepository,
+	optionRepo quoteDomain.OptionRepository,
+	historyRepo quoteDomain.StatusHistoryRepository,
+	productRepo productDomain.Repository,
+	slabRepo slabDomain.Repository,
+	serviceRepo serviceDomain.Repository,
+	clientRepo clientDomain.Repository,
+	zoneRepo zoneDomain.Repository,
+	settingsRepo settingsDomain.Repository,
+	uow database.UnitOfWork,
+) UseCaseInterface {
 	return &UseCase{
-		quoteRepo: quoteRepo,
-		itemRepo:  itemRepo,
+		quoteRepo:    quoteRepo,
+		itemRepo:     itemRepo,
+		optionRepo:   optionRepo,
+		historyRepo:  historyRepo,
+		productRepo:  productRepo,
+		slabRepo:     slabRepo,
+		serviceRepo:  serviceRepo,
+		clientRepo:   clientRepo,
+		zoneRepo:     zoneRepo,
+		settingsRepo: settingsRepo,
+		uow:          uow,
 	}
 }
 
+// withTransaction executa fn com repositórios que compartilham a mesma transação.
+func (u *UseCase) withTransaction(ctx context.Context, fn func(r *txRepos) error) error {
+	return u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		return fn(&txRepos{
+			quotes:    tx.CreateQuoteRepository(),
+			items:     tx.CreateQuoteItemRepository(),
+			options:   tx.CreateQuoteOptionRepository(),
+			history:   tx.CreateQuoteStatusHistoryRepository(),
+			sequences: tx.CreateQuoteSequenceRepository(),
+			tx:        tx,
+		})
+	})
+}
+
 func (u *UseCase) Create(ctx context.Context, req *quoteDomain.CreateQuoteDTO) (*quoteDomain.Quote, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	// Calcular valor total
-	totalValue := 0.0
-	for _, item := range req.Items {
-		totalValue += float64(item.Quantity) * item.Price
+	settings, err := u.settingsRepo.Get(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	pricing := quoteDomain.PricingConfigFromSettings(settings)
+
+	// Precificar itens a partir das medidas e do tipo de preço de cada produto
+	items := make([]*quoteDomain.QuoteItem, 0, len(req.Items))
+	for _, itemDTO := range req.Items {
+		item, err := u.buildItem(ctx, req.TenantID, &itemDTO, pricing)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	// Alternativas: cada opção recebe seus próprios itens
+	options := make([]*quoteDomain.QuoteOption, 0, len(req.Options))
+	for i, optionDTO := range req.Options {
+		option := &quoteDomain.QuoteOption{
+			ID:       dbtypes.NewUUID(),
+			TenantID: dbtypes.UUID(req.TenantID),
+			Name:     strings.TrimSpace(optionDTO.Name),
+			Position: i + 1,
+		}
+		options = append(options, option)
+
+		for _, itemDTO := range optionDTO.Items {
+			item, err := u.buildItem(ctx, req.TenantID, &itemDTO, pricing)
+			if err != nil {
+				return nil, err
+			}
+			item.OptionID = &option.ID
+			items = append(items, item)
+		}
 	}
 
 	// Criar orçamento
 	newQuote := &quoteDomain.Quote{
-		TenantID:   dbtypes.UUID(req.TenantID),
-		ClientID:   dbtypes.UUID(req.ClientID),
-		UserID:     dbtypes.UUID(req.UserID),
-		TotalValue: totalValue,
-		Discount:   req.Discount,
-		Status:     quoteDomain.QuoteStatusPending,
-		Notes:      req.Notes,
+		TenantID:     dbtypes.UUID(req.TenantID),
+		ClientID:     dbtypes.UUID(req.ClientID),
+		UserID:       dbtypes.UUID(req.UserID),
+		Discount:     req.Discount,
+		DiscountType: req.DiscountType,
+		Status:       quoteDomain.QuoteStatusPending,
+		Notes:        req.Notes,
+	}
+	if newQuote.DiscountType == "" {
+		newQuote.DiscountType = quoteDomain.DiscountTypeAmount
+	}
+
+	// Condições de pagamento: as informadas no orçamento ou as padrão do tenant
+	newQuote.PaymentTerms = quoteDomain.PaymentTermsFromSettings(settings)
+	if req.PaymentTerms != nil {
+		newQuote.PaymentTerms = *req.PaymentTerms
 	}
 
-	// Se status foi fornecido, usar ele
-	if req.Status != "" {
-		newQuote.Status = req.Status
+	// Validade: a informada no orçamento ou a padrão do tenant
+	validUntil := quoteDomain.ValidUntilFrom(time.Now(), quoteDomain.ValidityDaysFromSettings(settings))
+	if req.ValidUntil != nil {
+		validUntil = *req.ValidUntil
 	}
+	newQuote.ValidUntil = &validUntil
 
-	err := u.quoteRepo.Create(ctx, newQuote)
+	// Frete pela zona de entrega do cliente, quando houver uma que o atenda
+	zone, err := u.freightZone(ctx, req.TenantID, req.ClientID)
 	if err != nil {
 		return nil, err
 	}
-
-	// Criar itens do orçamento
-	for _, itemDTO := range req.Items {
-		item := &quoteDomain.QuoteItem{
-			TenantID:  dbtypes.UUID(req.TenantID),
-			QuoteID:   newQuote.ID,
-			ProductID: dbtypes.UUID(itemDTO.ProductID),
-			Quantity:  itemDTO.Quantity,
-			UnitPrice: itemDTO.Price,
+	if zone != nil {
+		_, freight, err := applyFreight(zone, settings, options, items)
+		if err != nil {
+			return nil, err
 		}
+		items = append(items, freight...)
+	}
+
+	if err := quoteDomain.CalculateQuoteTotals(newQuote, options, items); err != nil {
+		return nil, err
+	}
+
+	// Desconto acima do limite do tenant aguarda a aprovação de um gerente
+	initial := quoteDomain.StatusChange{
+		UserID: req.UserID,
+		Source: quoteDomain.StatusSourceUser,
+	}
+	policy := quoteDomain.DiscountPolicyFromSettings(settings)
+	if policy.RequiresApproval(newQuote) {
+		newQuote.Status = quoteDomain.QuoteStatusAwaitingApproval
+		initial.Reason = discountApprovalReason(newQuote, policy)
+	}
+
+	numbering := quoteDomain.NumberingConfigFromSettings(settings)
+	now := time.Now()
 
-		err = u.itemRepo.Create(ctx, item)
+	// Número, orçamento, itens e histórico são gravados atomicamente
+	err = u.withTransaction(ctx, func(r *txRepos) error {
+		seq, err := r.sequences.Next(ctx, req.TenantID, numbering.Scope(now))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		newQuote.Number = numbering.Format(now, seq)
+
+		if err := r.quotes.Create(ctx, newQuote); err != nil {
+			return err
 		}
+
+		for _, option := range options {
+			option.QuoteID = newQuote.ID
+			if err := r.options.Create(ctx, option); err != nil {
+				return err
+			}
+		}
+
+		// Criar itens do orçamento
+		for _, item := range items {
+			item.QuoteID = newQuote.ID
+			if err := r.items.Create(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		// Registrar status inicial no histórico
+		return recordStatusChange(ctx, r, newQuote, "", initial)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return newQuote, nil
 }
 
+// buildItem monta e precifica um item a partir do DTO e do produto cadastrado.
+func (u *UseCase) buildItem(ctx context.Context, tenantID string, itemDTO *quoteDomain.QuoteItemDTO, pricing quoteDomain.PricingConfig) (*quoteDomain.QuoteItem, error) {
+	if err := itemDTO.Validate(); err != nil {
+		return nil, err
+	}
+	if itemDTO.Kind == quoteDomain.ItemKindService {
+		return u.buildServiceItem(ctx, tenantID, itemDTO, pricing)
+	}
+
+	product, err := u.productRepo.GetByID(ctx, tenantID, itemDTO.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	unitPrice := itemDTO.Price
+	if unitPrice == 0 {
+		unitPrice = product.Price
+	}
+
+	item := &quoteDomain.QuoteItem{
+		TenantID:       dbtypes.UUID(tenantID),
+		ProductID:      &product.ID,
+		Kind:           quoteDomain.ItemKindProduct,
+		WidthCM:        itemDTO.WidthCM,
+		HeightCM:       itemDTO.HeightCM,
+		Thickness:      itemDTO.Thickness,
+		UnitPrice:      unitPrice,
+		UnitCost:       product.CostPrice,
+		Quantity:       itemDTO.Quantity,
+		Discount:       itemDTO.Discount,
+		DiscountType:   itemDTO.DiscountType,
+		EdgeType:       itemDTO.EdgeType,
+		EdgeSides:      itemDTO.EdgeSides,
+		HasCutout:      itemDTO.HasCutout,
+		ReferenceImage: itemDTO.ReferenceImage,
+		Notes:          itemDTO.Notes,
+	}
+
+	if err := quoteDomain.PriceItem(item, product.PriceType, pricing); err != nil {
+		return nil, err
+	}
+	if err := u.attachSlab(ctx, tenantID, item, itemDTO.SlabID); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// attachSlab vincula ao item a chapa escolhida, que precisa ser do produto do
+// item e estar disponível no pátio. Sem chapa, o item fica desvinculado.
+func (u *UseCase) attachSlab(ctx context.Context, tenantID string, item *quoteDomain.QuoteItem, slabID string) error {
+	if slabID == "" {
+		item.SlabID = nil
+		return nil
+	}
+
+	slab, err := u.slabRepo.GetByID(ctx, tenantID, slabID)
+	if err != nil {
+		return err
+	}
+	if slab.ProductID.String() != item.ProductIDString() {
+		return slabDomain.ErrSlabProductMismatch
+	}
+	if slab.Status != slabDomain.StatusAvailable {
+		return slabDomain.ErrSlabUnavailable
+	}
+
+	item.SlabID = &slab.ID
+	return nil
+}
+
+// pricingConfig carrega os adicionais de borda e recorte configurados para o tenant.
+func (u *UseCase) pricingConfig(ctx context.Context, tenantID string) (quoteDomain.PricingConfig, error) {
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return quoteDomain.PricingConfig{}, err
+	}
+	return quoteDomain.PricingConfigFromSettings(settings), nil
+}
+
 func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*quoteDomain.Quote, error) {
 	return u.quoteRepo.GetByID(ctx, tenantID, id)
 }
 
-func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *quoteDomain.UpdateQuoteDTO) (*quoteDomain.Quote, error) {
-	// Buscar orçamento existente (já filtra por tenant_id)
+func (u *UseCase) GetDetail(ctx context.Context, tenantID, id string) (*quoteDomain.QuoteDetailDTO, error) {
 	quote, err := u.quoteRepo.GetByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Atualizar campos
-	if req.ClientID != "" {
-		quote.ClientID = dbtypes.UUID(req.ClientID)
+	items, err := u.itemRepo.GetByQuoteID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if req.UserID != "" {
-		quote.UserID = dbtypes.UUID(req.UserID)
+
+	options, err := u.optionRepo.ListByQuoteID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := quoteDomain.CalculateOptionTotals(quote, options, items); err != nil {
+		return nil, err
+	}
+
+	return &quoteDomain.QuoteDetailDTO{
+		Quote:       quote,
+		Items:       items,
+		Options:     options,
+		PaymentPlan: quoteDomain.BuildPaymentPlan(quote.PaymentTerms, quote.TotalValue, quote.ApprovedAt),
+	}, nil
+}
+
+func (u *UseCase) GetItems(ctx context.Context, tenantID, id string) ([]*quoteDomain.QuoteItem, error) {
+	// Garante que o orçamento existe e pertence ao tenant
+	if _, err := u.quoteRepo.GetByID(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	return u.itemRepo.GetByQuoteID(ctx, id)
+}
+
+func (u *UseCase) AddItem(ctx context.Context, tenantID, quoteID string, req *quoteDomain.QuoteItemDTO) (*quoteDomain.QuoteDetailDTO, error) {
+	var detail *quoteDomain.QuoteDetailDTO
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+
+		pricing, err := u.pricingConfig(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		item, err := u.buildItem(ctx, tenantID, req, pricing)
+		if err != nil {
+			return err
+		}
+		item.QuoteID = quote.ID
+
+		// Em orçamentos com opções o item precisa indicar a qual opção pertence
+		options, err := r.options.ListByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+		if len(options) > 0 || req.OptionID != "" {
+			if req.OptionID == "" {
+				return quoteDomain.ErrOptionRequired
+			}
+			option, err := quoteDomain.FindOption(options, req.OptionID)
+			if err != nil {
+				return err
+			}
+			item.OptionID = &option.ID
+		}
+
+		if err := r.items.Create(ctx, item); err != nil {
+			return err
+		}
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+func (u *UseCase) UpdateItem(ctx context.Context, tenantID, quoteID, itemID string, req *quoteDomain.UpdateQuoteItemDTO) (*quoteDomain.QuoteDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var detail *quoteDomain.QuoteDetailDTO
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+
+		item, err := r.items.GetByID(ctx, quoteID, itemID)
+		if err != nil {
+			return err
+		}
+		if item.IsFreight() {
+			return quoteDomain.ErrFreightLine
+		}
+
+		if err := u.applyItemChanges(ctx, tenantID, item, req); err != nil {
+			return err
+		}
+
+		item.UpdatedAt = time.Now()
+		if err := r.items.Update(ctx, item); err != nil {
+			return err
+		}
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// applyItemChanges aplica ao item as alterações da requisição e o precifica de novo.
+func (u *UseCase) applyItemChanges(ctx context.Context, tenantID string, item *quoteDomain.QuoteItem, req *quoteDomain.UpdateQuoteItemDTO) error {
+	// Linhas de serviço são sempre cobradas por unidade
+	priceType := productDomain.PriceTypeUnit
+	if item.IsService() {
+		if err := u.applyServiceChanges(ctx, tenantID, item, req); err != nil {
+			return err
+		}
+	} else {
+		productID := item.ProductIDString()
+		if req.ProductID != "" {
+			productID = req.ProductID
+		}
+		product, err := u.productRepo.GetByID(ctx, tenantID, productID)
+		if err != nil {
+			return err
+		}
+
+		// Ao trocar de produto o custo passa a ser o do novo produto e, sem
+		// preço informado, o preço também
+		productChanged := product.ID.String() != item.ProductIDString()
+		if productChanged {
+			item.UnitCost = product.CostPrice
+			if req.Price == nil {
+				item.UnitPrice = product.Price
+			}
+		}
+		item.ProductID = &product.ID
+		priceType = product.PriceType
+
+		// A chapa escolhida precisa continuar sendo do produto do item
+		switch {
+		case req.SlabID != nil:
+			if err := u.attachSlab(ctx, tenantID, item, *req.SlabID); err != nil {
+				return err
+			}
+		case productChanged && item.SlabID != nil:
+			if err := u.attachSlab(ctx, tenantID, item, item.SlabID.String()); err != nil {
+				return err
+			}
+		}
+
+		if req.Price != nil {
+			item.UnitPrice = *req.Price
+			if item.UnitPrice == 0 {
+				item.UnitPrice = product.Price
+			}
+		}
+	}
+	if req.Quantity != nil {
+		item.Quantity = *req.Quantity
 	}
 	if req.Discount != nil {
-		quote.Discount = *req.Discount
+		item.Discount = *req.Discount
 	}
-	if req.Status != "" {
-		quote.Status = req.Status
+	if req.DiscountType != nil {
+		item.DiscountType = *req.DiscountType
 	}
-	if req.Notes != "" {
-		quote.Notes = req.Notes
+	if req.WidthCM != nil {
+		item.WidthCM = *req.WidthCM
+	}
+	if req.HeightCM != nil {
+		item.HeightCM = *req.HeightCM
+	}
+	if req.Thickness != nil {
+		item.Thickness = *req.Thickness
+	}
+	if req.EdgeType != nil {
+		item.EdgeType = *req.EdgeType
+	}
+	if req.EdgeSides != nil {
+		item.EdgeSides = *req.EdgeSides
+	}
+	if req.HasCutout != nil {
+		item.HasCutout = *req.HasCutout
+	}
+	if req.ReferenceImage != nil {
+		item.ReferenceImage = *req.ReferenceImage
+	}
+	if req.Notes != nil {
+		item.Notes = *req.Notes
+	}
+
+	pricing, err := u.pricingConfig(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return quoteDomain.PriceItem(item, priceType, pricing)
+}
+
+func (u *UseCase) DeleteItem(ctx context.Context, tenantID, quoteID, itemID string) (*quoteDomain.QuoteDetailDTO, error) {
+	var detail *quoteDomain.QuoteDetailDTO
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		quote, err := editableQuote(ctx, r, tenantID, quoteID)
+		if err != nil {
+			return err
+		}
+
+		item, err := r.items.GetByID(ctx, quoteID, itemID)
+		if err != nil {
+			return err
+		}
+
+		items, err := r.items.GetByQuoteID(ctx, quoteID)
+		if err != nil {
+			return err
+		}
+		// O orçamento (ou a opção do item) precisa manter ao menos um item
+		remaining := items
+		if item.OptionID != nil {
+			remaining = quoteDomain.ItemsOf(&quoteDomain.QuoteOption{ID: *item.OptionID}, items)
+		}
+		if len(remaining) <= 1 {
+			return quoteDomain.ErrInvalidItems
+		}
+
+		if err := r.items.Delete(ctx, quoteID, itemID); err != nil {
+			return err
+		}
+		detail, err = u.reprice(ctx, r, quote)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// editableQuote lê o orçamento com a linha travada e confirma que ele ainda aceita
+// alterações. Uma aprovação ou um cancelamento concorrente espera a edição terminar,
+// e a edição que chega depois deles encontra o orçamento fora de edição.
+func editableQuote(ctx context.Context, r *txRepos, tenantID, id string) (*quoteDomain.Quote, error) {
+	quote, err := r.quotes.GetForUpdate(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !quote.Status.IsEditable() {
+		return nil, quoteDomain.ErrQuoteNotEditable
+	}
+	return quote, nil
+}
+
+// recalculate recalcula Subtotal e TotalValue a partir dos itens persistidos.
+func recalculate(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) (*quoteDomain.QuoteDetailDTO, error) {
+	items, err := r.items.GetByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := r.options.ListByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := quoteDomain.CalculateQuoteTotals(quote, options, items); err != nil {
+		return nil, err
 	}
 
 	quote.UpdatedAt = time.Now()
+	if err := r.quotes.Update(ctx, quote); err != nil {
+		return nil, err
+	}
+
+	return &quoteDomain.QuoteDetailDTO{
+		Quote:       quote,
+		Items:       items,
+		Options:     options,
+		PaymentPlan: quoteDomain.BuildPaymentPlan(quote.PaymentTerms, quote.TotalValue, quote.ApprovedAt),
+	}, nil
+}
+
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *quoteDomain.UpdateQuoteDTO) (*quoteDomain.Quote, error) {
+	var quote *quoteDomain.Quote
+	err := u.withTransaction(ctx, func(r *txRepos) error {
+		// A linha travada impede que a gravação desfaça uma transição ou edição concorrente
+		locked, err := r.quotes.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		quote = locked
+
+		clientChanged, err := u.applyUpdate(ctx, r, quote, req)
+		if err != nil {
+			return err
+		}
+		quote.UpdatedAt = time.Now()
 
-	err = u.quoteRepo.Update(ctx, quote)
+		// O frete depende do endereço do cliente
+		if clientChanged {
+			_, err := u.reprice(ctx, r, quote)
+			return err
+		}
+		if err := r.quotes.Update(ctx, quote); err != nil {
+			return err
+		}
+		// Uma mudança no desconto pode exigir (ou dispensar) a aprovação do gerente
+		return u.enforceDiscountPolicy(ctx, r, quote)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -119,22 +664,282 @@ func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *quoteDom
 	return quote, nil
 }
 
+// applyUpdate aplica ao orçamento os campos informados. Cliente, vendedor, desconto,
+// condições de pagamento e validade só mudam enquanto o orçamento aceita edição;
+// as observações podem mudar a qualquer momento.
+func (u *UseCase) applyUpdate(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, req *quoteDomain.UpdateQuoteDTO) (clientChanged bool, err error) {
+	// Mudanças de status passam pela máquina de estados em UpdateStatus
+	if req.Status != "" && req.Status != quote.Status {
+		return false, quoteDomain.ErrStatusChangeNotAllowed
+	}
+
+	clientChanged = req.ClientID != "" && req.ClientID != quote.ClientID.String()
+	userChanged := req.UserID != "" && req.UserID != quote.UserID.String()
+	changesTerms := req.Discount != nil || req.DiscountType != "" || req.PaymentTerms != nil || req.ValidUntil != nil
+	if (clientChanged || userChanged || changesTerms) && !quote.Status.IsEditable() {
+		return false, quoteDomain.ErrQuoteNotEditable
+	}
+
+	if clientChanged {
+		if _, err := u.clientRepo.GetByID(ctx, quote.TenantID.String(), req.ClientID); err != nil {
+			return false, err
+		}
+		quote.ClientID = dbtypes.UUID(req.ClientID)
+	}
+	if userChanged {
+		quote.UserID = dbtypes.UUID(req.UserID)
+	}
+	if req.Discount != nil || req.DiscountType != "" {
+		if req.Discount != nil {
+			quote.Discount = *req.Discount
+		}
+		if req.DiscountType != "" {
+			quote.DiscountType = req.DiscountType
+		}
+		if err := applyDiscount(ctx, r, quote); err != nil {
+			return false, err
+		}
+	}
+	if req.PaymentTerms != nil {
+		if err := req.PaymentTerms.Validate(); err != nil {
+			return false, err
+		}
+		quote.PaymentTerms = *req.PaymentTerms
+	}
+	if req.Notes != "" {
+		quote.Notes = req.Notes
+	}
+	if req.ValidUntil != nil {
+		if req.ValidUntil.Before(time.Now()) {
+			return false, quoteDomain.ErrInvalidDate
+		}
+		quote.ValidUntil = req.ValidUntil
+	}
+	return clientChanged, nil
+}
+
+// applyDiscount recalcula os totais com o novo desconto, validando-o contra o
+// subtotal do orçamento e de cada opção.
+func applyDiscount(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) error {
+	options, err := r.options.ListByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return err
+	}
+
+	items, err := r.items.GetByQuoteID(ctx, quote.ID.String())
+	if err != nil {
+		return err
+	}
+	return quoteDomain.CalculateQuoteTotals(quote, options, items)
+}
+
+// Delete remove o orçamento e libera o material que ainda estiver reservado para ele.
 func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
-	return u.quoteRepo.Delete(ctx, tenantID, id)
+	quote, err := u.quoteRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	return u.withTransaction(ctx, func(r *txRepos) error {
+		if err := ensureNotConverted(ctx, r, quote); err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("Exclusão do orçamento %s", quote.Number)
+		if err := releaseStock(ctx, r, quote, "", reason); err != nil {
+			return err
+		}
+		return r.quotes.Delete(ctx, tenantID, id)
+	})
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, filter quoteDomain.ListFilter, limit, offset int) ([]*quoteDomain.Quote, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return u.quoteRepo.List(ctx, tenantID, filter, limit, offset)
 }
 
-func (u *UseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]*quoteDomain.Quote, error) {
-	return u.quoteRepo.List(ctx, tenantID, limit, offset)
+func (u *UseCase) Count(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (int, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+	return u.quoteRepo.Count(ctx, tenantID, filter)
+}
+
+// ConversionReport calcula os indicadores de conversão dos orçamentos que atendem ao filtro.
+func (u *UseCase) ConversionReport(ctx context.Context, tenantID string, filter quoteDomain.ListFilter) (*quoteDomain.ConversionReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	quotes, err := u.quoteRepo.ListForAnalytics(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return quoteDomain.BuildConversionReport(quotes), nil
 }
 
-func (u *UseCase) Count(ctx context.Context, tenantID string) (int, error) {
-	return u.quoteRepo.Count(ctx, tenantID)
+func (u *UseCase) UpdateStatus(ctx context.Context, tenantID, id, userID string, req *quoteDomain.UpdateQuoteStatusDTO) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return u.changeStatus(ctx, tenantID, id, quoteDomain.StatusChange{
+		Status:   req.Status,
+		Reason:   req.Reason,
+		OptionID: req.OptionID,
+		UserID:   userID,
+		Source:   quoteDomain.StatusSourceUser,
+	})
 }
 
-func (u *UseCase) UpdateStatus(ctx context.Context, tenantID, id string, req *quoteDomain.UpdateQuoteStatusDTO) error {
+// RespondAsClient registra a aprovação ou recusa feita pelo cliente no link público.
+func (u *UseCase) RespondAsClient(ctx context.Context, tenantID, id string, req *quoteDomain.ClientDecisionDTO, ipAddress, userAgent string) error {
 	if err := req.Validate(); err != nil {
 		return err
 	}
 
-	return u.quoteRepo.UpdateStatus(ctx, tenantID, id, req.Status)
+	return u.changeStatus(ctx, tenantID, id, quoteDomain.StatusChange{
+		Status:    req.Decision,
+		Reason:    req.Comment,
+		OptionID:  req.OptionID,
+		Source:    quoteDomain.StatusSourcePublicLink,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+}
+
+// changeStatus aplica a transição de status e grava o histórico na mesma transação.
+func (u *UseCase) changeStatus(ctx context.Context, tenantID, id string, change quoteDomain.StatusChange) error {
+	quote, err := u.quoteRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	from := quote.Status
+	if !from.CanTransitionTo(change.Status) {
+		return quoteDomain.ErrInvalidStatusTransition
+	}
+	// Um orçamento vencido não pode ser aprovado antes de ser renovado
+	if change.Status == quoteDomain.QuoteStatusApproved && quote.IsOverdue(time.Now()) {
+		return quoteDomain.ErrQuoteExpired
+	}
+
+	var approvedAt *time.Time
+	if change.Status == quoteDomain.QuoteStatusApproved {
+		now := time.Now()
+		approvedAt = &now
+	}
+
+	// Na aprovação, exatamente uma opção é escolhida e passa a valer para o orçamento
+	var selected *quoteDomain.QuoteOption
+	if change.Status == quoteDomain.QuoteStatusApproved {
+		options, err := u.optionRepo.ListByQuoteID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if selected, err = quoteDomain.ResolveApprovedOption(options, change.OptionID); err != nil {
+			return err
+		}
+	}
+
+	return u.withTransaction(ctx, func(r *txRepos) error {
+		if change.Status == quoteDomain.QuoteStatusCancelled {
+			if err := ensureNotConverted(ctx, r, quote); err != nil {
+				return err
+			}
+		}
+		if err := r.quotes.UpdateStatus(ctx, tenantID, id, from, change.Status, approvedAt); err != nil {
+			return err
+		}
+
+		quote.Status = change.Status
+		if selected != nil {
+			quote.ApprovedAt = approvedAt
+			quote.SelectedOptionID = &selected.ID
+			if _, err := recalculate(ctx, r, quote); err != nil {
+				return err
+			}
+		}
+		// A margem é conferida depois do recálculo, já com a opção escolhida
+		if quote.Status == quoteDomain.QuoteStatusApproved {
+			if err := u.checkMargin(ctx, quote); err != nil {
+				return err
+			}
+		}
+		if err := recordStatusChange(ctx, r, quote, from, change); err != nil {
+			return err
+		}
+		if err := u.syncStock(ctx, r, quote, change.UserID); err != nil {
+			return err
+		}
+
+		// Reaberto: o desconto volta a passar pela política do tenant
+		if quote.Status == quoteDomain.QuoteStatusPending {
+			return u.enforceDiscountPolicy(ctx, r, quote)
+		}
+		return nil
+	})
+}
+
+// ensureNotConverted impede cancelar ou excluir um orçamento que já virou pedido
+// ou entrou em produção: o material reservado para ele está em uso e a exclusão
+// apagaria o histórico das etapas da produção. O orçamento é travado antes da
+// verificação, então um pedido ou ordem aberto ao mesmo tempo não escapa dela.
+func ensureNotConverted(ctx context.Context, r *txRepos, quote *quoteDomain.Quote) error {
+	tenantID, quoteID := quote.TenantID.String(), quote.ID.String()
+	if _, err := r.quotes.GetForUpdate(ctx, tenantID, quoteID); err != nil {
+		return err
+	}
+
+	_, err := r.tx.CreateOrderRepository().GetByQuoteID(ctx, tenantID, quoteID)
+	switch err {
+	case nil:
+		return quoteDomain.ErrQuoteHasOrder
+	case orderDomain.ErrOrderNotFound:
+	default:
+		return err
+	}
+
+	workOrders, err := r.tx.CreateWorkOrderRepository().Count(ctx, tenantID, productionDomain.ListFilter{QuoteID: quoteID})
+	if err != nil {
+		return err
+	}
+	if workOrders > 0 {
+		return quoteDomain.ErrQuoteInProduction
+	}
+	return nil
+}
+
+func (u *UseCase) GetHistory(ctx context.Context, tenantID, id string) ([]*quoteDomain.QuoteStatusHistory, error) {
+	// Garante que o orçamento existe e pertence ao tenant
+	if _, err := u.quoteRepo.GetByID(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	return u.historyRepo.ListByQuoteID(ctx, tenantID, id)
+}
+
+// recordStatusChange grava a transição atual do orçamento no histórico.
+func recordStatusChange(ctx context.Context, r *txRepos, quote *quoteDomain.Quote, from quoteDomain.QuoteStatus, change quoteDomain.StatusChange) error {
+	history := &quoteDomain.QuoteStatusHistory{
+		TenantID:   quote.TenantID,
+		QuoteID:    quote.ID,
+		FromStatus: from,
+		ToStatus:   quote.Status,
+		Reason:     change.Reason,
+		Source:     change.Source,
+		IPAddress:  change.IPAddress,
+		UserAgent:  change.UserAgent,
+	}
+	if history.Source == "" {
+		history.Source = quoteDomain.StatusSourceUser
+	}
+	if change.UserID != "" {
+		uid := dbtypes.UUID(change.UserID)
+		history.UserID = &uid
+	}
+
+	return r.history.Create(ctx, history)
 }
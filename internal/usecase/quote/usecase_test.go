@@ -0,0 +1,432 @@
+package quote
+
+import (
+	"context"
+	"testing"
+
+	clientDomain "erp-api/internal/domain/client"
+	"erp-api/internal/domain/cutting"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/usecase/usecasetest"
+)
+
+const testTenant = "tenant-1"
+
+func intPtr(i int) *int { return &i }
+
+// newTestUseCase monta o caso de uso com todos os repositórios sobre o store.
+func newTestUseCase(store *usecasetest.Store) *UseCase {
+	factory := store.Factory()
+	return NewUseCase(
+		factory.CreateQuoteRepository(),
+		factory.CreateQuoteItemRepository(),
+		factory.CreateQuoteOptionRepository(),
+		factory.CreateQuoteStatusHistoryRepository(),
+		factory.CreateProductRepository(),
+		factory.CreateSlabRepository(),
+		factory.CreateServiceRepository(),
+		factory.CreateClientRepository(),
+		factory.CreateDeliveryZoneRepository(),
+		factory.CreateSettingsRepository(),
+		store,
+	).(*UseCase)
+}
+
+// seedCatalog cadastra um cliente e dois produtos vendidos por peça, com estoque.
+func seedCatalog(store *usecasetest.Store) {
+	store.Clients["client-1"] = &clientDomain.Client{
+		ID:       "client-1",
+		TenantID: testTenant,
+		Name:     "Maria",
+		City:     "Curitiba",
+		State:    "PR",
+		ZipCode:  "80000000",
+	}
+	store.Products["granito"] = &productDomain.Product{
+		ID:        "granito",
+		TenantID:  testTenant,
+		Name:      "Granito São Gabriel",
+		Price:     100,
+		CostPrice: 40,
+		PriceType: productDomain.PriceTypeUnit,
+		Stock:     10,
+	}
+	store.Products["quartzo"] = &productDomain.Product{
+		ID:        "quartzo",
+		TenantID:  testTenant,
+		Name:      "Quartzo Branco",
+		Price:     250,
+		CostPrice: 120,
+		PriceType: productDomain.PriceTypeUnit,
+		Stock:     4,
+	}
+
+	// Saldo inicial no livro, como o cadastro do produto registra
+	for _, product := range store.Products {
+		store.Movements = append(store.Movements, stockDomain.NewMovement(
+			testTenant, product.ID.String(), stockDomain.TypeEntry, product.Stock, stockDomain.ReasonOpeningBalance))
+	}
+}
+
+// createQuote cria, pelo caso de uso, um orçamento pendente com os itens informados.
+func createQuote(t *testing.T, useCase *UseCase, items ...quoteDomain.QuoteItemDTO) *quoteDomain.Quote {
+	t.Helper()
+
+	quote, err := useCase.Create(context.Background(), &quoteDomain.CreateQuoteDTO{
+		TenantID: testTenant,
+		ClientID: "client-1",
+		UserID:   "user-1",
+		Items:    items,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return quote
+}
+
+func TestUseCase_Create(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+
+	if quote.Status != quoteDomain.QuoteStatusPending {
+		t.Errorf("status = %s, want %s", quote.Status, quoteDomain.QuoteStatusPending)
+	}
+	if quote.Number == "" {
+		t.Error("quote number was not assigned")
+	}
+	if quote.Subtotal != 200 || quote.TotalValue != 200 {
+		t.Errorf("subtotal/total = %.2f/%.2f, want 200/200", quote.Subtotal, quote.TotalValue)
+	}
+	if got := len(store.ItemsOf(quote.ID.String())); got != 1 {
+		t.Errorf("items stored = %d, want 1", got)
+	}
+	if history := store.HistoryOf(quote.ID.String()); len(history) != 1 || history[0].ToStatus != quoteDomain.QuoteStatusPending {
+		t.Errorf("history = %v, want a single pending entry", history)
+	}
+}
+
+func TestUseCase_AddItem(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+
+	detail, err := useCase.AddItem(ctx, testTenant, quote.ID.String(), &quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1})
+	if err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	if len(detail.Items) != 2 {
+		t.Fatalf("items = %d, want 2", len(detail.Items))
+	}
+	if detail.Quote.Subtotal != 450 {
+		t.Errorf("subtotal = %.2f, want 450", detail.Quote.Subtotal)
+	}
+	if stored := store.Quotes[quote.ID.String()]; stored.TotalValue != 450 {
+		t.Errorf("stored total = %.2f, want 450", stored.TotalValue)
+	}
+}
+
+func TestUseCase_AddItem_Errors(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+
+	if _, err := useCase.AddItem(ctx, testTenant, quote.ID.String(), &quoteDomain.QuoteItemDTO{ProductID: "marmore", Quantity: 1}); err != productDomain.ErrProductNotFound {
+		t.Errorf("AddItem() with unknown product error = %v, want %v", err, productDomain.ErrProductNotFound)
+	}
+	if _, err := useCase.AddItem(ctx, "other-tenant", quote.ID.String(), &quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1}); err != quoteDomain.ErrQuoteNotFound {
+		t.Errorf("AddItem() from another tenant error = %v, want %v", err, quoteDomain.ErrQuoteNotFound)
+	}
+
+	store.Quotes[quote.ID.String()].Status = quoteDomain.QuoteStatusApproved
+	if _, err := useCase.AddItem(ctx, testTenant, quote.ID.String(), &quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1}); err != quoteDomain.ErrQuoteNotEditable {
+		t.Errorf("AddItem() on approved quote error = %v, want %v", err, quoteDomain.ErrQuoteNotEditable)
+	}
+	if got := len(store.ItemsOf(quote.ID.String())); got != 1 {
+		t.Errorf("items stored = %d, want 1", got)
+	}
+}
+
+func TestUseCase_UpdateItem(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	item := store.ItemsOf(quote.ID.String())[0]
+
+	detail, err := useCase.UpdateItem(ctx, testTenant, quote.ID.String(), item.ID.String(), &quoteDomain.UpdateQuoteItemDTO{Quantity: intPtr(5)})
+	if err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	if detail.Quote.Subtotal != 500 {
+		t.Errorf("subtotal = %.2f, want 500", detail.Quote.Subtotal)
+	}
+
+	// Trocar o produto traz o preço e o custo do novo produto
+	detail, err = useCase.UpdateItem(ctx, testTenant, quote.ID.String(), item.ID.String(), &quoteDomain.UpdateQuoteItemDTO{ProductID: "quartzo"})
+	if err != nil {
+		t.Fatalf("UpdateItem() changing product error = %v", err)
+	}
+	updated := store.Items[item.ID.String()]
+	if updated.ProductIDString() != "quartzo" || updated.UnitPrice != 250 || updated.UnitCost != 120 {
+		t.Errorf("item = product %s price %.2f cost %.2f, want quartzo 250 120", updated.ProductIDString(), updated.UnitPrice, updated.UnitCost)
+	}
+	if detail.Quote.Subtotal != 1250 {
+		t.Errorf("subtotal = %.2f, want 1250", detail.Quote.Subtotal)
+	}
+
+	if _, err := useCase.UpdateItem(ctx, testTenant, quote.ID.String(), "missing", &quoteDomain.UpdateQuoteItemDTO{Quantity: intPtr(1)}); err != quoteDomain.ErrQuoteItemNotFound {
+		t.Errorf("UpdateItem() on unknown item error = %v, want %v", err, quoteDomain.ErrQuoteItemNotFound)
+	}
+}
+
+func TestUseCase_DeleteItem(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase,
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2},
+		quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1},
+	)
+
+	var quartzo *quoteDomain.QuoteItem
+	for _, item := range store.ItemsOf(quote.ID.String()) {
+		if item.ProductIDString() == "quartzo" {
+			quartzo = item
+		}
+	}
+
+	detail, err := useCase.DeleteItem(ctx, testTenant, quote.ID.String(), quartzo.ID.String())
+	if err != nil {
+		t.Fatalf("DeleteItem() error = %v", err)
+	}
+	if len(detail.Items) != 1 || detail.Quote.Subtotal != 200 {
+		t.Errorf("items/subtotal = %d/%.2f, want 1/200", len(detail.Items), detail.Quote.Subtotal)
+	}
+
+	// O orçamento precisa manter ao menos um item
+	last := store.ItemsOf(quote.ID.String())[0]
+	if _, err := useCase.DeleteItem(ctx, testTenant, quote.ID.String(), last.ID.String()); err != quoteDomain.ErrInvalidItems {
+		t.Errorf("DeleteItem() on last item error = %v, want %v", err, quoteDomain.ErrInvalidItems)
+	}
+	if _, ok := store.Items[last.ID.String()]; !ok {
+		t.Error("last item must not be deleted")
+	}
+}
+
+func TestUseCase_EditItems_ApprovedMeanwhile(t *testing.T) {
+	edits := []struct {
+		name string
+		edit func(useCase *UseCase, quoteID, itemID string) error
+	}{
+		{"AddItem", func(useCase *UseCase, quoteID, itemID string) error {
+			_, err := useCase.AddItem(context.Background(), testTenant, quoteID, &quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1})
+			return err
+		}},
+		{"UpdateItem", func(useCase *UseCase, quoteID, itemID string) error {
+			_, err := useCase.UpdateItem(context.Background(), testTenant, quoteID, itemID, &quoteDomain.UpdateQuoteItemDTO{Quantity: intPtr(5)})
+			return err
+		}},
+		{"DeleteItem", func(useCase *UseCase, quoteID, itemID string) error {
+			_, err := useCase.DeleteItem(context.Background(), testTenant, quoteID, itemID)
+			return err
+		}},
+		{"AddOption", func(useCase *UseCase, quoteID, itemID string) error {
+			_, err := useCase.AddOption(context.Background(), testTenant, quoteID, &quoteDomain.CreateOptionDTO{Name: "Quartzo", ProductID: "quartzo"})
+			return err
+		}},
+		{"CalculateFreight", func(useCase *UseCase, quoteID, itemID string) error {
+			_, err := useCase.CalculateFreight(context.Background(), testTenant, quoteID)
+			return err
+		}},
+	}
+
+	for _, tt := range edits {
+		t.Run(tt.name, func(t *testing.T) {
+			store := usecasetest.NewStore()
+			seedCatalog(store)
+			useCase := newTestUseCase(store)
+
+			quote := createQuote(t, useCase,
+				quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2},
+				quoteDomain.QuoteItemDTO{ProductID: "quartzo", Quantity: 1},
+			)
+			id := quote.ID.String()
+			items := store.ItemsOf(id)
+
+			// A aprovação é confirmada antes de a edição travar o orçamento
+			store.BeforeTx = func() { store.Quotes[id].Status = quoteDomain.QuoteStatusApproved }
+
+			if err := tt.edit(useCase, id, items[0].ID.String()); err != quoteDomain.ErrQuoteNotEditable {
+				t.Fatalf("%s() error = %v, want %v", tt.name, err, quoteDomain.ErrQuoteNotEditable)
+			}
+			if store.Locks["quotes"] != 1 {
+				t.Errorf("quote locked %d times, want 1", store.Locks["quotes"])
+			}
+			if got := store.ItemsOf(id); len(got) != 2 || got[0].Total != items[0].Total {
+				t.Errorf("items = %d, want the approved items untouched", len(got))
+			}
+			if stored := store.Quotes[id]; stored.TotalValue != 450 {
+				t.Errorf("total = %.2f, want 450", stored.TotalValue)
+			}
+		})
+	}
+}
+
+func TestUseCase_Update_ClientChangeRefreshesFreight(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Clients["client-2"] = &clientDomain.Client{
+		ID:       "client-2",
+		TenantID: testTenant,
+		Name:     "João",
+		City:     "Londrina",
+		State:    "PR",
+	}
+	store.Zones = []*zoneDomain.DeliveryZone{
+		{TenantID: testTenant, Name: "Curitiba", City: "Curitiba", State: "PR", BaseFee: 50, IsActive: true},
+		{TenantID: testTenant, Name: "Londrina", City: "Londrina", State: "PR", BaseFee: 120, IsActive: true},
+	}
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	if _, err := useCase.CalculateFreight(ctx, testTenant, quote.ID.String()); err != nil {
+		t.Fatalf("CalculateFreight() error = %v", err)
+	}
+
+	updated, err := useCase.Update(ctx, testTenant, quote.ID.String(), &quoteDomain.UpdateQuoteDTO{ClientID: "client-2"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	freight := freightLine(store.ItemsOf(quote.ID.String()))
+	if freight == nil || freight.Description != "Frete - Londrina" || freight.UnitPrice != 120 {
+		t.Fatalf("freight line = %+v, want it repriced for Londrina", freight)
+	}
+	if updated.TotalValue != 320 || store.Quotes[quote.ID.String()].TotalValue != 320 {
+		t.Errorf("total = %.2f, want 320", updated.TotalValue)
+	}
+}
+
+func TestUseCase_Update_ClientAndSeller(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Clients["client-other-tenant"] = &clientDomain.Client{ID: "client-other-tenant", TenantID: "tenant-2", Name: "Ana"}
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 1})
+	id := quote.ID.String()
+
+	// Cliente de outro tenant não existe para este
+	if _, err := useCase.Update(ctx, testTenant, id, &quoteDomain.UpdateQuoteDTO{ClientID: "client-other-tenant"}); err != clientDomain.ErrClientNotFound {
+		t.Fatalf("Update(other tenant client) error = %v, want %v", err, clientDomain.ErrClientNotFound)
+	}
+
+	store.Quotes[id].Status = quoteDomain.QuoteStatusApproved
+	tests := []struct {
+		name string
+		req  quoteDomain.UpdateQuoteDTO
+	}{
+		{name: "client", req: quoteDomain.UpdateQuoteDTO{ClientID: "client-other-tenant"}},
+		{name: "seller", req: quoteDomain.UpdateQuoteDTO{UserID: "user-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := useCase.Update(ctx, testTenant, id, &tt.req); err != quoteDomain.ErrQuoteNotEditable {
+				t.Fatalf("Update() on approved quote error = %v, want %v", err, quoteDomain.ErrQuoteNotEditable)
+			}
+		})
+	}
+	if stored := store.Quotes[id]; stored.ClientID != "client-1" || stored.UserID != quote.UserID {
+		t.Errorf("client = %s, user = %s, want them unchanged", stored.ClientID, stored.UserID)
+	}
+
+	// As observações continuam editáveis
+	if _, err := useCase.Update(ctx, testTenant, id, &quoteDomain.UpdateQuoteDTO{Notes: "Entregar pela manhã"}); err != nil {
+		t.Fatalf("Update(notes) error = %v", err)
+	}
+}
+
+func TestUseCase_Update_ClientWithoutZoneDropsFreight(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	store.Clients["client-2"] = &clientDomain.Client{
+		ID:       "client-2",
+		TenantID: testTenant,
+		Name:     "João",
+		City:     "Manaus",
+		State:    "AM",
+	}
+	store.Zones = []*zoneDomain.DeliveryZone{
+		{TenantID: testTenant, Name: "Curitiba", City: "Curitiba", State: "PR", BaseFee: 50, IsActive: true},
+	}
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase, quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 2})
+	if _, err := useCase.CalculateFreight(ctx, testTenant, quote.ID.String()); err != nil {
+		t.Fatalf("CalculateFreight() error = %v", err)
+	}
+
+	updated, err := useCase.Update(ctx, testTenant, quote.ID.String(), &quoteDomain.UpdateQuoteDTO{ClientID: "client-2"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if freight := freightLine(store.ItemsOf(quote.ID.String())); freight != nil {
+		t.Fatalf("freight line = %+v, want it removed", freight)
+	}
+	if updated.TotalValue != 200 || store.Quotes[quote.ID.String()].TotalValue != 200 {
+		t.Errorf("total = %.2f, want 200 without freight", updated.TotalValue)
+	}
+}
+
+func TestUseCase_CuttingPlan_TooManyPieces(t *testing.T) {
+	store := usecasetest.NewStore()
+	seedCatalog(store)
+	useCase := newTestUseCase(store)
+	ctx := context.Background()
+
+	quote := createQuote(t, useCase,
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: 4, WidthCM: 60, HeightCM: 40},
+		quoteDomain.QuoteItemDTO{ProductID: "granito", Quantity: cutting.MaxPieces, WidthCM: 10, HeightCM: 10},
+	)
+	id := quote.ID.String()
+	store.Quotes[id].Status = quoteDomain.QuoteStatusApproved
+	req := &cutting.PlanRequest{Slabs: []cutting.SlabSize{{ProductID: "granito", WidthCM: 300, HeightCM: 180}}}
+
+	if _, err := useCase.CuttingPlan(ctx, testTenant, id, req); err != cutting.ErrTooManyPieces {
+		t.Fatalf("CuttingPlan() error = %v, want %v", err, cutting.ErrTooManyPieces)
+	}
+
+	for _, item := range store.ItemsOf(id) {
+		if item.Quantity == cutting.MaxPieces {
+			store.Items[item.ID.String()].Quantity = 10
+		}
+	}
+	plan, err := useCase.CuttingPlan(ctx, testTenant, id, req)
+	if err != nil {
+		t.Fatalf("CuttingPlan() error = %v", err)
+	}
+	if len(plan.Products) != 1 {
+		t.Errorf("products = %d, want 1", len(plan.Products))
+	}
+}
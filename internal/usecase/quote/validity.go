@@ -0,0 +1,163 @@
+package quote
+
+import (
+	"context"
+	"time"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	serviceDomain "erp-api/internal/domain/service"
+)
+
+// expirationBatchSize limita quantos orçamentos são lidos por consulta na expiração
+const expirationBatchSize = 100
+
+// Renew renova a validade do orçamento e atualiza os preços e custos dos itens
+// com os valores atuais dos produtos. Orçamentos expirados voltam a ficar pendentes
+// (ou aguardando aprovação, se o desconto passar do limite do tenant).
+func (u *UseCase) Renew(ctx context.Context, tenantID, id, userID string, req *quoteDomain.RenewQuoteDTO) (*quoteDomain.QuoteDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	settings, err := u.settingsRepo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	pricing := quoteDomain.PricingConfigFromSettings(settings)
+
+	validUntil := quoteDomain.ValidUntilFrom(time.Now(), quoteDomain.ValidityDaysFromSettings(settings))
+	if req.ValidUntil != nil {
+		validUntil = *req.ValidUntil
+	}
+
+	var detail *quoteDomain.QuoteDetailDTO
+	err = u.withTransaction(ctx, func(r *txRepos) error {
+		// A linha travada impede que uma edição ou cancelamento concorrente seja
+		// sobrescrito pelos itens e totais renovados
+		quote, err := r.quotes.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+		if !quote.Status.IsEditable() && quote.Status != quoteDomain.QuoteStatusExpired {
+			return quoteDomain.ErrQuoteNotRenewable
+		}
+
+		items, err := r.items.GetByQuoteID(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := u.renewItemPrice(ctx, tenantID, item, pricing); err != nil {
+				return err
+			}
+			if err := r.items.Update(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		from := quote.Status
+		if from == quoteDomain.QuoteStatusExpired {
+			quote.Status = quoteDomain.QuoteStatusPending
+			if err := r.quotes.UpdateStatus(ctx, tenantID, id, from, quote.Status, nil); err != nil {
+				return err
+			}
+		}
+		quote.ValidUntil = &validUntil
+
+		// O frete acompanha a tarifa atual da zona de entrega
+		if err := u.refreshFreight(ctx, r, quote); err != nil {
+			return err
+		}
+
+		if detail, err = recalculate(ctx, r, quote); err != nil {
+			return err
+		}
+
+		if from != quote.Status {
+			err := recordStatusChange(ctx, r, quote, from, quoteDomain.StatusChange{
+				Reason: "Orçamento renovado",
+				UserID: userID,
+				Source: quoteDomain.StatusSourceUser,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Os preços atualizados mudam o desconto efetivo
+		return u.enforceDiscountPolicy(ctx, r, quote)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// renewItemPrice volta o item ao preço e custo atuais do catálogo. Se o produto ou
+// o serviço foi removido, o item mantém o preço e o custo gravados no orçamento.
+func (u *UseCase) renewItemPrice(ctx context.Context, tenantID string, item *quoteDomain.QuoteItem, pricing quoteDomain.PricingConfig) error {
+	if item.IsService() {
+		// Descrição livre, sem serviço do catálogo, mantém o preço
+		if item.ServiceID != nil {
+			service, err := u.serviceRepo.GetByID(ctx, tenantID, item.ServiceID.String())
+			switch err {
+			case nil:
+				item.UnitPrice = service.Price
+				item.UnitCost = service.CostPrice
+			case serviceDomain.ErrServiceNotFound:
+			default:
+				return err
+			}
+		}
+		return quoteDomain.PriceItem(item, productDomain.PriceTypeUnit, pricing)
+	}
+
+	product, err := u.productRepo.GetByID(ctx, tenantID, item.ProductIDString())
+	switch err {
+	case nil:
+		item.UnitPrice = product.Price
+		item.UnitCost = product.CostPrice
+		return quoteDomain.PriceItem(item, product.PriceType, pricing)
+	case productDomain.ErrProductNotFound:
+		return quoteDomain.PriceItem(item, item.PriceType, pricing)
+	default:
+		return err
+	}
+}
+
+// ExpireOverdue marca como expirados os orçamentos pendentes com validade vencida,
+// em todos os tenants. Retorna quantos orçamentos foram expirados.
+func (u *UseCase) ExpireOverdue(ctx context.Context, now time.Time) (int, error) {
+	expired := 0
+
+	for {
+		quotes, err := u.quoteRepo.ListOverdue(ctx, now, expirationBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		batchExpired := 0
+		for _, quote := range quotes {
+			err := u.changeStatus(ctx, quote.TenantID.String(), quote.ID.String(), quoteDomain.StatusChange{
+				Status: quoteDomain.QuoteStatusExpired,
+				Reason: "Validade do orçamento vencida",
+				Source: quoteDomain.StatusSourceSystem,
+			})
+			switch err {
+			case nil:
+				batchExpired++
+			case quoteDomain.ErrQuoteNotFound, quoteDomain.ErrInvalidStatusTransition:
+				// Alterado ou removido desde a consulta; segue para o próximo
+			default:
+				return expired + batchExpired, err
+			}
+		}
+
+		expired += batchExpired
+		if len(quotes) < expirationBatchSize || batchExpired == 0 {
+			return expired, nil
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package quotetemplate
+
+import (
+	"context"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	templateDomain "erp-api/internal/domain/quotetemplate"
+	"erp-api/internal/infra/database"
+	quoteUseCase "erp-api/internal/usecase/quote"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	Create(ctx context.Context, tenantID string, req *templateDomain.CreateTemplateDTO) (*templateDomain.TemplateDetailDTO, error)
+	GetDetail(ctx context.Context, tenantID, id string) (*templateDomain.TemplateDetailDTO, error)
+	Update(ctx context.Context, tenantID, id string, req *templateDomain.UpdateTemplateDTO) (*templateDomain.TemplateDetailDTO, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*templateDomain.QuoteTemplate, error)
+	Count(ctx context.Context, tenantID string) (int, error)
+	Instantiate(ctx context.Context, tenantID, id, userID string, req *templateDomain.InstantiateTemplateDTO) (*quoteDomain.Quote, error)
+}
+
+type UseCase struct {
+	templateRepo templateDomain.Repository
+	itemRepo     templateDomain.ItemRepository
+	productRepo  productDomain.Repository
+	quoteUseCase quoteUseCase.UseCaseInterface
+	uow          database.UnitOfWork
+}
+
+func NewUseCase(
+	templateRepo templateDomain.Repository,
+	itemRepo templateDomain.ItemRepository,
+	productRepo productDomain.Repository,
+	quoteUseCase quoteUseCase.UseCaseInterface,
+	uow database.UnitOfWork,
+) UseCaseInterface {
+	return &UseCase{
+		templateRepo: templateRepo,
+		itemRepo:     itemRepo,
+		productRepo:  productRepo,
+		quoteUseCase: quoteUseCase,
+		uow:          uow,
+	}
+}
+
+func (u *UseCase) Create(ctx context.Context, tenantID string, req *templateDomain.CreateTemplateDTO) (*templateDomain.TemplateDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := u.ensureUniqueName(ctx, tenantID, name, ""); err != nil {
+		return nil, err
+	}
+
+	items, err := u.buildItems(ctx, tenantID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &templateDomain.QuoteTemplate{
+		TenantID:    dbtypes.UUID(tenantID),
+		Name:        name,
+		Description: req.Description,
+		Notes:       req.Notes,
+	}
+
+	err = u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		if err := tx.CreateQuoteTemplateRepository().Create(ctx, template); err != nil {
+			return err
+		}
+		return createItems(ctx, tx.CreateQuoteTemplateItemRepository(), template, items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateDomain.TemplateDetailDTO{QuoteTemplate: template, Items: items}, nil
+}
+
+func (u *UseCase) GetDetail(ctx context.Context, tenantID, id string) (*templateDomain.TemplateDetailDTO, error) {
+	template, err := u.templateRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.GetByTemplateID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateDomain.TemplateDetailDTO{QuoteTemplate: template, Items: items}, nil
+}
+
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *templateDomain.UpdateTemplateDTO) (*templateDomain.TemplateDetailDTO, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	template, err := u.templateRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := u.ensureUniqueName(ctx, tenantID, name, id); err != nil {
+			return nil, err
+		}
+		template.Name = name
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Notes != nil {
+		template.Notes = *req.Notes
+	}
+
+	var items []*templateDomain.QuoteTemplateItem
+	if req.Items != nil {
+		items, err = u.buildItems(ctx, tenantID, *req.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Os itens são substituídos por completo junto com os dados do modelo
+	err = u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		if err := tx.CreateQuoteTemplateRepository().Update(ctx, template); err != nil {
+			return err
+		}
+		if items == nil {
+			return nil
+		}
+
+		itemRepo := tx.CreateQuoteTemplateItemRepository()
+		if err := itemRepo.DeleteByTemplateID(ctx, id); err != nil {
+			return err
+		}
+		return createItems(ctx, itemRepo, template, items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		if items, err = u.itemRepo.GetByTemplateID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return &templateDomain.TemplateDetailDTO{QuoteTemplate: template, Items: items}, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
+	return u.templateRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, limit, offset int) ([]*templateDomain.QuoteTemplate, error) {
+	return u.templateRepo.List(ctx, tenantID, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string) (int, error) {
+	return u.templateRepo.Count(ctx, tenantID)
+}
+
+// Instantiate cria um orçamento pendente a partir do modelo. Os itens saem com as
+// medidas padrão do modelo e os preços atuais dos produtos.
+func (u *UseCase) Instantiate(ctx context.Context, tenantID, id, userID string, req *templateDomain.InstantiateTemplateDTO) (*quoteDomain.Quote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	detail, err := u.GetDetail(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	create := &quoteDomain.CreateQuoteDTO{
+		TenantID:     tenantID,
+		ClientID:     req.ClientID,
+		UserID:       userID,
+		Discount:     req.Discount,
+		DiscountType: req.DiscountType,
+		Notes:        detail.Notes,
+		ValidUntil:   req.ValidUntil,
+		Items:        make([]quoteDomain.QuoteItemDTO, 0, len(detail.Items)),
+	}
+	if req.Notes != nil {
+		create.Notes = *req.Notes
+	}
+
+	// Sem Price o orçamento usa o preço de cadastro do produto
+	for _, item := range detail.Items {
+		create.Items = append(create.Items, quoteDomain.QuoteItemDTO{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			WidthCM:   item.WidthCM,
+			HeightCM:  item.HeightCM,
+			Thickness: item.Thickness,
+			EdgeType:  item.EdgeType,
+			EdgeSides: item.EdgeSides,
+			HasCutout: item.HasCutout,
+			Notes:     item.Notes,
+		})
+	}
+
+	return u.quoteUseCase.Create(ctx, create)
+}
+
+// ensureUniqueName garante que não existe outro modelo com o mesmo nome no tenant
+func (u *UseCase) ensureUniqueName(ctx context.Context, tenantID, name, currentID string) error {
+	existing, err := u.templateRepo.GetByName(ctx, tenantID, name)
+	if err != nil && err != templateDomain.ErrTemplateNotFound {
+		return err
+	}
+	if existing != nil && existing.ID.String() != currentID {
+		return templateDomain.ErrTemplateAlreadyExists
+	}
+	return nil
+}
+
+// buildItems monta os itens do modelo, conferindo que os produtos pertencem ao tenant
+func (u *UseCase) buildItems(ctx context.Context, tenantID string, dtos []templateDomain.TemplateItemDTO) ([]*templateDomain.QuoteTemplateItem, error) {
+	items := make([]*templateDomain.QuoteTemplateItem, 0, len(dtos))
+	for i, dto := range dtos {
+		product, err := u.productRepo.GetByID(ctx, tenantID, dto.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, &templateDomain.QuoteTemplateItem{
+			TenantID:  dbtypes.UUID(tenantID),
+			ProductID: product.ID,
+			Position:  i + 1,
+			Quantity:  dto.Quantity,
+			WidthCM:   dto.WidthCM,
+			HeightCM:  dto.HeightCM,
+			Thickness: dto.Thickness,
+			EdgeType:  dto.EdgeType,
+			EdgeSides: dto.EdgeSides,
+			HasCutout: dto.HasCutout,
+			Notes:     dto.Notes,
+		})
+	}
+	return items, nil
+}
+
+func createItems(ctx context.Context, repo templateDomain.ItemRepository, template *templateDomain.QuoteTemplate, items []*templateDomain.QuoteTemplateItem) error {
+	for _, item := range items {
+		item.TemplateID = template.ID
+		if err := repo.Create(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	serviceDomain "erp-api/internal/domain/service"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	Create(ctx context.Context, tenantID string, req *serviceDomain.CreateServiceDTO) (*serviceDomain.Service, error)
+	GetByID(ctx context.Context, tenantID, id string) (*serviceDomain.Service, error)
+	Update(ctx context.Context, tenantID, id string, req *serviceDomain.UpdateServiceDTO) (*serviceDomain.Service, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, activeOnly bool, limit, offset int) ([]*serviceDomain.Service, error)
+	Count(ctx context.Context, tenantID string, activeOnly bool) (int, error)
+}
+
+type UseCase struct {
+	serviceRepo serviceDomain.Repository
+}
+
+func NewUseCase(serviceRepo serviceDomain.Repository) UseCaseInterface {
+	return &UseCase{
+		serviceRepo: serviceRepo,
+	}
+}
+
+func (u *UseCase) Create(ctx context.Context, tenantID string, req *serviceDomain.CreateServiceDTO) (*serviceDomain.Service, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := u.ensureUniqueName(ctx, tenantID, name, ""); err != nil {
+		return nil, err
+	}
+
+	service := &serviceDomain.Service{
+		TenantID:    dbtypes.UUID(tenantID),
+		Name:        name,
+		Description: req.Description,
+		Unit:        strings.TrimSpace(req.Unit),
+		Price:       req.Price,
+		CostPrice:   req.CostPrice,
+		IsActive:    true,
+	}
+
+	if err := u.serviceRepo.Create(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*serviceDomain.Service, error) {
+	return u.serviceRepo.GetByID(ctx, tenantID, id)
+}
+
+// Update altera o serviço; linhas já orçadas mantêm a descrição e o preço gravados
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *serviceDomain.UpdateServiceDTO) (*serviceDomain.Service, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	service, err := u.serviceRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := u.ensureUniqueName(ctx, tenantID, name, id); err != nil {
+			return nil, err
+		}
+		service.Name = name
+	}
+	if req.Description != nil {
+		service.Description = *req.Description
+	}
+	if req.Unit != nil {
+		service.Unit = strings.TrimSpace(*req.Unit)
+	}
+	if req.Price != nil {
+		service.Price = *req.Price
+	}
+	if req.CostPrice != nil {
+		service.CostPrice = *req.CostPrice
+	}
+	if req.IsActive != nil {
+		service.IsActive = *req.IsActive
+	}
+
+	if err := u.serviceRepo.Update(ctx, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
+	return u.serviceRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, activeOnly bool, limit, offset int) ([]*serviceDomain.Service, error) {
+	return u.serviceRepo.List(ctx, tenantID, activeOnly, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string, activeOnly bool) (int, error) {
+	return u.serviceRepo.Count(ctx, tenantID, activeOnly)
+}
+
+// ensureUniqueName garante que não existe outro serviço com o mesmo nome no tenant
+func (u *UseCase) ensureUniqueName(ctx context.Context, tenantID, name, currentID string) error {
+	existing, err := u.serviceRepo.GetByName(ctx, tenantID, name)
+	if err != nil && err != serviceDomain.ErrServiceNotFound {
+		return err
+	}
+	if existing != nil && existing.ID.String() != currentID {
+		return serviceDomain.ErrServiceAlreadyExists
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	settingsDomain "erp-api/internal/domain/settings"
+	"erp-api/internal/infra/database"
 )
 
 type UseCaseInterface interface {
@@ -13,11 +14,13 @@ type UseCaseInterface interface {
 
 type UseCase struct {
 	settingsRepo settingsDomain.Repository
+	uow          database.UnitOfWork
 }
 
-func NewUseCase(settingsRepo settingsDomain.Repository) UseCaseInterface {
+func NewUseCase(settingsRepo settingsDomain.Repository, uow database.UnitOfWork) UseCaseInterface {
 	return &UseCase{
 		settingsRepo: settingsRepo,
+		uow:          uow,
 	}
 }
 
@@ -38,7 +41,10 @@ func (u *UseCase) Update(ctx context.Context, req *settingsDomain.UpdateSettings
 		return nil, err
 	}
 
-	err := u.settingsRepo.Update(ctx, req)
+	// A atualização apaga e recria as chaves do tenant; deve ser atômica
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		return tx.CreateSettingsRepository().Update(ctx, req)
+	})
 	if err != nil {
 		return nil, err
 	}
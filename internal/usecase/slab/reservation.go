@@ -0,0 +1,89 @@
+package slab
+
+import (
+	"context"
+	"sort"
+
+	slabDomain "erp-api/internal/domain/slab"
+	"erp-api/internal/infra/database"
+	"erp-api/internal/utils/dbtypes"
+)
+
+// Reserve separa para o orçamento as chapas escolhidas nos itens. slabs liga cada
+// chapa ao produto do item, que ela precisa ter. A linha de cada chapa é travada,
+// então duas aprovações concorrentes não reservam a mesma chapa. Deve ser chamado
+// dentro da transação do orçamento.
+func Reserve(ctx context.Context, tx database.RepositoryFactory, tenantID, quoteID string, slabs map[string]string) error {
+	slabRepo := tx.CreateSlabRepository()
+
+	slabIDs := make([]string, 0, len(slabs))
+	for slabID := range slabs {
+		slabIDs = append(slabIDs, slabID)
+	}
+	sort.Strings(slabIDs)
+
+	for _, slabID := range slabIDs {
+		slab, err := slabRepo.GetForUpdate(ctx, tenantID, slabID)
+		if err != nil {
+			return err
+		}
+		if slab.ProductID.String() != slabs[slabID] {
+			return slabDomain.ErrSlabProductMismatch
+		}
+		if err := slab.ReserveFor(dbtypes.UUID(quoteID)); err != nil {
+			return err
+		}
+		if err := slabRepo.Update(ctx, slab); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release devolve ao pátio as chapas que seguem reservadas para o orçamento.
+func Release(ctx context.Context, tx database.RepositoryFactory, tenantID, quoteID string) error {
+	slabRepo := tx.CreateSlabRepository()
+
+	slabs, err := slabRepo.ListAll(ctx, tenantID, slabDomain.ListFilter{
+		QuoteID: quoteID,
+		Status:  slabDomain.StatusReserved,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, slab := range slabs {
+		if err := slab.ChangeStatus(slabDomain.StatusAvailable); err != nil {
+			return err
+		}
+		if err := slabRepo.Update(ctx, slab); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Consume dá baixa nas chapas reservadas para o orçamento, que passam a usadas
+// e podem ter os retalhos cadastrados. Deve ser chamado dentro da transação do
+// pedido que entra em produção.
+func Consume(ctx context.Context, tx database.RepositoryFactory, tenantID, quoteID string) error {
+	slabRepo := tx.CreateSlabRepository()
+
+	slabs, err := slabRepo.ListAll(ctx, tenantID, slabDomain.ListFilter{
+		QuoteID: quoteID,
+		Status:  slabDomain.StatusReserved,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, slab := range slabs {
+		if err := slab.UseFor(dbtypes.UUID(quoteID)); err != nil {
+			return err
+		}
+		if err := slabRepo.Update(ctx, slab); err != nil {
+			return err
+		}
+	}
+	return nil
+}
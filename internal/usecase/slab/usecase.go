@@ -0,0 +1,265 @@
+package slab
+
+import (
+	"context"
+	"strings"
+
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	"erp-api/internal/infra/database"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type UseCaseInterface interface {
+	Create(ctx context.Context, tenantID string, req *slabDomain.CreateSlabDTO) (*slabDomain.Slab, error)
+	GetByID(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error)
+	Update(ctx context.Context, tenantID, id string, req *slabDomain.UpdateSlabDTO) (*slabDomain.Slab, error)
+	Delete(ctx context.Context, tenantID, id string) error
+	List(ctx context.Context, tenantID string, filter slabDomain.ListFilter, limit, offset int) ([]*slabDomain.Slab, error)
+	Count(ctx context.Context, tenantID string, filter slabDomain.ListFilter) (int, error)
+	AddPhoto(ctx context.Context, tenantID, id string, req *slabDomain.AddPhotoDTO) (*slabDomain.Photo, error)
+	DeletePhoto(ctx context.Context, tenantID, id, photoID string) error
+	RegisterRemnants(ctx context.Context, tenantID, id string, req *slabDomain.RegisterRemnantsDTO) ([]*slabDomain.Slab, error)
+	SearchRemnants(ctx context.Context, tenantID, quoteID, itemID string, allowRotation bool, marginCM float64) (*slabDomain.RemnantSearchDTO, error)
+}
+
+type UseCase struct {
+	slabRepo      slabDomain.Repository
+	photoRepo     slabDomain.PhotoRepository
+	productRepo   productDomain.Repository
+	quoteRepo     quoteDomain.Repository
+	quoteItemRepo quoteDomain.ItemRepository
+	uow           database.UnitOfWork
+}
+
+func NewUseCase(
+	slabRepo slabDomain.Repository,
+	photoRepo slabDomain.PhotoRepository,
+	productRepo productDomain.Repository,
+	quoteRepo quoteDomain.Repository,
+	quoteItemRepo quoteDomain.ItemRepository,
+	uow database.UnitOfWork,
+) UseCaseInterface {
+	return &UseCase{
+		slabRepo:      slabRepo,
+		photoRepo:     photoRepo,
+		productRepo:   productRepo,
+		quoteRepo:     quoteRepo,
+		quoteItemRepo: quoteItemRepo,
+		uow:           uow,
+	}
+}
+
+// Create cadastra uma chapa do produto, já com as fotos informadas.
+func (u *UseCase) Create(ctx context.Context, tenantID string, req *slabDomain.CreateSlabDTO) (*slabDomain.Slab, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	product, err := u.productRepo.GetByID(ctx, tenantID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	slab := &slabDomain.Slab{
+		TenantID:  dbtypes.UUID(tenantID),
+		ProductID: product.ID,
+		Lot:       strings.TrimSpace(req.Lot),
+		Bundle:    strings.TrimSpace(req.Bundle),
+		WidthCM:   req.WidthCM,
+		HeightCM:  req.HeightCM,
+		Thickness: req.Thickness,
+		Location:  strings.TrimSpace(req.Location),
+		Status:    slabDomain.StatusAvailable,
+		Notes:     req.Notes,
+	}
+
+	err = u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		if err := tx.CreateSlabRepository().Create(ctx, slab); err != nil {
+			return err
+		}
+
+		photoRepo := tx.CreateSlabPhotoRepository()
+		for _, url := range req.PhotoURLs {
+			photo := slabDomain.NewPhoto(slab, url, "")
+			if err := photoRepo.Create(ctx, photo); err != nil {
+				return err
+			}
+			slab.Photos = append(slab.Photos, photo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slab, nil
+}
+
+func (u *UseCase) GetByID(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error) {
+	return u.slabRepo.GetByID(ctx, tenantID, id)
+}
+
+// Update altera o cadastro da chapa. A linha é travada para não sobrescrever uma
+// reserva feita ao mesmo tempo pela aprovação de um orçamento.
+func (u *UseCase) Update(ctx context.Context, tenantID, id string, req *slabDomain.UpdateSlabDTO) (*slabDomain.Slab, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var slab *slabDomain.Slab
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		slabRepo := tx.CreateSlabRepository()
+
+		var err error
+		slab, err = slabRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Lot != nil {
+			slab.Lot = strings.TrimSpace(*req.Lot)
+		}
+		if req.Bundle != nil {
+			slab.Bundle = strings.TrimSpace(*req.Bundle)
+		}
+		if req.Thickness != nil {
+			slab.Thickness = *req.Thickness
+		}
+		if req.Location != nil {
+			slab.Location = strings.TrimSpace(*req.Location)
+		}
+		if req.Notes != nil {
+			slab.Notes = *req.Notes
+		}
+		if req.Status != nil {
+			if err := slab.ChangeStatusManually(*req.Status); err != nil {
+				return err
+			}
+		}
+
+		return slabRepo.Update(ctx, slab)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slab, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, tenantID, id string) error {
+	return u.slabRepo.Delete(ctx, tenantID, id)
+}
+
+func (u *UseCase) List(ctx context.Context, tenantID string, filter slabDomain.ListFilter, limit, offset int) ([]*slabDomain.Slab, error) {
+	return u.slabRepo.List(ctx, tenantID, filter, limit, offset)
+}
+
+func (u *UseCase) Count(ctx context.Context, tenantID string, filter slabDomain.ListFilter) (int, error) {
+	return u.slabRepo.Count(ctx, tenantID, filter)
+}
+
+func (u *UseCase) AddPhoto(ctx context.Context, tenantID, id string, req *slabDomain.AddPhotoDTO) (*slabDomain.Photo, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	slab, err := u.slabRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	photo := slabDomain.NewPhoto(slab, req.URL, req.Caption)
+	if err := u.photoRepo.Create(ctx, photo); err != nil {
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+func (u *UseCase) DeletePhoto(ctx context.Context, tenantID, id, photoID string) error {
+	return u.photoRepo.Delete(ctx, tenantID, id, photoID)
+}
+
+// RegisterRemnants cadastra as sobras do corte como novas chapas e marca a
+// chapa de origem como usada. A chapa de origem é travada durante o cadastro.
+func (u *UseCase) RegisterRemnants(ctx context.Context, tenantID, id string, req *slabDomain.RegisterRemnantsDTO) ([]*slabDomain.Slab, error) {
+	var remnants []*slabDomain.Slab
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		slabRepo := tx.CreateSlabRepository()
+
+		parent, err := slabRepo.GetForUpdate(ctx, tenantID, id)
+		if err != nil {
+			return err
+		}
+
+		remnants, err = slabDomain.NewRemnants(parent, req)
+		if err != nil {
+			return err
+		}
+
+		for _, remnant := range remnants {
+			if err := slabRepo.Create(ctx, remnant); err != nil {
+				return err
+			}
+		}
+
+		if parent.Status == slabDomain.StatusUsed {
+			return nil
+		}
+		parent.Status = slabDomain.StatusUsed
+		return slabRepo.Update(ctx, parent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return remnants, nil
+}
+
+// SearchRemnants procura retalhos disponíveis do mesmo produto e espessura
+// grandes o bastante para a peça do item do orçamento.
+func (u *UseCase) SearchRemnants(ctx context.Context, tenantID, quoteID, itemID string, allowRotation bool, marginCM float64) (*slabDomain.RemnantSearchDTO, error) {
+	if _, err := u.quoteRepo.GetByID(ctx, tenantID, quoteID); err != nil {
+		return nil, err
+	}
+
+	item, err := u.quoteItemRepo.GetByID(ctx, quoteID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.ProductID == nil {
+		return nil, slabDomain.ErrItemWithoutProduct
+	}
+	if item.WidthCM <= 0 || item.HeightCM <= 0 {
+		return nil, slabDomain.ErrItemWithoutDimension
+	}
+
+	search := slabDomain.RemnantSearch{
+		ProductID:     item.ProductID.String(),
+		WidthCM:       item.WidthCM,
+		HeightCM:      item.HeightCM,
+		Thickness:     item.Thickness,
+		AllowRotation: allowRotation,
+		MarginCM:      marginCM,
+	}
+
+	candidates, err := u.slabRepo.ListAll(ctx, tenantID, slabDomain.ListFilter{
+		ProductID:    search.ProductID,
+		Status:       slabDomain.StatusAvailable,
+		RemnantsOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &slabDomain.RemnantSearchDTO{
+		QuoteItemID: item.ID.String(),
+		ProductID:   search.ProductID,
+		WidthCM:     item.WidthCM,
+		HeightCM:    item.HeightCM,
+		Thickness:   item.Thickness,
+		Matches:     slabDomain.FindRemnants(candidates, search),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package stock
+
+import (
+	"context"
+	"sort"
+
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+)
+
+// Reserve lança uma reserva por produto para o documento informado. Com
+// blockOversell, a reserva é recusada quando o saldo disponível (estoque menos
+// reservas) ficaria negativo. Deve ser chamado dentro da transação do documento:
+// Post trava a linha do produto, então os totais lidos em seguida não mudam até
+// o commit e aprovações concorrentes do mesmo produto esperam umas pelas outras.
+func Reserve(ctx context.Context, tx database.RepositoryFactory, tenantID, userID, referenceType, referenceID, reason string, needs map[string]int, blockOversell bool) error {
+	movementRepo := tx.CreateStockMovementRepository()
+
+	for _, productID := range sortedProducts(needs) {
+		movement := stockDomain.NewMovement(tenantID, productID, stockDomain.TypeReservation, needs[productID], reason).
+			WithReference(referenceType, referenceID).
+			WithUser(userID)
+		if err := Post(ctx, tx, movement); err != nil {
+			return err
+		}
+
+		if !blockOversell {
+			continue
+		}
+		onHand, reserved, err := movementRepo.Totals(ctx, tenantID, productID)
+		if err != nil {
+			return err
+		}
+		if onHand-reserved < 0 {
+			return stockDomain.ErrInsufficientStock
+		}
+	}
+	return nil
+}
+
+// Release devolve ao disponível tudo o que segue reservado para o documento.
+// Documentos sem reserva não geram lançamentos.
+func Release(ctx context.Context, tx database.RepositoryFactory, tenantID, userID, referenceType, referenceID, reason string) error {
+	reserved, err := tx.CreateStockMovementRepository().ReservedByReference(ctx, tenantID, referenceType, referenceID)
+	if err != nil {
+		return err
+	}
+
+	for _, productID := range sortedProducts(reserved) {
+		movement := stockDomain.NewMovement(tenantID, productID, stockDomain.TypeReservation, -reserved[productID], reason).
+			WithReference(referenceType, referenceID).
+			WithUser(userID)
+		if err := Post(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Consume dá baixa no que segue reservado para o documento: cada reserva é
+// liberada e vira uma saída da mesma quantidade, então o material sai do saldo
+// físico uma única vez e o reservado volta a zero. Com blockOversell, a baixa é
+// recusada quando o saldo físico ficaria negativo; sem a trava, o tenant vende sem
+// saldo e o produto fica negativo até a próxima entrada. Deve ser chamado dentro
+// da transação do documento.
+func Consume(ctx context.Context, tx database.RepositoryFactory, tenantID, userID, referenceType, referenceID, reason string, blockOversell bool) error {
+	reserved, err := tx.CreateStockMovementRepository().ReservedByReference(ctx, tenantID, referenceType, referenceID)
+	if err != nil {
+		return err
+	}
+
+	for _, productID := range sortedProducts(reserved) {
+		release := stockDomain.NewMovement(tenantID, productID, stockDomain.TypeReservation, -reserved[productID], reason).
+			WithReference(referenceType, referenceID).
+			WithUser(userID)
+		if err := Post(ctx, tx, release); err != nil {
+			return err
+		}
+
+		exit := stockDomain.NewMovement(tenantID, productID, stockDomain.TypeExit, reserved[productID], reason).
+			WithReference(referenceType, referenceID).
+			WithUser(userID)
+		if err := post(ctx, tx, exit, !blockOversell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedProducts ordena os produtos para que Post trave as linhas sempre na
+// mesma ordem entre transações concorrentes, evitando deadlocks.
+func sortedProducts(quantities map[string]int) []string {
+	productIDs := make([]string, 0, len(quantities))
+	for productID, quantity := range quantities {
+		if quantity != 0 {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	sort.Strings(productIDs)
+	return productIDs
+}
@@ -0,0 +1,147 @@
+package stock
+
+import (
+	"context"
+
+	productDomain "erp-api/internal/domain/product"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+)
+
+type UseCaseInterface interface {
+	Record(ctx context.Context, tenantID, userID, productID string, req *stockDomain.CreateMovementDTO) (*stockDomain.Movement, error)
+	History(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter, limit, offset int) ([]*stockDomain.Movement, error)
+	CountHistory(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter) (int, error)
+	Reconcile(ctx context.Context, tenantID, productID string) (*stockDomain.ReconcileDTO, error)
+}
+
+type UseCase struct {
+	productRepo  productDomain.Repository
+	movementRepo stockDomain.Repository
+	uow          database.UnitOfWork
+}
+
+func NewUseCase(productRepo productDomain.Repository, movementRepo stockDomain.Repository, uow database.UnitOfWork) UseCaseInterface {
+	return &UseCase{
+		productRepo:  productRepo,
+		movementRepo: movementRepo,
+		uow:          uow,
+	}
+}
+
+// Record lança um movimento manual no estoque do produto. Reservas só nascem da
+// aprovação do orçamento.
+func (u *UseCase) Record(ctx context.Context, tenantID, userID, productID string, req *stockDomain.CreateMovementDTO) (*stockDomain.Movement, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	movement := stockDomain.NewMovement(tenantID, productID, req.Type, req.Quantity, req.Reason).
+		WithReference(req.ReferenceType, req.ReferenceID).
+		WithUser(userID)
+
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		return Post(ctx, tx, movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// Post grava o lançamento e atualiza o saldo do produto com ele. Deve ser
+// chamado dentro de uma transação, junto com a operação que gerou o lançamento.
+func Post(ctx context.Context, tx database.RepositoryFactory, movement *stockDomain.Movement) error {
+	return post(ctx, tx, movement, false)
+}
+
+// post é o Post que, com allowNegative, aceita uma saída que deixa o saldo
+// físico negativo, como a baixa de material vendido sem saldo.
+func post(ctx context.Context, tx database.RepositoryFactory, movement *stockDomain.Movement, allowNegative bool) error {
+	productRepo := tx.CreateProductRepository()
+	movementRepo := tx.CreateStockMovementRepository()
+	tenantID, productID := movement.TenantID.String(), movement.ProductID.String()
+
+	// O UPDATE atômico trava a linha do produto até o fim da transação, então
+	// o saldo lido em seguida já inclui este lançamento. Reservas não mudam o
+	// saldo e são serializadas pelo SELECT ... FOR UPDATE logo abaixo
+	delta := movement.StockDelta()
+	if delta != 0 {
+		if err := productRepo.AddStock(ctx, tenantID, productID, delta); err != nil {
+			return err
+		}
+	}
+
+	product, err := productRepo.GetForUpdate(ctx, tenantID, productID)
+	if err != nil {
+		return err
+	}
+	if delta < 0 && product.Stock < 0 && !allowNegative {
+		return stockDomain.ErrInsufficientStock
+	}
+
+	if movement.Type == stockDomain.TypeReservation && movement.Quantity < 0 {
+		_, reserved, err := movementRepo.Totals(ctx, tenantID, productID)
+		if err != nil {
+			return err
+		}
+		if reserved+movement.Quantity < 0 {
+			return stockDomain.ErrInvalidReservation
+		}
+	}
+
+	movement.Balance = product.Stock
+	return movementRepo.Create(ctx, movement)
+}
+
+func (u *UseCase) History(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter, limit, offset int) ([]*stockDomain.Movement, error) {
+	if _, err := u.productRepo.GetByID(ctx, tenantID, productID); err != nil {
+		return nil, err
+	}
+	return u.movementRepo.List(ctx, tenantID, productID, filter, limit, offset)
+}
+
+func (u *UseCase) CountHistory(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter) (int, error) {
+	return u.movementRepo.Count(ctx, tenantID, productID, filter)
+}
+
+// Reconcile recalcula o saldo pelo livro de estoque e corrige o produto quando
+// os dois divergem. O livro é a fonte da verdade.
+func (u *UseCase) Reconcile(ctx context.Context, tenantID, productID string) (*stockDomain.ReconcileDTO, error) {
+	var result *stockDomain.ReconcileDTO
+
+	err := u.uow.Transaction(ctx, func(tx database.RepositoryFactory) error {
+		productRepo := tx.CreateProductRepository()
+
+		// Trava o produto: um lançamento concorrente não pode cair entre a soma
+		// do livro e a correção do saldo
+		product, err := productRepo.GetForUpdate(ctx, tenantID, productID)
+		if err != nil {
+			return err
+		}
+
+		onHand, reserved, err := tx.CreateStockMovementRepository().Totals(ctx, tenantID, productID)
+		if err != nil {
+			return err
+		}
+
+		result = &stockDomain.ReconcileDTO{
+			ProductID:   productID,
+			Stock:       product.Stock,
+			LedgerStock: onHand,
+			Reserved:    reserved,
+		}
+		if product.Stock == onHand {
+			return nil
+		}
+
+		result.Corrected = true
+		return productRepo.SetStock(ctx, tenantID, productID, onHand)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,196 @@
+package usecasetest
+
+import (
+	"context"
+
+	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	productDomain "erp-api/internal/domain/product"
+	serviceDomain "erp-api/internal/domain/service"
+	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type ProductRepository struct {
+	productDomain.Repository
+	store *Store
+}
+
+func (m *ProductRepository) GetByID(ctx context.Context, tenantID, id string) (*productDomain.Product, error) {
+	p, ok := m.store.Products[id]
+	if !ok || p.TenantID.String() != tenantID {
+		return nil, productDomain.ErrProductNotFound
+	}
+	copied := *p
+	return &copied, nil
+}
+
+func (m *ProductRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*productDomain.Product, error) {
+	product, err := m.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	m.store.Locks["products"]++
+	return product, nil
+}
+
+func (m *ProductRepository) Update(ctx context.Context, product *productDomain.Product) error {
+	if _, ok := m.store.Products[product.ID.String()]; !ok {
+		return productDomain.ErrProductNotFound
+	}
+	copied := *product
+	m.store.Products[product.ID.String()] = &copied
+	return nil
+}
+
+func (m *ProductRepository) Delete(ctx context.Context, tenantID, id string) error {
+	if _, err := m.GetByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+	delete(m.store.Products, id)
+	m.store.Deleted = append(m.store.Deleted, id)
+	return nil
+}
+
+func (m *ProductRepository) AddStock(ctx context.Context, tenantID, id string, delta int) error {
+	p, ok := m.store.Products[id]
+	if !ok || p.TenantID.String() != tenantID {
+		return productDomain.ErrProductNotFound
+	}
+	p.Stock += delta
+	return nil
+}
+
+type MovementRepository struct {
+	stockDomain.Repository
+	store *Store
+}
+
+func (m *MovementRepository) Create(ctx context.Context, movement *stockDomain.Movement) error {
+	if err := m.store.fail("movements.Create"); err != nil {
+		return err
+	}
+	if movement.ID == "" {
+		movement.ID = dbtypes.NewUUID()
+	}
+	m.store.Movements = append(m.store.Movements, movement)
+	return nil
+}
+
+func (m *MovementRepository) Totals(ctx context.Context, tenantID, productID string) (int, int, error) {
+	var onHand, reserved int
+	for _, movement := range m.store.Movements {
+		if movement.ProductID.String() != productID {
+			continue
+		}
+		if movement.Type == stockDomain.TypeReservation {
+			reserved += movement.Quantity
+		} else {
+			onHand += movement.Quantity
+		}
+	}
+	return onHand, reserved, nil
+}
+
+func (m *MovementRepository) ReservedByReference(ctx context.Context, tenantID, referenceType, referenceID string) (map[string]int, error) {
+	return m.store.ReservedFor(referenceID), nil
+}
+
+func (m *MovementRepository) Count(ctx context.Context, tenantID, productID string, filter stockDomain.ListFilter) (int, error) {
+	count := 0
+	for _, movement := range m.store.Movements {
+		if movement.TenantID.String() == tenantID && movement.ProductID.String() == productID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type ClientRepository struct {
+	clientDomain.Repository
+	store *Store
+}
+
+func (m *ClientRepository) GetByID(ctx context.Context, tenantID, id string) (*clientDomain.Client, error) {
+	c, ok := m.store.Clients[id]
+	if !ok || c.TenantID.String() != tenantID {
+		return nil, clientDomain.ErrClientNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+type ZoneRepository struct {
+	zoneDomain.Repository
+	store *Store
+}
+
+func (m *ZoneRepository) ListActive(ctx context.Context, tenantID string) ([]*zoneDomain.DeliveryZone, error) {
+	return m.store.Zones, nil
+}
+
+type SettingsRepository struct {
+	settingsDomain.Repository
+	store *Store
+}
+
+func (m *SettingsRepository) Get(ctx context.Context, tenantID string) (map[string]string, error) {
+	return m.store.Settings, nil
+}
+
+type ServiceRepository struct {
+	serviceDomain.Repository
+}
+
+type SlabRepository struct {
+	slabDomain.Repository
+	store *Store
+}
+
+func (m *SlabRepository) GetByID(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error) {
+	slab, ok := m.store.Slabs[id]
+	if !ok || slab.TenantID.String() != tenantID {
+		return nil, slabDomain.ErrSlabNotFound
+	}
+	copied := *slab
+	return &copied, nil
+}
+
+func (m *SlabRepository) Create(ctx context.Context, slab *slabDomain.Slab) error {
+	if slab.ID == "" {
+		slab.ID = dbtypes.NewUUID()
+	}
+	copied := *slab
+	m.store.Slabs[slab.ID.String()] = &copied
+	return nil
+}
+
+func (m *SlabRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*slabDomain.Slab, error) {
+	return m.GetByID(ctx, tenantID, id)
+}
+
+func (m *SlabRepository) Update(ctx context.Context, slab *slabDomain.Slab) error {
+	if _, ok := m.store.Slabs[slab.ID.String()]; !ok {
+		return slabDomain.ErrSlabNotFound
+	}
+	copied := *slab
+	m.store.Slabs[slab.ID.String()] = &copied
+	return nil
+}
+
+func (m *SlabRepository) ListAll(ctx context.Context, tenantID string, filter slabDomain.ListFilter) ([]*slabDomain.Slab, error) {
+	var slabs []*slabDomain.Slab
+	for _, slab := range m.store.Slabs {
+		if slab.TenantID.String() != tenantID || (filter.Status != "" && slab.Status != filter.Status) {
+			continue
+		}
+		if filter.QuoteID != "" && (slab.QuoteID == nil || slab.QuoteID.String() != filter.QuoteID) {
+			continue
+		}
+		copied := *slab
+		slabs = append(slabs, &copied)
+	}
+	return slabs, nil
+}
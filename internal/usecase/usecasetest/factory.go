@@ -0,0 +1,88 @@
+package usecasetest
+
+import (
+	"context"
+
+	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
+	productDomain "erp-api/internal/domain/product"
+	productionDomain "erp-api/internal/domain/production"
+	quoteDomain "erp-api/internal/domain/quote"
+	serviceDomain "erp-api/internal/domain/service"
+	settingsDomain "erp-api/internal/domain/settings"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+)
+
+// Factory expõe os repositórios do Store. Os repositórios que os testes não
+// usam ficam no RepositoryFactory embutido (nil).
+type Factory struct {
+	database.RepositoryFactory
+	store *Store
+}
+
+func (f *Factory) Transaction(ctx context.Context, fn func(tx database.RepositoryFactory) error) error {
+	return fn(f)
+}
+
+func (f *Factory) CreateClientRepository() clientDomain.Repository {
+	return &ClientRepository{store: f.store}
+}
+
+func (f *Factory) CreateProductRepository() productDomain.Repository {
+	return &ProductRepository{store: f.store}
+}
+
+func (f *Factory) CreateQuoteRepository() quoteDomain.Repository {
+	return &QuoteRepository{store: f.store}
+}
+
+func (f *Factory) CreateQuoteItemRepository() quoteDomain.ItemRepository {
+	return &QuoteItemRepository{store: f.store}
+}
+
+func (f *Factory) CreateQuoteOptionRepository() quoteDomain.OptionRepository {
+	return &QuoteOptionRepository{store: f.store}
+}
+
+func (f *Factory) CreateQuoteStatusHistoryRepository() quoteDomain.StatusHistoryRepository {
+	return &QuoteHistoryRepository{store: f.store}
+}
+
+func (f *Factory) CreateQuoteSequenceRepository() quoteDomain.SequenceRepository {
+	return &QuoteSequenceRepository{store: f.store}
+}
+
+func (f *Factory) CreateServiceRepository() serviceDomain.Repository {
+	return &ServiceRepository{}
+}
+
+func (f *Factory) CreateDeliveryZoneRepository() zoneDomain.Repository {
+	return &ZoneRepository{store: f.store}
+}
+
+func (f *Factory) CreateOrderRepository() orderDomain.Repository {
+	return &OrderRepository{store: f.store}
+}
+
+func (f *Factory) CreateOrderItemRepository() orderDomain.ItemRepository {
+	return &OrderItemRepository{store: f.store}
+}
+
+func (f *Factory) CreateWorkOrderRepository() productionDomain.Repository {
+	return &WorkOrderRepository{store: f.store}
+}
+
+func (f *Factory) CreateStockMovementRepository() stockDomain.Repository {
+	return &MovementRepository{store: f.store}
+}
+
+func (f *Factory) CreateSlabRepository() slabDomain.Repository {
+	return &SlabRepository{store: f.store}
+}
+
+func (f *Factory) CreateSettingsRepository() settingsDomain.Repository {
+	return &SettingsRepository{store: f.store}
+}
@@ -0,0 +1,97 @@
+package usecasetest
+
+import (
+	"context"
+
+	orderDomain "erp-api/internal/domain/order"
+	productionDomain "erp-api/internal/domain/production"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type OrderRepository struct {
+	orderDomain.Repository
+	store *Store
+}
+
+func (m *OrderRepository) Create(ctx context.Context, order *orderDomain.Order) error {
+	if order.ID == "" {
+		order.ID = dbtypes.NewUUID()
+	}
+	copied := *order
+	m.store.Orders[order.ID.String()] = &copied
+	return nil
+}
+
+func (m *OrderRepository) GetByID(ctx context.Context, tenantID, id string) (*orderDomain.Order, error) {
+	o, ok := m.store.Orders[id]
+	if !ok || o.TenantID.String() != tenantID {
+		return nil, orderDomain.ErrOrderNotFound
+	}
+	copied := *o
+	return &copied, nil
+}
+
+func (m *OrderRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*orderDomain.Order, error) {
+	order, err := m.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	m.store.Locks["orders"]++
+	return order, nil
+}
+
+func (m *OrderRepository) GetByQuoteID(ctx context.Context, tenantID, quoteID string) (*orderDomain.Order, error) {
+	for _, o := range m.store.Orders {
+		if o.TenantID.String() == tenantID && o.QuoteID.String() == quoteID {
+			copied := *o
+			return &copied, nil
+		}
+	}
+	return nil, orderDomain.ErrOrderNotFound
+}
+
+func (m *OrderRepository) Update(ctx context.Context, order *orderDomain.Order) error {
+	if _, ok := m.store.Orders[order.ID.String()]; !ok {
+		return orderDomain.ErrOrderNotFound
+	}
+	copied := *order
+	m.store.Orders[order.ID.String()] = &copied
+	return nil
+}
+
+type OrderItemRepository struct {
+	orderDomain.ItemRepository
+	store *Store
+}
+
+func (m *OrderItemRepository) Create(ctx context.Context, item *orderDomain.OrderItem) error {
+	if err := m.store.fail("orderItems.Create"); err != nil {
+		return err
+	}
+	if item.ID == "" {
+		item.ID = dbtypes.NewUUID()
+	}
+	copied := *item
+	m.store.OrderItems = append(m.store.OrderItems, &copied)
+	return nil
+}
+
+func (m *OrderItemRepository) GetByOrderID(ctx context.Context, orderID string) ([]*orderDomain.OrderItem, error) {
+	var items []*orderDomain.OrderItem
+	for _, item := range m.store.OrderItems {
+		if item.OrderID.String() == orderID {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	return items, nil
+}
+
+type WorkOrderRepository struct {
+	productionDomain.Repository
+	store *Store
+}
+
+func (m *WorkOrderRepository) Count(ctx context.Context, tenantID string, filter productionDomain.ListFilter) (int, error) {
+	return m.store.WorkOrders[filter.QuoteID], nil
+}
@@ -0,0 +1,215 @@
+package usecasetest
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	quoteDomain "erp-api/internal/domain/quote"
+	"erp-api/internal/utils/dbtypes"
+)
+
+type QuoteRepository struct {
+	quoteDomain.Repository
+	store *Store
+}
+
+func (m *QuoteRepository) Create(ctx context.Context, quote *quoteDomain.Quote) error {
+	if err := m.store.fail("quotes.Create"); err != nil {
+		return err
+	}
+	if quote.ID == "" {
+		quote.ID = dbtypes.NewUUID()
+	}
+	copied := *quote
+	m.store.Quotes[quote.ID.String()] = &copied
+	return nil
+}
+
+func (m *QuoteRepository) GetByID(ctx context.Context, tenantID, id string) (*quoteDomain.Quote, error) {
+	q, ok := m.store.Quotes[id]
+	if !ok || q.TenantID.String() != tenantID {
+		return nil, quoteDomain.ErrQuoteNotFound
+	}
+	copied := *q
+	return &copied, nil
+}
+
+func (m *QuoteRepository) GetForUpdate(ctx context.Context, tenantID, id string) (*quoteDomain.Quote, error) {
+	quote, err := m.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	m.store.Locks["quotes"]++
+	return quote, nil
+}
+
+// Update grava o orçamento sem tocar no status, que só muda por UpdateStatus.
+func (m *QuoteRepository) Update(ctx context.Context, quote *quoteDomain.Quote) error {
+	if err := m.store.fail("quotes.Update"); err != nil {
+		return err
+	}
+	stored, ok := m.store.Quotes[quote.ID.String()]
+	if !ok {
+		return quoteDomain.ErrQuoteNotFound
+	}
+	copied := *quote
+	copied.Status, copied.ApprovedAt = stored.Status, stored.ApprovedAt
+	m.store.Quotes[quote.ID.String()] = &copied
+	return nil
+}
+
+func (m *QuoteRepository) Delete(ctx context.Context, tenantID, id string) error {
+	if _, err := m.GetByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+	delete(m.store.Quotes, id)
+	return nil
+}
+
+func (m *QuoteRepository) UpdateStatus(ctx context.Context, tenantID, id string, from, status quoteDomain.QuoteStatus, approvedAt *time.Time) error {
+	if err := m.store.fail("quotes.UpdateStatus"); err != nil {
+		return err
+	}
+	q, ok := m.store.Quotes[id]
+	if !ok || q.TenantID.String() != tenantID || q.Status != from {
+		return quoteDomain.ErrInvalidStatusTransition
+	}
+	q.Status = status
+	if approvedAt != nil {
+		q.ApprovedAt = approvedAt
+	}
+	return nil
+}
+
+func (m *QuoteRepository) ApproveDiscount(ctx context.Context, quote *quoteDomain.Quote) error {
+	q, ok := m.store.Quotes[quote.ID.String()]
+	if !ok || q.TenantID != quote.TenantID {
+		return quoteDomain.ErrQuoteNotFound
+	}
+	q.DiscountApprovedPercent = quote.DiscountApprovedPercent
+	q.DiscountApprovedBy = quote.DiscountApprovedBy
+	q.DiscountApprovedAt = quote.DiscountApprovedAt
+	q.UpdatedAt = quote.UpdatedAt
+	return nil
+}
+
+type QuoteItemRepository struct {
+	store *Store
+}
+
+func (m *QuoteItemRepository) Create(ctx context.Context, item *quoteDomain.QuoteItem) error {
+	if err := m.store.fail("items.Create"); err != nil {
+		return err
+	}
+	if item.ID == "" {
+		item.ID = dbtypes.NewUUID()
+	}
+	copied := *item
+	m.store.Items[item.ID.String()] = &copied
+	return nil
+}
+
+func (m *QuoteItemRepository) GetByID(ctx context.Context, quoteID, id string) (*quoteDomain.QuoteItem, error) {
+	item, ok := m.store.Items[id]
+	if !ok || item.QuoteID.String() != quoteID {
+		return nil, quoteDomain.ErrQuoteItemNotFound
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (m *QuoteItemRepository) GetByQuoteID(ctx context.Context, quoteID string) ([]*quoteDomain.QuoteItem, error) {
+	return m.store.ItemsOf(quoteID), nil
+}
+
+func (m *QuoteItemRepository) Update(ctx context.Context, item *quoteDomain.QuoteItem) error {
+	if err := m.store.fail("items.Update"); err != nil {
+		return err
+	}
+	if _, ok := m.store.Items[item.ID.String()]; !ok {
+		return quoteDomain.ErrQuoteItemNotFound
+	}
+	copied := *item
+	m.store.Items[item.ID.String()] = &copied
+	return nil
+}
+
+func (m *QuoteItemRepository) Delete(ctx context.Context, quoteID, id string) error {
+	if err := m.store.fail("items.Delete"); err != nil {
+		return err
+	}
+	if _, err := m.GetByID(ctx, quoteID, id); err != nil {
+		return err
+	}
+	delete(m.store.Items, id)
+	return nil
+}
+
+func (m *QuoteItemRepository) DeleteByQuoteID(ctx context.Context, quoteID string) error {
+	for _, item := range m.store.ItemsOf(quoteID) {
+		delete(m.store.Items, item.ID.String())
+	}
+	return nil
+}
+
+func (m *QuoteItemRepository) DeleteByOptionID(ctx context.Context, quoteID, optionID string) error {
+	for _, item := range m.store.ItemsOf(quoteID) {
+		if item.OptionID != nil && item.OptionID.String() == optionID {
+			delete(m.store.Items, item.ID.String())
+		}
+	}
+	return nil
+}
+
+type QuoteOptionRepository struct {
+	quoteDomain.OptionRepository
+	store *Store
+}
+
+func (m *QuoteOptionRepository) Create(ctx context.Context, option *quoteDomain.QuoteOption) error {
+	if option.ID == "" {
+		option.ID = dbtypes.NewUUID()
+	}
+	copied := *option
+	m.store.Options[option.ID.String()] = &copied
+	return nil
+}
+
+func (m *QuoteOptionRepository) ListByQuoteID(ctx context.Context, quoteID string) ([]*quoteDomain.QuoteOption, error) {
+	var options []*quoteDomain.QuoteOption
+	for _, option := range m.store.Options {
+		if option.QuoteID.String() == quoteID {
+			copied := *option
+			options = append(options, &copied)
+		}
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Position < options[j].Position })
+	return options, nil
+}
+
+type QuoteHistoryRepository struct {
+	store *Store
+}
+
+func (m *QuoteHistoryRepository) Create(ctx context.Context, history *quoteDomain.QuoteStatusHistory) error {
+	if err := m.store.fail("history.Create"); err != nil {
+		return err
+	}
+	m.store.History = append(m.store.History, history)
+	return nil
+}
+
+func (m *QuoteHistoryRepository) ListByQuoteID(ctx context.Context, tenantID, quoteID string) ([]*quoteDomain.QuoteStatusHistory, error) {
+	return m.store.HistoryOf(quoteID), nil
+}
+
+type QuoteSequenceRepository struct {
+	store *Store
+}
+
+func (m *QuoteSequenceRepository) Next(ctx context.Context, tenantID, scope string) (int64, error) {
+	key := tenantID + "/" + scope
+	m.store.Sequences[key]++
+	return m.store.Sequences[key], nil
+}
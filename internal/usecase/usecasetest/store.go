@@ -0,0 +1,172 @@
+// Package usecasetest guarda em memória os repositórios usados nos testes dos
+// casos de uso, para que cada pacote não monte a sua própria cópia.
+package usecasetest
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	clientDomain "erp-api/internal/domain/client"
+	zoneDomain "erp-api/internal/domain/deliveryzone"
+	orderDomain "erp-api/internal/domain/order"
+	productDomain "erp-api/internal/domain/product"
+	quoteDomain "erp-api/internal/domain/quote"
+	slabDomain "erp-api/internal/domain/slab"
+	stockDomain "erp-api/internal/domain/stock"
+	"erp-api/internal/infra/database"
+)
+
+// ErrInjected é o erro devolvido pela operação indicada em Store.FailOn.
+var ErrInjected = errors.New("injected failure")
+
+// Store guarda em memória o que os repositórios gravariam no banco. Cada
+// leitura devolve uma cópia, como uma consulta nova, e Transaction desfaz tudo
+// o que foi gravado quando fn retorna erro.
+type Store struct {
+	Quotes    map[string]*quoteDomain.Quote
+	Items     map[string]*quoteDomain.QuoteItem
+	Options   map[string]*quoteDomain.QuoteOption
+	History   []*quoteDomain.QuoteStatusHistory
+	Sequences map[string]int64
+	Products  map[string]*productDomain.Product
+	Clients   map[string]*clientDomain.Client
+	Zones     []*zoneDomain.DeliveryZone
+	Settings  map[string]string
+	Movements []*stockDomain.Movement
+	Slabs     map[string]*slabDomain.Slab
+	// Orders guarda os pedidos de venda pelo ID do pedido
+	Orders     map[string]*orderDomain.Order
+	OrderItems []*orderDomain.OrderItem
+	// WorkOrders conta as ordens de produção de cada orçamento
+	WorkOrders map[string]int
+	// Deleted guarda os IDs dos produtos removidos
+	Deleted []string
+
+	// FailOn faz a operação indicada (ex.: "items.Create") retornar ErrInjected
+	FailOn string
+	// BeforeTx simula outra transação confirmada entre a leitura do caso de uso
+	// e o início da transação dele; roda uma única vez
+	BeforeTx func()
+	// Locks conta as leituras com a linha travada por repositório (ex.: "quotes")
+	Locks map[string]int
+}
+
+func NewStore() *Store {
+	return &Store{
+		Quotes:     make(map[string]*quoteDomain.Quote),
+		Items:      make(map[string]*quoteDomain.QuoteItem),
+		Options:    make(map[string]*quoteDomain.QuoteOption),
+		Sequences:  make(map[string]int64),
+		Products:   make(map[string]*productDomain.Product),
+		Clients:    make(map[string]*clientDomain.Client),
+		Settings:   make(map[string]string),
+		Slabs:      make(map[string]*slabDomain.Slab),
+		Orders:     make(map[string]*orderDomain.Order),
+		WorkOrders: make(map[string]int),
+		Locks:      make(map[string]int),
+	}
+}
+
+// Factory devolve os repositórios sobre o store, fora de transação.
+func (s *Store) Factory() *Factory {
+	return &Factory{store: s}
+}
+
+// Transaction roda fn e, se ela falhar, restaura o estado anterior.
+func (s *Store) Transaction(ctx context.Context, fn func(tx database.RepositoryFactory) error) error {
+	if before := s.BeforeTx; before != nil {
+		s.BeforeTx = nil
+		before()
+	}
+	snapshot := s.clone()
+	if err := fn(s.Factory()); err != nil {
+		*s = *snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *Store) fail(op string) error {
+	if s.FailOn == op {
+		return ErrInjected
+	}
+	return nil
+}
+
+func (s *Store) clone() *Store {
+	c := &Store{
+		Quotes:     copyMap(s.Quotes),
+		Items:      copyMap(s.Items),
+		Options:    copyMap(s.Options),
+		History:    append([]*quoteDomain.QuoteStatusHistory(nil), s.History...),
+		Sequences:  make(map[string]int64, len(s.Sequences)),
+		Products:   copyMap(s.Products),
+		Clients:    s.Clients,
+		Zones:      s.Zones,
+		Settings:   s.Settings,
+		Movements:  append([]*stockDomain.Movement(nil), s.Movements...),
+		Slabs:      copyMap(s.Slabs),
+		Orders:     copyMap(s.Orders),
+		OrderItems: append([]*orderDomain.OrderItem(nil), s.OrderItems...),
+		WorkOrders: make(map[string]int, len(s.WorkOrders)),
+		Deleted:    append([]string(nil), s.Deleted...),
+		FailOn:     s.FailOn,
+		Locks:      s.Locks,
+	}
+	for key, seq := range s.Sequences {
+		c.Sequences[key] = seq
+	}
+	for quoteID, count := range s.WorkOrders {
+		c.WorkOrders[quoteID] = count
+	}
+	return c
+}
+
+// copyMap copia o mapa e cada valor, para que o snapshot não veja as gravações.
+func copyMap[T any](src map[string]*T) map[string]*T {
+	dst := make(map[string]*T, len(src))
+	for id, value := range src {
+		copied := *value
+		dst[id] = &copied
+	}
+	return dst
+}
+
+// ItemsOf devolve os itens do orçamento ordenados pelo ID.
+func (s *Store) ItemsOf(quoteID string) []*quoteDomain.QuoteItem {
+	var items []*quoteDomain.QuoteItem
+	for _, item := range s.Items {
+		if item.QuoteID.String() == quoteID {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items
+}
+
+// HistoryOf devolve o histórico de status do orçamento na ordem de gravação.
+func (s *Store) HistoryOf(quoteID string) []*quoteDomain.QuoteStatusHistory {
+	var history []*quoteDomain.QuoteStatusHistory
+	for _, h := range s.History {
+		if h.QuoteID.String() == quoteID {
+			history = append(history, h)
+		}
+	}
+	return history
+}
+
+// ReservedFor soma, por produto, o que ainda está reservado para o orçamento.
+func (s *Store) ReservedFor(quoteID string) map[string]int {
+	reserved := make(map[string]int)
+	for _, m := range s.Movements {
+		if m.Type == stockDomain.TypeReservation && m.ReferenceID == quoteID {
+			reserved[m.ProductID.String()] += m.Quantity
+			if reserved[m.ProductID.String()] == 0 {
+				delete(reserved, m.ProductID.String())
+			}
+		}
+	}
+	return reserved
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -80,7 +81,9 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	// Tokens de acesso sempre identificam o usuário; links públicos de orçamento
+	// (audience quote-share) nunca valem como login
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && claims.UserID != "" && !slices.Contains(claims.Audience, quoteShareAudience) {
 		return claims, nil
 	}
 
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// quoteShareAudience separa os links públicos dos tokens de acesso:
+// um token de orçamento nunca é aceito como login e vice-versa.
+const quoteShareAudience = "quote-share"
+
+type QuoteShareClaims struct {
+	TenantID string `json:"tenant_id"`
+	QuoteID  string `json:"quote_id"`
+	jwt.RegisteredClaims
+}
+
+// ShareTokenManager assina e valida os links públicos de orçamento
+type ShareTokenManager struct {
+	secretKey string
+	expiry    time.Duration
+}
+
+func NewShareTokenManager(secretKey string, expiry time.Duration) *ShareTokenManager {
+	return &ShareTokenManager{
+		secretKey: secretKey,
+		expiry:    expiry,
+	}
+}
+
+// GenerateQuoteToken gera um token assinado que dá acesso somente leitura ao orçamento.
+func (m *ShareTokenManager) GenerateQuoteToken(tenantID, quoteID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.expiry)
+
+	claims := &QuoteShareClaims{
+		TenantID: tenantID,
+		QuoteID:  quoteID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "erp-api",
+			Audience:  jwt.ClaimStrings{quoteShareAudience},
+			Subject:   quoteID,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(m.secretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (m *ShareTokenManager) ValidateQuoteToken(tokenString string) (*QuoteShareClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &QuoteShareClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(m.secretKey), nil
+	}, jwt.WithAudience(quoteShareAudience))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*QuoteShareClaims); ok && token.Valid && claims.QuoteID != "" && claims.TenantID != "" {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid token")
+}
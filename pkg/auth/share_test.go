@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShareTokenManager_RoundTrip(t *testing.T) {
+	manager := NewShareTokenManager("share-secret", time.Hour)
+
+	token, expiresAt, err := manager.GenerateQuoteToken("tenant-123", "quote-456")
+	if err != nil {
+		t.Fatalf("GenerateQuoteToken() error = %v", err)
+	}
+	if time.Until(expiresAt) <= 0 {
+		t.Errorf("expected expiration in the future, got %v", expiresAt)
+	}
+
+	claims, err := manager.ValidateQuoteToken(token)
+	if err != nil {
+		t.Fatalf("ValidateQuoteToken() error = %v", err)
+	}
+	if claims.TenantID != "tenant-123" || claims.QuoteID != "quote-456" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestShareTokenManager_RejectsExpiredToken(t *testing.T) {
+	manager := NewShareTokenManager("share-secret", -time.Minute)
+
+	token, _, err := manager.GenerateQuoteToken("tenant-123", "quote-456")
+	if err != nil {
+		t.Fatalf("GenerateQuoteToken() error = %v", err)
+	}
+
+	if _, err := manager.ValidateQuoteToken(token); err == nil {
+		t.Error("expected expired token to be rejected")
+	}
+}
+
+func TestShareTokenManager_RejectsAccessToken(t *testing.T) {
+	secret := "shared-secret"
+	jwtManager := NewJWTManager(secret, time.Hour, time.Hour)
+	shareManager := NewShareTokenManager(secret, time.Hour)
+
+	accessToken, err := jwtManager.GenerateAccessToken("user-123", "tenant-123", "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	if _, err := shareManager.ValidateQuoteToken(accessToken); err == nil {
+		t.Error("expected access token to be rejected as share token")
+	}
+}
+
+func TestJWTManager_RejectsShareToken(t *testing.T) {
+	secret := "shared-secret"
+	jwtManager := NewJWTManager(secret, time.Hour, time.Hour)
+	shareManager := NewShareTokenManager(secret, time.Hour)
+
+	shareToken, _, err := shareManager.GenerateQuoteToken("tenant-123", "quote-456")
+	if err != nil {
+		t.Fatalf("GenerateQuoteToken() error = %v", err)
+	}
+
+	// Mesmo assinado com o segredo dos tokens de acesso, o link não vale como login
+	if _, err := jwtManager.ValidateToken(shareToken); err == nil {
+		t.Error("expected share token to be rejected as access token")
+	}
+	if _, err := jwtManager.RefreshAccessToken(shareToken); err == nil {
+		t.Error("expected share token to be rejected as refresh token")
+	}
+}
+
+func TestJWTManager_RejectsTokenWithoutUser(t *testing.T) {
+	jwtManager := NewJWTManager("secret", time.Hour, time.Hour)
+
+	token, err := jwtManager.GenerateAccessToken("", "tenant-123", "test@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	if _, err := jwtManager.ValidateToken(token); err == nil {
+		t.Error("expected token without user_id to be rejected")
+	}
+}